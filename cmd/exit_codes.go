@@ -17,6 +17,7 @@ const (
 	ExitAuth     = 3 // Authentication failure (missing, unauthorized, forbidden)
 	ExitNotFound = 4 // Resource not found
 	ExitConflict = 5 // Conflict / resource already exists
+	ExitReadOnly = 6 // Mutation blocked by read-only mode
 
 	// HTTP 4xx range: 10 + (status - 400)
 	// Note: 404 and 409 are mapped to ExitNotFound and ExitConflict above.
@@ -55,6 +56,9 @@ func ExitCodeFromError(err error) int {
 	if errors.Is(err, asc.ErrConflict) {
 		return ExitConflict
 	}
+	if errors.Is(err, asc.ErrReadOnly) {
+		return ExitReadOnly
+	}
 
 	// Check for APIError with status code or known code
 	if apiErr, ok := errors.AsType[*asc.APIError](err); ok {
@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"flag"
+	"fmt"
 	"net/http"
 	"os"
 	"os/exec"
@@ -62,6 +63,11 @@ func TestExitCodeFromError(t *testing.T) {
 			err:      asc.ErrConflict,
 			expected: ExitConflict,
 		},
+		{
+			name:     "ReadOnlyError returns read-only",
+			err:      fmt.Errorf("wrapped: %w", &asc.ReadOnlyError{Method: "POST", Path: "/v1/apps", Source: "--read-only"}),
+			expected: ExitReadOnly,
+		},
 		{
 			name:     "generic error returns generic error",
 			err:      errors.New("something went wrong"),
@@ -0,0 +1,501 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// profileFanoutExecutable resolves the binary re-run once per profile.
+var profileFanoutExecutable = os.Executable
+
+// maxProfileFanoutParallel caps how many profile runs execute at once.
+const maxProfileFanoutParallel = 4
+
+// profileFanoutFileFlags are root flags naming a file the run writes. Each
+// profile run gets its own copy of the path so concurrent runs never share
+// a file.
+var profileFanoutFileFlags = map[string]bool{
+	"trace-file":  true,
+	"record":      true,
+	"report-file": true,
+}
+
+// profileRun is the outcome of running the command under one profile.
+type profileRun struct {
+	Profile  string
+	Stdout   []byte
+	ExitCode int
+	Err      error
+}
+
+// profileFanoutError reports a profile whose run failed.
+type profileFanoutError struct {
+	Profile  string `json:"profile"`
+	Error    string `json:"error"`
+	ExitCode int    `json:"exitCode"`
+}
+
+// profileFanoutFormats are the output formats printProfileFanout can merge.
+var profileFanoutFormats = map[string]bool{
+	"json":     true,
+	"ndjson":   true,
+	"table":    true,
+	"markdown": true,
+	"csv":      true,
+	"tsv":      true,
+}
+
+// runProfileFanout re-runs the command once per profile, concurrently (at
+// most maxProfileFanoutParallel at a time) and in read-only mode, with JSON
+// output. The per-profile results are merged into one list whose items carry
+// a "profile" field (or column) and printed in the format the caller asked
+// for.
+func runProfileFanout(ctx context.Context, rootFlags *flag.FlagSet, args []string, profiles []string) int {
+	fanoutArgs := profileFanoutChildArgs(rootFlags, args)
+	if !profileFanoutFormats[fanoutArgs.format] {
+		fmt.Fprintf(os.Stderr, "Error: --output %s is not supported with --all-profiles or --profiles (use json, ndjson, table, markdown, csv or tsv)\n", fanoutArgs.format)
+		return ExitUsage
+	}
+	executable, err := profileFanoutExecutable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: resolve asc executable: %v\n", err)
+		return ExitError
+	}
+
+	runs := make([]profileRun, len(profiles))
+	var stderrMu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxProfileFanoutParallel)
+	for i, profile := range profiles {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			runs[i] = runProfileChild(ctx, executable, profile, fanoutArgs.forProfile(profile), &stderrMu)
+		}()
+	}
+	wg.Wait()
+
+	items, failures := mergeProfileRuns(runs)
+	for _, failure := range failures {
+		fmt.Fprintf(os.Stderr, "Error: profile %s: %s\n", failure.Profile, failure.Error)
+	}
+	if err := printProfileFanout(items, failures, fanoutArgs.format, fanoutArgs.pretty); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	if len(failures) > 0 {
+		return failures[0].ExitCode
+	}
+	return ExitSuccess
+}
+
+// profileFanoutArgs is the command line each profile run receives.
+type profileFanoutArgs struct {
+	// root holds the caller's root flags, minus the fan-out flags, with
+	// file flags written as --name=value.
+	root []string
+	// command holds the command path and its flags, with --output json.
+	command []string
+	format  string
+	pretty  bool
+}
+
+// forProfile returns the arguments for one profile's run. File flags get a
+// per-profile path, such as trace.alpha.json for --trace-file trace.json.
+func (a profileFanoutArgs) forProfile(profile string) []string {
+	args := make([]string, 0, len(a.root)+len(a.command))
+	for _, arg := range a.root {
+		if name, value, hasValue := splitFlagArg(arg); hasValue && profileFanoutFileFlags[name] && value != "" {
+			arg = "--" + name + "=" + profileFilePath(value, profile)
+		}
+		args = append(args, arg)
+	}
+	return append(args, a.command...)
+}
+
+// profileFilePath inserts the profile name before the file extension.
+func profileFilePath(path, profile string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, profile)
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + safe + ext
+}
+
+// profileFanoutChildArgs strips the fan-out flags from the root flags and
+// forces JSON output, recording the format and --pretty the caller asked
+// for. Root flags are walked with rootFlags so that flag values are not
+// mistaken for the command name.
+func profileFanoutChildArgs(rootFlags *flag.FlagSet, args []string) profileFanoutArgs {
+	var result profileFanoutArgs
+	i := 0
+	for i < len(args) && args[i] != "--" {
+		next, consumed := consumeFlagToken(rootFlags, args[i], args, i)
+		if !consumed {
+			break
+		}
+		name, value, hasValue := splitFlagArg(args[i])
+		switch {
+		case name == "all-profiles" || name == "profiles":
+		case profileFanoutFileFlags[name]:
+			if !hasValue && next > i+1 {
+				value = args[i+1]
+			}
+			result.root = append(result.root, "--"+name+"="+value)
+		default:
+			result.root = append(result.root, args[i:next]...)
+		}
+		i = next
+	}
+
+	format := ""
+	for ; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := splitFlagArg(arg)
+		switch name {
+		case "output":
+			if !hasValue && i+1 < len(args) {
+				i++
+				value = args[i]
+			}
+			format = value
+			result.command = append(result.command, "--output", "json")
+			continue
+		case "pretty":
+			result.pretty = !hasValue || value == "true" || value == "1"
+			continue
+		}
+		result.command = append(result.command, arg)
+	}
+	if format == "" {
+		format = shared.DefaultOutputFormat()
+	}
+	result.format = shared.NormalizeOutputFormat(format)
+	return result
+}
+
+// splitFlagArg returns the flag name for "-name", "--name" or "--name=value".
+func splitFlagArg(arg string) (name, value string, hasValue bool) {
+	if !strings.HasPrefix(arg, "-") || arg == "-" || arg == "--" {
+		return "", "", false
+	}
+	name = strings.TrimLeft(arg, "-")
+	if before, after, ok := strings.Cut(name, "="); ok {
+		return before, after, true
+	}
+	return name, "", false
+}
+
+func runProfileChild(ctx context.Context, executable, profile string, args []string, stderrMu *sync.Mutex) profileRun {
+	command := exec.CommandContext(ctx, executable, append([]string{"--profile", profile, "--read-only"}, args...)...)
+	command.Env = append(os.Environ(), "ASC_DEFAULT_OUTPUT=json", "ASC_PROFILE=")
+	var stdout bytes.Buffer
+	command.Stdout = &stdout
+	stderr := &prefixedLineWriter{prefix: "[" + profile + "] ", w: os.Stderr, mu: stderrMu}
+	command.Stderr = stderr
+
+	err := command.Run()
+	stderr.Flush()
+	run := profileRun{Profile: profile, Stdout: stdout.Bytes()}
+	if err != nil {
+		run.Err = err
+		run.ExitCode = ExitError
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+			run.ExitCode = exitErr.ExitCode()
+		}
+	}
+	return run
+}
+
+// mergeProfileRuns flattens each successful run into items tagged with their
+// profile. A list response ({"data": [...]}) contributes one item per
+// element; any other object contributes itself.
+func mergeProfileRuns(runs []profileRun) ([]json.RawMessage, []profileFanoutError) {
+	items := []json.RawMessage{}
+	var failures []profileFanoutError
+	for _, run := range runs {
+		if run.Err != nil {
+			failures = append(failures, profileFanoutError{Profile: run.Profile, Error: profileRunErrorText(run.Err), ExitCode: run.ExitCode})
+			continue
+		}
+		values, err := profileRunValues(run.Stdout)
+		if err != nil {
+			failures = append(failures, profileFanoutError{Profile: run.Profile, Error: err.Error(), ExitCode: ExitError})
+			continue
+		}
+		for _, value := range values {
+			items = append(items, tagProfile(value, run.Profile))
+		}
+	}
+	return items, failures
+}
+
+func profileRunErrorText(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Sprintf("exited with status %d", exitErr.ExitCode())
+	}
+	return err.Error()
+}
+
+func profileRunValues(stdout []byte) ([]json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(stdout)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	var document json.RawMessage
+	if err := json.Unmarshal(trimmed, &document); err != nil {
+		return nil, fmt.Errorf("output is not JSON: %w", err)
+	}
+	switch trimmed[0] {
+	case '[':
+		var values []json.RawMessage
+		if err := json.Unmarshal(trimmed, &values); err != nil {
+			return nil, err
+		}
+		return values, nil
+	case '{':
+		var envelope struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(trimmed, &envelope); err == nil && bytes.HasPrefix(bytes.TrimSpace(envelope.Data), []byte("[")) {
+			var values []json.RawMessage
+			if err := json.Unmarshal(envelope.Data, &values); err != nil {
+				return nil, err
+			}
+			return values, nil
+		}
+	}
+	return []json.RawMessage{document}, nil
+}
+
+// tagProfile adds "profile" as the first field of an object, keeping the
+// rest of the object byte-for-byte. Non-objects are wrapped.
+func tagProfile(value json.RawMessage, profile string) json.RawMessage {
+	name, _ := json.Marshal(profile)
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return json.RawMessage(`{"profile":` + string(name) + `,"value":` + string(trimmed) + `}`)
+	}
+	rest := bytes.TrimSpace(trimmed[1:])
+	if len(rest) > 0 && rest[0] == '}' {
+		return json.RawMessage(`{"profile":` + string(name) + `}`)
+	}
+	return json.RawMessage(`{"profile":` + string(name) + `,` + string(rest))
+}
+
+func printProfileFanout(items []json.RawMessage, failures []profileFanoutError, format string, pretty bool) error {
+	switch format {
+	case "table", "markdown", "csv", "tsv":
+		headers, rows := profileFanoutRows(items)
+		if len(headers) == 0 {
+			return nil
+		}
+		switch format {
+		case "markdown":
+			asc.RenderMarkdown(headers, rows)
+		case "csv":
+			asc.RenderCSV(headers, rows)
+		case "tsv":
+			asc.RenderTSV(headers, rows)
+		default:
+			asc.RenderTable(headers, rows)
+		}
+		return nil
+	case "ndjson":
+		for _, item := range items {
+			if _, err := fmt.Fprintf(os.Stdout, "%s\n", item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "json":
+	default:
+		return fmt.Errorf("unsupported format for profile fan-out: %s", format)
+	}
+
+	document := struct {
+		Data   []json.RawMessage    `json:"data"`
+		Errors []profileFanoutError `json:"errors,omitempty"`
+	}{Data: items, Errors: failures}
+	var data []byte
+	var err error
+	if pretty {
+		data, err = json.MarshalIndent(document, "", "  ")
+	} else {
+		data, err = json.Marshal(document)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+	return err
+}
+
+// profileFanoutRows flattens items into a table: profile, id, then the
+// scalar fields of each item's attributes (or of the item itself) in the
+// order they first appear.
+func profileFanoutRows(items []json.RawMessage) ([]string, [][]string) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	headers := []string{"profile"}
+	index := map[string]int{"profile": 0}
+	records := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		record := map[string]string{}
+		for _, field := range profileFanoutFields(item) {
+			if _, ok := index[field.name]; !ok {
+				index[field.name] = len(headers)
+				headers = append(headers, field.name)
+			}
+			record[field.name] = field.value
+		}
+		records = append(records, record)
+	}
+	rows := make([][]string, 0, len(records))
+	for _, record := range records {
+		row := make([]string, len(headers))
+		for i, header := range headers {
+			row[i] = record[header]
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
+
+type profileFanoutField struct {
+	name  string
+	value string
+}
+
+func profileFanoutFields(item json.RawMessage) []profileFanoutField {
+	top := orderedJSONFields(item)
+	var fields []profileFanoutField
+	var attributes json.RawMessage
+	for _, field := range top {
+		switch field.name {
+		case "attributes":
+			attributes = field.raw
+		case "type", "relationships", "links":
+		default:
+			if value, ok := scalarJSONText(field.raw); ok {
+				fields = append(fields, profileFanoutField{name: field.name, value: value})
+			}
+		}
+	}
+	for _, field := range orderedJSONFields(attributes) {
+		if value, ok := scalarJSONText(field.raw); ok {
+			fields = append(fields, profileFanoutField{name: field.name, value: value})
+		}
+	}
+	return fields
+}
+
+type orderedJSONField struct {
+	name string
+	raw  json.RawMessage
+}
+
+// orderedJSONFields returns the top-level fields of a JSON object in
+// document order.
+func orderedJSONFields(raw json.RawMessage) []orderedJSONField {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+	var fields []orderedJSONField
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fields
+		}
+		name, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return fields
+		}
+		fields = append(fields, orderedJSONField{name: name, raw: value})
+	}
+	return fields
+}
+
+func scalarJSONText(raw json.RawMessage) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	}
+	switch v := value.(type) {
+	case nil:
+		return "", true
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+// prefixedLineWriter prefixes each line written by a child process so
+// interleaved stderr from concurrent profiles stays attributable.
+type prefixedLineWriter struct {
+	prefix string
+	w      io.Writer
+	mu     *sync.Mutex
+	buf    []byte
+}
+
+func (p *prefixedLineWriter) Write(data []byte) (int, error) {
+	p.buf = append(p.buf, data...)
+	for {
+		newline := bytes.IndexByte(p.buf, '\n')
+		if newline < 0 {
+			return len(data), nil
+		}
+		p.writeLine(p.buf[:newline+1])
+		p.buf = p.buf[newline+1:]
+	}
+}
+
+// Flush writes any trailing partial line.
+func (p *prefixedLineWriter) Flush() {
+	if len(p.buf) > 0 {
+		p.writeLine(append(p.buf, '\n'))
+		p.buf = nil
+	}
+}
+
+func (p *prefixedLineWriter) writeLine(line []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = io.WriteString(p.w, p.prefix)
+	_, _ = p.w.Write(line)
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestProfileFanoutChildArgs(t *testing.T) {
+	rootFlags := RootCommand("1.2.3").FlagSet
+
+	args := []string{"--all-profiles", "--debug", "apps", "list", "--output", "table", "--limit", "5", "--pretty"}
+	fanout := profileFanoutChildArgs(rootFlags, args)
+	want := []string{"--debug", "apps", "list", "--output", "json", "--limit", "5"}
+	if got := fanout.forProfile("a"); !slices.Equal(got, want) {
+		t.Fatalf("child args = %v, want %v", got, want)
+	}
+	if fanout.format != "table" || !fanout.pretty {
+		t.Fatalf("format, pretty = %q, %v; want table, true", fanout.format, fanout.pretty)
+	}
+
+	fanout = profileFanoutChildArgs(rootFlags, []string{"--profiles=a,b", "status", "--output=md"})
+	if got := fanout.forProfile("a"); !slices.Equal(got, []string{"status", "--output", "json"}) || fanout.format != "markdown" {
+		t.Fatalf("child args = %v, format = %q", got, fanout.format)
+	}
+
+	fanout = profileFanoutChildArgs(rootFlags, []string{"--profiles", "a,b", "certificates", "list"})
+	if got := fanout.forProfile("a"); !slices.Equal(got, []string{"certificates", "list"}) {
+		t.Fatalf("child args = %v", got)
+	}
+}
+
+func TestProfileFanoutChildArgsConsumesRootFlagValues(t *testing.T) {
+	rootFlags := RootCommand("1.2.3").FlagSet
+
+	args := []string{"--trace-file", "trace.json", "--all-profiles", "--trace-format", "chrome", "--record=out/run.cassette", "apps", "list"}
+	fanout := profileFanoutChildArgs(rootFlags, args)
+
+	want := []string{"--trace-file=trace.alpha.json", "--trace-format", "chrome", "--record=out/run.alpha.cassette", "apps", "list"}
+	if got := fanout.forProfile("alpha"); !slices.Equal(got, want) {
+		t.Fatalf("child args = %v, want %v", got, want)
+	}
+	want = []string{"--trace-file=trace.team_b.json", "--trace-format", "chrome", "--record=out/run.team_b.cassette", "apps", "list"}
+	if got := fanout.forProfile("team/b"); !slices.Equal(got, want) {
+		t.Fatalf("child args = %v, want %v", got, want)
+	}
+}
+
+func TestMergeProfileRunsTagsItemsWithProfile(t *testing.T) {
+	runs := []profileRun{
+		{Profile: "alpha", Stdout: []byte(`{"data":[{"type":"apps","id":"1","attributes":{"name":"One"}},{"type":"apps","id":"2","attributes":{"name":"Two"}}],"links":{"self":"x"}}`)},
+		{Profile: "beta", Stdout: []byte(`{"app":"3","state":"READY"}`)},
+		{Profile: "gamma", Err: errors.New("boom"), ExitCode: ExitAuth},
+		{Profile: "delta", Stdout: []byte("not json")},
+	}
+	items, failures := mergeProfileRuns(runs)
+
+	var got []string
+	for _, item := range items {
+		got = append(got, string(item))
+	}
+	want := []string{
+		`{"profile":"alpha","type":"apps","id":"1","attributes":{"name":"One"}}`,
+		`{"profile":"alpha","type":"apps","id":"2","attributes":{"name":"Two"}}`,
+		`{"profile":"beta","app":"3","state":"READY"}`,
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("items = %v, want %v", got, want)
+	}
+	if len(failures) != 2 || failures[0].Profile != "gamma" || failures[0].ExitCode != ExitAuth || failures[1].Profile != "delta" {
+		t.Fatalf("unexpected failures %+v", failures)
+	}
+}
+
+func TestProfileFanoutRowsFlattenAttributes(t *testing.T) {
+	items, _ := mergeProfileRuns([]profileRun{
+		{Profile: "alpha", Stdout: []byte(`{"data":[{"type":"apps","id":"1","attributes":{"name":"One","bundleId":"com.one","primaryLocale":"en-US"}}]}`)},
+		{Profile: "beta", Stdout: []byte(`{"data":[{"type":"apps","id":"2","attributes":{"name":"Two","bundleId":"com.two","isOrEverWasMadeForKids":false,"nested":{"a":1}}}]}`)},
+	})
+	headers, rows := profileFanoutRows(items)
+	wantHeaders := []string{"profile", "id", "name", "bundleId", "primaryLocale", "isOrEverWasMadeForKids"}
+	if !slices.Equal(headers, wantHeaders) {
+		t.Fatalf("headers = %v, want %v", headers, wantHeaders)
+	}
+	wantRows := [][]string{
+		{"alpha", "1", "One", "com.one", "en-US", ""},
+		{"beta", "2", "Two", "com.two", "", "false"},
+	}
+	if !slices.EqualFunc(rows, wantRows, slices.Equal[[]string]) {
+		t.Fatalf("rows = %v, want %v", rows, wantRows)
+	}
+}
+
+func TestRun_AllProfilesMergesReadOnlyResults(t *testing.T) {
+	tmpDir := t.TempDir()
+	binaryPath := filepath.Join(tmpDir, "asc-test")
+	build := exec.Command("go", "build", "-o", binaryPath, ".")
+	build.Dir = ".."
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build binary: %v\n%s", err, out)
+	}
+	configPath := writeAuthTokenProfilesConfig(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/apps" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch requestKeyID(r) {
+		case "KEY_A":
+			_, _ = w.Write([]byte(`{"data":[{"type":"apps","id":"a1","attributes":{"name":"Alpha","bundleId":"com.a"}}]}`))
+		case "KEY_B":
+			_, _ = w.Write([]byte(`{"data":[{"type":"apps","id":"b1","attributes":{"name":"Beta","bundleId":"com.b"}},{"type":"apps","id":"b2","attributes":{"name":"Beta 2","bundleId":"com.b2"}}]}`))
+		default:
+			http.Error(w, "unknown key", http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	run := exec.Command(binaryPath, "--all-profiles", "apps", "list", "--output", "json")
+	run.Env = append(isolatedCLITestEnv(configPath), "ASC_BASE_URL="+server.URL, "ASC_NO_CACHE=1")
+	output, err := run.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			t.Fatalf("run failed: %v\nstderr: %s", err, exitErr.Stderr)
+		}
+		t.Fatalf("run failed: %v", err)
+	}
+
+	var document struct {
+		Data []struct {
+			Profile string `json:"profile"`
+			ID      string `json:"id"`
+		} `json:"data"`
+		Errors []json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(output, &document); err != nil {
+		t.Fatalf("unmarshal output: %v\n%s", err, output)
+	}
+	var got []string
+	for _, item := range document.Data {
+		got = append(got, item.Profile+"/"+item.ID)
+	}
+	want := []string{"first/a1", "second/b1", "second/b2"}
+	if !slices.Equal(got, want) || len(document.Errors) != 0 {
+		t.Fatalf("merged items = %v (errors %s), want %v", got, document.Errors, want)
+	}
+
+	run = exec.Command(binaryPath, "--profiles", "second", "apps", "list", "--output", "table")
+	run.Env = append(isolatedCLITestEnv(configPath), "ASC_BASE_URL="+server.URL, "ASC_NO_CACHE=1")
+	output, err = run.Output()
+	if err != nil {
+		t.Fatalf("table run failed: %v", err)
+	}
+	table := string(output)
+	if !strings.Contains(table, "profile") || !strings.Contains(table, "Beta 2") || strings.Contains(table, "Alpha") {
+		t.Fatalf("unexpected table output:\n%s", table)
+	}
+}
+
+func TestRun_ProfileFanoutRejectsConflictingFlags(t *testing.T) {
+	resetReportFlags(t)
+	resetSelectedProfile(t)
+
+	for _, args := range [][]string{
+		{"--all-profiles", "--profiles", "a", "apps", "list"},
+		{"--profile", "a", "--profiles", "b", "apps", "list"},
+	} {
+		_, stderr := captureCommandOutput(t, func() {
+			if code := Run(args, "1.0.0"); code != ExitUsage {
+				t.Fatalf("Run(%v) exit code = %d, want %d", args, code, ExitUsage)
+			}
+		})
+		if !strings.Contains(stderr, "--profiles") {
+			t.Fatalf("expected --profiles conflict error for %v, got %q", args, stderr)
+		}
+	}
+}
+
+func TestRun_ProfileFanoutRejectsUnsupportedFormatBeforeRunningChildren(t *testing.T) {
+	resetReportFlags(t)
+	resetSelectedProfile(t)
+	t.Setenv("ASC_CONFIG_PATH", writeAuthTokenProfilesConfig(t))
+	t.Setenv("ASC_BYPASS_KEYCHAIN", "1")
+
+	previous := profileFanoutExecutable
+	profileFanoutExecutable = func() (string, error) {
+		t.Fatal("expected the format to be rejected before resolving the profile child binary")
+		return "", nil
+	}
+	t.Cleanup(func() { profileFanoutExecutable = previous })
+
+	for _, format := range []string{"html", "sarif", "template"} {
+		args := []string{"--all-profiles", "apps", "list", "--output", format}
+		_, stderr := captureCommandOutput(t, func() {
+			if code := Run(args, "1.0.0"); code != ExitUsage {
+				t.Fatalf("Run(%v) exit code = %d, want %d", args, code, ExitUsage)
+			}
+		})
+		if !strings.Contains(stderr, "--output "+format+" is not supported with --all-profiles") {
+			t.Fatalf("expected unsupported format error for %s, got %q", format, stderr)
+		}
+	}
+}
+
+// requestKeyID returns the kid from the request's bearer JWT header.
+func requestKeyID(r *http.Request) string {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	header, _, _ := strings.Cut(token, ".")
+	data, err := base64.RawURLEncoding.DecodeString(header)
+	if err != nil {
+		return ""
+	}
+	var parsed struct {
+		Kid string `json:"kid"`
+	}
+	_ = json.Unmarshal(data, &parsed)
+	return parsed.Kid
+}
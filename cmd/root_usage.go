@@ -70,7 +70,7 @@ var rootUsageGroups = []rootCommandGroup{
 	},
 	{
 		title:    "UTILITY COMMANDS",
-		commands: []string{"diff", "snitch", "version", "completion", "schema"},
+		commands: []string{"diff", "snitch", "version", "completion", "schema", "api", "mock", "cache", "undo"},
 	},
 }
 
@@ -49,6 +49,63 @@ func Run(args []string, versionInfo string) int {
 		return ExitUsage
 	}
 
+	if err := shared.ValidateCacheFlags(); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.FormatStderr(err))
+		return ExitUsage
+	}
+
+	if err := shared.ValidateTraceFlags(); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.FormatStderr(err))
+		return ExitUsage
+	}
+
+	if err := shared.ValidateUploadFlags(); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.FormatStderr(err))
+		return ExitUsage
+	}
+
+	if err := shared.ValidateProfileFanoutFlags(); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.FormatStderr(err))
+		return ExitUsage
+	}
+
+	if err := shared.ValidateProjectionFlags(); err != nil {
+		fmt.Fprint(os.Stderr, errfmt.FormatStderr(err))
+		return ExitUsage
+	}
+
+	shared.ApplyReadOnlyFlag()
+	shared.SetAuditCommandLine(args)
+
+	if !versionRequested && hasPositionalArgs(root.FlagSet, args) {
+		profiles, err := shared.FanoutProfiles()
+		if err != nil {
+			fmt.Fprint(os.Stderr, errfmt.FormatStderr(err))
+			return ExitUsage
+		}
+		if len(profiles) > 0 {
+			return runProfileFanout(runCtx, root.FlagSet, args, profiles)
+		}
+	}
+
+	finishCassette, err := shared.StartCassette()
+	if err != nil {
+		fmt.Fprint(os.Stderr, errfmt.FormatStderr(err))
+		return ExitUsage
+	}
+	defer func() {
+		if err := finishCassette(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to save cassette: %v\n", err)
+		}
+	}()
+
+	finishPlan := shared.StartPlan()
+	defer func() {
+		if err := finishPlan(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to write plan: %v\n", err)
+		}
+	}()
+
 	if versionRequested {
 		if err := root.Run(runCtx); err != nil {
 			if errors.Is(err, flag.ErrHelp) {
@@ -69,9 +126,15 @@ func Run(args []string, versionInfo string) int {
 
 	commandName := getCommandName(root, args)
 
+	traceCtx, finishTrace := shared.StartTrace(runCtx, commandName)
+	finishUploadProgress := shared.StartUploadProgress()
 	start := time.Now()
-	runErr := root.Run(runCtx)
+	runErr := root.Run(traceCtx)
 	elapsed := time.Since(start)
+	finishUploadProgress()
+	if err := finishTrace(runErr); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write trace: %v\n", err)
+	}
 
 	if shouldCancelRunContextAfterError(runErr) {
 		stopSignals()
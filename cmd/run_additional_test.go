@@ -7,6 +7,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"encoding/xml"
@@ -58,6 +59,44 @@ func TestRun_ReportFlagValidationError(t *testing.T) {
 	}
 }
 
+func TestRun_RecordAndReplayAreMutuallyExclusive(t *testing.T) {
+	resetReportFlags(t)
+	dir := t.TempDir()
+
+	_, stderr := captureCommandOutput(t, func() {
+		code := Run([]string{
+			"--record", filepath.Join(dir, "a.cassette"),
+			"--replay", filepath.Join(dir, "b.cassette"),
+			"completion", "--shell", "bash",
+		}, "1.0.0")
+		if code != ExitUsage {
+			t.Fatalf("Run() exit code = %d, want %d", code, ExitUsage)
+		}
+	})
+
+	if !strings.Contains(stderr, "mutually exclusive") {
+		t.Fatalf("expected cassette flag validation error, got %q", stderr)
+	}
+}
+
+func TestRun_NoCacheAndRefreshCacheAreMutuallyExclusive(t *testing.T) {
+	resetReportFlags(t)
+
+	_, stderr := captureCommandOutput(t, func() {
+		code := Run([]string{"--no-cache", "--refresh-cache", "completion", "--shell", "bash"}, "1.0.0")
+		if code != ExitUsage {
+			t.Fatalf("Run() exit code = %d, want %d", code, ExitUsage)
+		}
+	})
+
+	if got := strings.Count(stderr, "--no-cache and --refresh-cache are mutually exclusive"); got != 1 {
+		t.Fatalf("expected the cache flag validation error once, got %q", stderr)
+	}
+	if strings.Contains(stderr, "help requested") {
+		t.Fatalf("expected no flag.ErrHelp text, got %q", stderr)
+	}
+}
+
 func TestRun_ReportWriteFailureReturnsExitError(t *testing.T) {
 	resetReportFlags(t)
 
@@ -1145,3 +1184,96 @@ func TestRunHelpHelperProcess(t *testing.T) {
 	code := Run(os.Args[sep+1:], "1.0.0")
 	os.Exit(code)
 }
+
+func TestRun_AuthTokenIndividualKeyWithScope(t *testing.T) {
+	resetReportFlags(t)
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+	keyPath := filepath.Join(tempDir, "AuthKey.p8")
+	writeRunTestECDSAPEM(t, keyPath)
+
+	cfg := &config.Config{
+		DefaultKeyName: "me",
+		Keys: []config.Credential{
+			{
+				Name:           "me",
+				KeyID:          "USERKEY",
+				PrivateKeyPath: keyPath,
+				KeyType:        config.KeyTypeIndividual,
+			},
+		},
+	}
+	if err := config.SaveAt(configPath, cfg); err != nil {
+		t.Fatalf("SaveAt() error: %v", err)
+	}
+
+	t.Setenv("ASC_CONFIG_PATH", configPath)
+	t.Setenv("ASC_BYPASS_KEYCHAIN", "1")
+	t.Setenv("ASC_PROFILE", "")
+	t.Setenv("ASC_KEY_ID", "")
+	// An env issuer must not turn an Individual key into a team token.
+	t.Setenv("ASC_ISSUER_ID", "ENVISS")
+	t.Setenv("ASC_PRIVATE_KEY_PATH", "")
+	t.Setenv("ASC_PRIVATE_KEY", "")
+	t.Setenv("ASC_PRIVATE_KEY_B64", "")
+	resetSelectedProfile(t)
+
+	stdout, stderr := captureCommandOutput(t, func() {
+		code := Run([]string{"auth", "token", "--confirm", "--scope", "get /v1/apps", "--scope", "GET /v1/builds?filter[app]=1", "--output", "json"}, "1.0.0")
+		if code != ExitSuccess {
+			t.Fatalf("Run() exit code = %d, want %d", code, ExitSuccess)
+		}
+	})
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+
+	var payload struct {
+		Token   string   `json:"token"`
+		KeyType string   `json:"keyType"`
+		Scope   []string `json:"scope"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v; stdout=%q", err, stdout)
+	}
+	if payload.KeyType != config.KeyTypeIndividual {
+		t.Fatalf("expected individual key type, got %q", payload.KeyType)
+	}
+	if len(payload.Scope) != 2 || payload.Scope[0] != "GET /v1/apps" {
+		t.Fatalf("unexpected scope %v", payload.Scope)
+	}
+
+	parts := strings.Split(payload.Token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected JWT token, got %q", payload.Token)
+	}
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("decode claims: %v", err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		t.Fatalf("unmarshal claims: %v", err)
+	}
+	if claims["sub"] != "user" || claims["iss"] != nil {
+		t.Fatalf("expected individual key claims, got %v", claims)
+	}
+	if scope, ok := claims["scope"].([]any); !ok || len(scope) != 2 {
+		t.Fatalf("expected scope claim, got %v", claims["scope"])
+	}
+}
+
+func TestRun_AuthTokenRejectsInvalidScope(t *testing.T) {
+	resetReportFlags(t)
+
+	_, stderr := captureCommandOutput(t, func() {
+		code := Run([]string{"auth", "token", "--confirm", "--scope", "PUT apps"}, "1.0.0")
+		if code != ExitUsage {
+			t.Fatalf("Run() exit code = %d, want %d", code, ExitUsage)
+		}
+	})
+	if !strings.Contains(stderr, "--scope") {
+		t.Fatalf("expected scope error, got %q", stderr)
+	}
+}
@@ -36,13 +36,33 @@ func UploadAsset(ctx context.Context, filePath string, operations []UploadOperat
 }
 
 // UploadAssetFromFile uploads a file using the provided upload operations.
-func UploadAssetFromFile(ctx context.Context, file *os.File, fileSize int64, operations []UploadOperation) error {
+// Only WithUploadState is honored among opts; parts are sent sequentially,
+// reporting progress and respecting the process upload rate cap.
+func UploadAssetFromFile(ctx context.Context, file *os.File, fileSize int64, operations []UploadOperation, opts ...UploadOption) error {
 	if len(operations) == 0 {
 		return fmt.Errorf("no upload operations provided")
 	}
+	var uploadOpts UploadOptions
+	for _, opt := range opts {
+		opt(&uploadOpts)
+	}
 
 	client := &http.Client{Timeout: ResolveUploadTimeout()}
 
+	var tracker *uploadTracker
+	if ActivePlan() == nil {
+		var totalBytes, resumedBytes int64
+		resumedParts := 0
+		for i, op := range operations {
+			totalBytes += op.Length
+			if uploadOpts.State.isCompleted(i) {
+				resumedBytes += op.Length
+				resumedParts++
+			}
+		}
+		tracker = newUploadTracker(file.Name(), totalBytes, len(operations), resumedBytes, resumedParts)
+	}
+
 	for i, op := range operations {
 		method := strings.ToUpper(strings.TrimSpace(op.Method))
 		if method == "" {
@@ -57,9 +77,24 @@ func UploadAssetFromFile(ctx context.Context, file *os.File, fileSize int64, ope
 		if op.Offset+op.Length > fileSize {
 			return fmt.Errorf("upload operation %d exceeds file size", i)
 		}
+		if uploadOpts.State.isCompleted(i) {
+			continue
+		}
+		if plan := ActivePlan(); plan != nil {
+			plan.recordUpload(method, op.URL, op.Length)
+			continue
+		}
+		if err := CheckReadOnly(method, op.URL); err != nil {
+			return err
+		}
 
-		reader := io.NewSectionReader(file, op.Offset, op.Length)
-		req, err := http.NewRequestWithContext(ctx, method, op.URL, reader)
+		var body io.Reader = io.NewSectionReader(file, op.Offset, op.Length)
+		var attempt *uploadProgressReader
+		if tracker != nil {
+			attempt = tracker.reader(ctx, body)
+			body = attempt
+		}
+		req, err := http.NewRequestWithContext(ctx, method, op.URL, body)
 		if err != nil {
 			return fmt.Errorf("upload operation %d: %w", i, err)
 		}
@@ -70,16 +105,21 @@ func UploadAssetFromFile(ctx context.Context, file *os.File, fileSize int64, ope
 
 		resp, err := client.Do(req)
 		if err != nil {
+			attempt.rewind()
 			return fmt.Errorf("upload operation %d failed: %w", i, err)
 		}
 		_, _ = io.Copy(io.Discard, resp.Body)
 		_ = resp.Body.Close()
 
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			attempt.rewind()
 			return fmt.Errorf("upload operation %d failed with status %d", i, resp.StatusCode)
 		}
+		uploadOpts.State.markCompleted(i)
+		tracker.partDone(attempt, op.Length)
 	}
 
+	tracker.complete()
 	return nil
 }
 
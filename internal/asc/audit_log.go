@@ -0,0 +1,443 @@
+package asc
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrAuditEntryNotFound is returned when an audit log has no entry with the requested ID.
+var ErrAuditEntryNotFound = errors.New("audit entry not found")
+
+// AuditEntry is one line of the mutation audit log.
+type AuditEntry struct {
+	ID           string          `json:"id"`
+	Time         time.Time       `json:"time"`
+	Command      string          `json:"command,omitempty"`
+	Profile      string          `json:"profile,omitempty"`
+	KeyID        string          `json:"keyId,omitempty"`
+	User         string          `json:"user,omitempty"`
+	Host         string          `json:"host,omitempty"`
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	Body         json.RawMessage `json:"body,omitempty"`
+	Status       int             `json:"status,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	ResourceType string          `json:"resourceType,omitempty"`
+	ResourceIDs  []string        `json:"resourceIds,omitempty"`
+	// Prior holds the resource (or relationship linkage) as it was before a
+	// PATCH or DELETE, which is what asc undo restores.
+	Prior  json.RawMessage `json:"prior,omitempty"`
+	UndoOf string          `json:"undoOf,omitempty"`
+}
+
+// Succeeded reports whether the audited request was accepted by the API.
+func (e AuditEntry) Succeeded() bool {
+	return e.Error == "" && e.Status >= 200 && e.Status < 300
+}
+
+// AuditLog appends one JSONL entry per mutating request sent by a client.
+type AuditLog struct {
+	path    string
+	command string
+	profile string
+	user    string
+	host    string
+
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// NewAuditLog creates an audit log appending to path. command and profile
+// are recorded with every entry.
+func NewAuditLog(path, command, profile string) *AuditLog {
+	log := &AuditLog{
+		path:    path,
+		command: command,
+		profile: profile,
+		now:     time.Now,
+	}
+	if current, err := user.Current(); err == nil {
+		log.user = current.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		log.host = host
+	}
+	return log
+}
+
+// Path returns the file the log appends to.
+func (l *AuditLog) Path() string {
+	return l.path
+}
+
+// SetAuditLog attaches a mutation audit log to the client. Passing nil
+// disables auditing.
+func (c *Client) SetAuditLog(log *AuditLog) {
+	c.auditLog = log
+}
+
+type auditUndoKey struct{}
+
+// ContextWithAuditUndo marks requests made with ctx as undoing entryID.
+func ContextWithAuditUndo(ctx context.Context, entryID string) context.Context {
+	return context.WithValue(ctx, auditUndoKey{}, entryID)
+}
+
+func auditUndoOf(ctx context.Context) string {
+	entryID, _ := ctx.Value(auditUndoKey{}).(string)
+	return entryID
+}
+
+// capturePriorState fetches the target of a PATCH or DELETE before it is
+// changed. Failures are ignored: the request is still sent and audited,
+// it just cannot be undone.
+func (c *Client) capturePriorState(ctx context.Context, method, path string) json.RawMessage {
+	if c.auditLog == nil || (method != http.MethodPatch && method != http.MethodDelete) {
+		return nil
+	}
+	segments := auditPathSegments(path)
+	switch {
+	case len(segments) == 3:
+	case len(segments) == 5 && segments[3] == "relationships" && method == http.MethodPatch:
+	default:
+		return nil
+	}
+	body, err := c.doOnce(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil
+	}
+	var document struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil || len(document.Data) == 0 {
+		return nil
+	}
+	return document.Data
+}
+
+// record appends an entry for a finished mutating request. Audit write
+// failures never fail the request itself.
+func (l *AuditLog) record(ctx context.Context, keyID, method, path string, body []byte, prior json.RawMessage, status int, respBody []byte, requestErr error) {
+	if l == nil {
+		return
+	}
+	id, err := newAuditEntryID()
+	if err != nil {
+		return
+	}
+	entry := AuditEntry{
+		ID:      id,
+		Time:    l.now().UTC(),
+		Command: l.command,
+		Profile: l.profile,
+		KeyID:   keyID,
+		User:    l.user,
+		Host:    l.host,
+		Method:  strings.ToUpper(method),
+		Path:    path,
+		Body:    redactAuditBody(body),
+		Status:  status,
+		Prior:   prior,
+		UndoOf:  auditUndoOf(ctx),
+	}
+	if requestErr != nil {
+		entry.Error = requestErr.Error()
+	}
+	entry.ResourceType, entry.ResourceIDs = auditResources(path, respBody)
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	_ = l.append(line)
+}
+
+func (l *AuditLog) append(line []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func newAuditEntryID() (string, error) {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func auditPathSegments(path string) []string {
+	parsed, err := url.Parse(path)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.Trim(parsed.Path, "/"), "/")
+}
+
+// auditResources returns the resource type and the IDs touched by a request:
+// the ID in the path plus any IDs returned in the response.
+func auditResources(path string, respBody []byte) (string, []string) {
+	segments := auditPathSegments(path)
+	var (
+		resourceType string
+		ids          []string
+	)
+	if len(segments) >= 2 {
+		resourceType = segments[1]
+	}
+	if len(segments) >= 3 {
+		ids = append(ids, segments[2])
+	}
+
+	var document struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &document); err != nil {
+		return resourceType, ids
+	}
+	var resource struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	}
+	if err := json.Unmarshal(document.Data, &resource); err == nil && resource.ID != "" {
+		if resource.Type != "" {
+			resourceType = resource.Type
+		}
+		if len(ids) == 0 || ids[0] != resource.ID {
+			ids = append(ids, resource.ID)
+		}
+	}
+	return resourceType, ids
+}
+
+// auditSensitiveKeys lists JSON member names (lowercased substrings) whose
+// values are never written to the audit log.
+var auditSensitiveKeys = []string{"password", "secret", "token", "privatekey"}
+
+func redactAuditBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return json.RawMessage(fmt.Sprintf(`{"redacted":"non-JSON body (%d bytes)"}`, len(body)))
+	}
+	data, err := json.Marshal(redactAuditValue(value))
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func redactAuditValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, item := range typed {
+			lower := strings.ToLower(key)
+			redacted := false
+			for _, sensitive := range auditSensitiveKeys {
+				if strings.Contains(lower, sensitive) {
+					typed[key] = "[REDACTED]"
+					redacted = true
+					break
+				}
+			}
+			if !redacted {
+				typed[key] = redactAuditValue(item)
+			}
+		}
+		return typed
+	case []any:
+		for i, item := range typed {
+			typed[i] = redactAuditValue(item)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// ReadAuditLog loads every entry from the audit log at path, oldest first.
+func ReadAuditLog(path string) ([]AuditEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal([]byte(text), &entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FindAuditEntry returns the entry with the given ID.
+func FindAuditEntry(entries []AuditEntry, id string) (AuditEntry, error) {
+	id = strings.TrimSpace(id)
+	for _, entry := range entries {
+		if entry.ID == id {
+			return entry, nil
+		}
+	}
+	return AuditEntry{}, fmt.Errorf("%w: %s", ErrAuditEntryNotFound, id)
+}
+
+// UndoRequest is the request that reverses an audited change.
+type UndoRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// Undo builds the request that reverses the entry:
+//   - PATCH of a resource restores the prior values of the patched attributes
+//   - PATCH of a to-many or to-one relationship restores the prior linkage
+//   - POST/DELETE of relationship members is reversed with the opposite method
+//   - POST creating a resource deletes the created resource
+//
+// Deleted resources cannot be recreated; their prior state stays in the
+// entry for manual recovery. Price schedules and availabilities are
+// create-only in the API, so creating one cannot be undone either.
+func (e AuditEntry) Undo() (*UndoRequest, error) {
+	if !e.Succeeded() {
+		return nil, fmt.Errorf("entry %s did not succeed; nothing to undo", e.ID)
+	}
+	path, _, _ := strings.Cut(e.Path, "?")
+	segments := auditPathSegments(path)
+
+	switch {
+	case len(segments) == 5 && segments[3] == "relationships":
+		switch e.Method {
+		case http.MethodPost:
+			return &UndoRequest{Method: http.MethodDelete, Path: path, Body: e.Body}, nil
+		case http.MethodDelete:
+			return &UndoRequest{Method: http.MethodPost, Path: path, Body: e.Body}, nil
+		case http.MethodPatch:
+			if len(e.Prior) == 0 {
+				return nil, fmt.Errorf("entry %s has no prior linkage recorded", e.ID)
+			}
+			body, err := json.Marshal(map[string]json.RawMessage{"data": e.Prior})
+			if err != nil {
+				return nil, err
+			}
+			return &UndoRequest{Method: http.MethodPatch, Path: path, Body: body}, nil
+		}
+	case len(segments) == 3 && e.Method == http.MethodPatch:
+		return e.undoPatch(path, segments)
+	case len(segments) == 3 && e.Method == http.MethodDelete:
+		return nil, fmt.Errorf("entry %s deleted %s; deleted resources cannot be recreated automatically (prior state is kept in the entry)", e.ID, path)
+	case len(segments) == 2 && e.Method == http.MethodPost && auditCreateOnlyTypes[segments[1]]:
+		return nil, fmt.Errorf("entry %s created %s, which the API cannot delete; it cannot be undone (create a new one with the previous values instead)", e.ID, segments[1])
+	case len(segments) == 2 && e.Method == http.MethodPost:
+		if len(e.ResourceIDs) == 0 {
+			return nil, fmt.Errorf("entry %s has no created resource ID recorded", e.ID)
+		}
+		return &UndoRequest{Method: http.MethodDelete, Path: path + "/" + e.ResourceIDs[len(e.ResourceIDs)-1]}, nil
+	}
+	return nil, fmt.Errorf("entry %s (%s %s) cannot be undone", e.ID, e.Method, path)
+}
+
+// auditCreateOnlyTypes are resource collections that accept POST but have no
+// DELETE endpoint; a new schedule or availability replaces the previous one.
+var auditCreateOnlyTypes = map[string]bool{
+	"appPriceSchedules":           true,
+	"appAvailabilities":           true,
+	"inAppPurchasePriceSchedules": true,
+	"inAppPurchaseAvailabilities": true,
+	"subscriptionAvailabilities":  true,
+}
+
+func (e AuditEntry) undoPatch(path string, segments []string) (*UndoRequest, error) {
+	if len(e.Prior) == 0 {
+		return nil, fmt.Errorf("entry %s has no prior state recorded", e.ID)
+	}
+	var patch struct {
+		Data struct {
+			Attributes map[string]json.RawMessage `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(e.Body, &patch); err != nil || len(patch.Data.Attributes) == 0 {
+		return nil, fmt.Errorf("entry %s changed no attributes; only attribute changes can be undone", e.ID)
+	}
+	var prior struct {
+		Type       string                     `json:"type"`
+		ID         string                     `json:"id"`
+		Attributes map[string]json.RawMessage `json:"attributes"`
+	}
+	if err := json.Unmarshal(e.Prior, &prior); err != nil {
+		return nil, fmt.Errorf("entry %s: invalid prior state: %w", e.ID, err)
+	}
+
+	restored := map[string]json.RawMessage{}
+	for name, value := range patch.Data.Attributes {
+		if string(value) == `"[REDACTED]"` {
+			return nil, fmt.Errorf("entry %s changed redacted attribute %q; it cannot be undone", e.ID, name)
+		}
+		previous, ok := prior.Attributes[name]
+		if !ok {
+			previous = json.RawMessage("null")
+		}
+		restored[name] = previous
+	}
+
+	resourceType := prior.Type
+	if resourceType == "" {
+		resourceType = segments[1]
+	}
+	resourceID := prior.ID
+	if resourceID == "" {
+		resourceID = segments[2]
+	}
+	body, err := json.Marshal(map[string]any{
+		"data": map[string]any{
+			"type":       resourceType,
+			"id":         resourceID,
+			"attributes": restored,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UndoRequest{Method: http.MethodPatch, Path: path, Body: body}, nil
+}
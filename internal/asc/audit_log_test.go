@@ -0,0 +1,184 @@
+package asc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditLogRecordsMutationsWithPriorState(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "audit", "audit.jsonl")
+
+	var methods []string
+	client := newTestClient(t, func(req *http.Request) {
+		methods = append(methods, req.Method+" "+req.URL.Path)
+	},
+		jsonResponse(http.StatusOK, `{"data":{"type":"betaGroups","id":"g1","attributes":{"name":"Old","publicLinkEnabled":false}}}`),
+		jsonResponse(http.StatusOK, `{"data":{"type":"betaGroups","id":"g1","attributes":{"name":"New","publicLinkEnabled":false}}}`),
+		jsonResponse(http.StatusCreated, `{"data":{"type":"betaGroups","id":"g2","attributes":{"name":"QA"}}}`),
+		jsonResponse(http.StatusNotFound, `{"errors":[{"status":"404","code":"NOT_FOUND","title":"Not found"}]}`),
+	)
+	client.SetAuditLog(NewAuditLog(logPath, "asc testflight groups edit", "ci"))
+	ctx := context.Background()
+
+	if _, err := client.do(ctx, http.MethodPatch, "/v1/betaGroups/g1", strings.NewReader(`{"data":{"type":"betaGroups","id":"g1","attributes":{"name":"New"}}}`)); err != nil {
+		t.Fatalf("PATCH error: %v", err)
+	}
+	if _, err := client.do(ctx, http.MethodPost, "/v1/betaGroups", strings.NewReader(`{"data":{"type":"betaGroups","attributes":{"name":"QA","feedbackToken":"abc"}}}`)); err != nil {
+		t.Fatalf("POST error: %v", err)
+	}
+	if _, err := client.do(ctx, http.MethodDelete, "/v1/betaGroups/g9/relationships/builds", strings.NewReader(`{"data":[{"type":"builds","id":"b1"}]}`)); err == nil {
+		t.Fatal("expected DELETE error")
+	}
+
+	want := []string{"GET /v1/betaGroups/g1", "PATCH /v1/betaGroups/g1", "POST /v1/betaGroups", "DELETE /v1/betaGroups/g9/relationships/builds"}
+	if strings.Join(methods, ",") != strings.Join(want, ",") {
+		t.Fatalf("requests = %v, want %v", methods, want)
+	}
+
+	entries, err := ReadAuditLog(logPath)
+	if err != nil {
+		t.Fatalf("ReadAuditLog() error: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	patch := entries[0]
+	if patch.Command != "asc testflight groups edit" || patch.Profile != "ci" || patch.KeyID != "KEY123" {
+		t.Fatalf("unexpected entry identity %+v", patch)
+	}
+	if patch.Method != http.MethodPatch || patch.Status != http.StatusOK || !patch.Succeeded() {
+		t.Fatalf("unexpected PATCH entry %+v", patch)
+	}
+	if !strings.Contains(string(patch.Prior), `"name":"Old"`) {
+		t.Fatalf("expected prior state, got %s", patch.Prior)
+	}
+	undo, err := patch.Undo()
+	if err != nil {
+		t.Fatalf("Undo() error: %v", err)
+	}
+	if undo.Method != http.MethodPatch || undo.Path != "/v1/betaGroups/g1" {
+		t.Fatalf("unexpected undo request %+v", undo)
+	}
+	var restored struct {
+		Data struct {
+			Type       string         `json:"type"`
+			ID         string         `json:"id"`
+			Attributes map[string]any `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(undo.Body, &restored); err != nil {
+		t.Fatalf("unmarshal undo body: %v", err)
+	}
+	if restored.Data.ID != "g1" || len(restored.Data.Attributes) != 1 || restored.Data.Attributes["name"] != "Old" {
+		t.Fatalf("expected only the patched attribute to be restored, got %s", undo.Body)
+	}
+
+	create := entries[1]
+	if strings.Contains(string(create.Body), "abc") || !strings.Contains(string(create.Body), `"feedbackToken":"[REDACTED]"`) {
+		t.Fatalf("expected redacted body, got %s", create.Body)
+	}
+	if create.ResourceType != "betaGroups" || strings.Join(create.ResourceIDs, ",") != "g2" {
+		t.Fatalf("unexpected created resources %q %v", create.ResourceType, create.ResourceIDs)
+	}
+	undo, err = create.Undo()
+	if err != nil {
+		t.Fatalf("Undo() error: %v", err)
+	}
+	if undo.Method != http.MethodDelete || undo.Path != "/v1/betaGroups/g2" || len(undo.Body) != 0 {
+		t.Fatalf("unexpected undo request %+v", undo)
+	}
+
+	failed := entries[2]
+	if failed.Status != http.StatusNotFound || failed.Error == "" || failed.Succeeded() {
+		t.Fatalf("unexpected failed entry %+v", failed)
+	}
+	if _, err := failed.Undo(); err == nil {
+		t.Fatal("expected failed entries to be irreversible")
+	}
+}
+
+func TestAuditEntryUndo(t *testing.T) {
+	linkage := json.RawMessage(`{"data":[{"type":"builds","id":"b1"}]}`)
+
+	tests := []struct {
+		name       string
+		entry      AuditEntry
+		wantMethod string
+		wantPath   string
+		wantBody   string
+		wantErr    string
+	}{
+		{
+			name:       "relationship add",
+			entry:      AuditEntry{ID: "a", Method: http.MethodPost, Path: "/v1/betaGroups/g1/relationships/builds", Body: linkage, Status: 204},
+			wantMethod: http.MethodDelete,
+			wantPath:   "/v1/betaGroups/g1/relationships/builds",
+			wantBody:   string(linkage),
+		},
+		{
+			name:       "relationship remove",
+			entry:      AuditEntry{ID: "b", Method: http.MethodDelete, Path: "/v1/betaGroups/g1/relationships/builds", Body: linkage, Status: 204},
+			wantMethod: http.MethodPost,
+			wantPath:   "/v1/betaGroups/g1/relationships/builds",
+			wantBody:   string(linkage),
+		},
+		{
+			name:       "relationship replace",
+			entry:      AuditEntry{ID: "c", Method: http.MethodPatch, Path: "/v1/appStoreVersions/1/relationships/build", Prior: json.RawMessage(`{"type":"builds","id":"old"}`), Status: 204},
+			wantMethod: http.MethodPatch,
+			wantPath:   "/v1/appStoreVersions/1/relationships/build",
+			wantBody:   `{"data":{"type":"builds","id":"old"}}`,
+		},
+		{
+			name:    "price schedule create",
+			entry:   AuditEntry{ID: "p", Method: http.MethodPost, Path: "/v1/appPriceSchedules", ResourceIDs: []string{"s1"}, Status: 201},
+			wantErr: "cannot be undone",
+		},
+		{
+			name:    "resource delete",
+			entry:   AuditEntry{ID: "d", Method: http.MethodDelete, Path: "/v1/appStoreVersionLocalizations/l1", Prior: json.RawMessage(`{"type":"appStoreVersionLocalizations","id":"l1"}`), Status: 204},
+			wantErr: "cannot be recreated",
+		},
+		{
+			name:    "patch without prior",
+			entry:   AuditEntry{ID: "e", Method: http.MethodPatch, Path: "/v1/apps/1", Body: json.RawMessage(`{"data":{"type":"apps","id":"1","attributes":{"primaryLocale":"en-US"}}}`), Status: 200},
+			wantErr: "no prior state",
+		},
+		{
+			name:    "redacted attribute",
+			entry:   AuditEntry{ID: "f", Method: http.MethodPatch, Path: "/v1/users/u1", Body: json.RawMessage(`{"data":{"type":"users","id":"u1","attributes":{"token":"[REDACTED]"}}}`), Prior: json.RawMessage(`{"type":"users","id":"u1","attributes":{}}`), Status: 200},
+			wantErr: "redacted attribute",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			undo, err := test.entry.Undo()
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Undo() error: %v", err)
+			}
+			if undo.Method != test.wantMethod || undo.Path != test.wantPath || string(undo.Body) != test.wantBody {
+				t.Fatalf("Undo() = %s %s %s, want %s %s %s", undo.Method, undo.Path, undo.Body, test.wantMethod, test.wantPath, test.wantBody)
+			}
+		})
+	}
+}
+
+func TestFindAuditEntryNotFound(t *testing.T) {
+	_, err := FindAuditEntry([]AuditEntry{{ID: "abc"}}, "def")
+	if !errors.Is(err, ErrAuditEntryNotFound) {
+		t.Fatalf("expected ErrAuditEntryNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,402 @@
+package asc
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// CassetteMode selects how the HTTP cassette interacts with the network.
+type CassetteMode string
+
+const (
+	// CassetteModeRecord sends requests to the network and records every interaction.
+	CassetteModeRecord CassetteMode = "record"
+	// CassetteModeReplay serves recorded interactions without touching the network.
+	CassetteModeReplay CassetteMode = "replay"
+
+	cassetteFormatVersion = 1
+)
+
+// ErrCassetteMiss is returned in replay mode when no recorded interaction matches a request.
+var ErrCassetteMiss = errors.New("no recorded cassette interaction matches request")
+
+// CassetteFile is the on-disk cassette format.
+type CassetteFile struct {
+	Version      int                   `json:"version"`
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// CassetteInteraction is a single recorded request/response pair.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is the redacted request half of an interaction.
+type CassetteRequest struct {
+	Method     string            `json:"method"`
+	URL        string            `json:"url"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	BodyBase64 string            `json:"bodyBase64,omitempty"`
+}
+
+// CassetteResponse is the response half of an interaction.
+type CassetteResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	BodyBase64 string            `json:"bodyBase64,omitempty"`
+}
+
+// Cassette records or replays HTTP interactions for an asc.Client.
+type Cassette struct {
+	mode CassetteMode
+	path string
+
+	mu           sync.Mutex
+	interactions []CassetteInteraction
+	// pending holds unreplayed interaction indexes per match key, in recorded order.
+	pending map[string][]int
+}
+
+// cassetteRecordedRequestHeaders lists request headers kept in recordings.
+var cassetteRecordedRequestHeaders = []string{"Accept", "Authorization", "Content-Type"}
+
+// cassetteRecordedResponseHeaders lists response headers kept in recordings.
+var cassetteRecordedResponseHeaders = []string{"Content-Type", "Retry-After", "X-Rate-Limit", "Location"}
+
+var activeCassette struct {
+	mu       sync.RWMutex
+	cassette *Cassette
+}
+
+// OpenCassette prepares a cassette for the given mode and path.
+// Replay mode loads the recorded interactions immediately.
+func OpenCassette(mode CassetteMode, path string) (*Cassette, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, fmt.Errorf("cassette path is required")
+	}
+
+	cassette := &Cassette{mode: mode, path: path}
+	switch mode {
+	case CassetteModeRecord:
+		return cassette, nil
+	case CassetteModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read cassette: %w", err)
+		}
+		var file CassetteFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("parse cassette %s: %w", path, err)
+		}
+		if file.Version != cassetteFormatVersion {
+			return nil, fmt.Errorf("unsupported cassette version %d (expected %d)", file.Version, cassetteFormatVersion)
+		}
+		cassette.interactions = file.Interactions
+		cassette.pending = make(map[string][]int, len(file.Interactions))
+		for i, interaction := range file.Interactions {
+			key, err := cassetteMatchKey(interaction.Request.Method, interaction.Request.URL, interaction.Request.bodyBytes())
+			if err != nil {
+				return nil, fmt.Errorf("cassette interaction %d: %w", i+1, err)
+			}
+			cassette.pending[key] = append(cassette.pending[key], i)
+		}
+		return cassette, nil
+	default:
+		return nil, fmt.Errorf("unsupported cassette mode %q", mode)
+	}
+}
+
+// SetActiveCassette installs the cassette used by clients created afterwards.
+// Passing nil disables cassette handling.
+func SetActiveCassette(cassette *Cassette) {
+	activeCassette.mu.Lock()
+	defer activeCassette.mu.Unlock()
+	activeCassette.cassette = cassette
+}
+
+// ActiveCassette returns the installed cassette, if any.
+func ActiveCassette() *Cassette {
+	activeCassette.mu.RLock()
+	defer activeCassette.mu.RUnlock()
+	return activeCassette.cassette
+}
+
+// CassetteReplayActive reports whether clients are currently replaying a cassette.
+func CassetteReplayActive() bool {
+	cassette := ActiveCassette()
+	return cassette != nil && cassette.mode == CassetteModeReplay
+}
+
+// Mode returns the cassette mode.
+func (c *Cassette) Mode() CassetteMode {
+	return c.mode
+}
+
+// Path returns the cassette file path.
+func (c *Cassette) Path() string {
+	return c.path
+}
+
+// Save writes recorded interactions to the cassette path.
+// It is a no-op in replay mode.
+func (c *Cassette) Save() error {
+	if c == nil || c.mode != CassetteModeRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	file := CassetteFile{
+		Version:      cassetteFormatVersion,
+		Interactions: append([]CassetteInteraction(nil), c.interactions...),
+	}
+	c.mu.Unlock()
+	if file.Interactions == nil {
+		file.Interactions = []CassetteInteraction{}
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cassette: %w", err)
+	}
+	data = append(data, '\n')
+
+	if dir := filepath.Dir(c.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create cassette directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(c.path, data, 0o600); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+// Transport wraps next so that requests are recorded or replayed.
+func (c *Cassette) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &cassetteTransport{cassette: c, next: next}
+}
+
+type cassetteTransport struct {
+	cassette *Cassette
+	next     http.RoundTripper
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+		body = data
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if t.cassette.mode == CassetteModeReplay {
+		return t.cassette.replay(req, body)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	t.cassette.record(req, body, resp, respBody)
+	return resp, nil
+}
+
+func (c *Cassette) record(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte) {
+	interaction := CassetteInteraction{
+		Request: CassetteRequest{
+			Method:  strings.ToUpper(req.Method),
+			URL:     sanitizeURLForLog(req.URL.String()),
+			Headers: pickCassetteHeaders(req.Header, cassetteRecordedRequestHeaders),
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    pickCassetteHeaders(resp.Header, cassetteRecordedResponseHeaders),
+		},
+	}
+	if auth, ok := interaction.Request.Headers["Authorization"]; ok {
+		interaction.Request.Headers["Authorization"] = sanitizeAuthHeader(auth)
+	}
+	interaction.Request.Body, interaction.Request.BodyBase64 = encodeCassetteBody(reqBody)
+	interaction.Response.Body, interaction.Response.BodyBase64 = encodeCassetteBody(respBody)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, interaction)
+}
+
+func (c *Cassette) replay(req *http.Request, body []byte) (*http.Response, error) {
+	key, err := cassetteMatchKey(req.Method, sanitizeURLForLog(req.URL.String()), body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	queue := c.pending[key]
+	if len(queue) == 0 {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s %s", ErrCassetteMiss, strings.ToUpper(req.Method), sanitizeURLForLog(req.URL.String()))
+	}
+	index := queue[0]
+	if len(queue) > 1 {
+		// Later matches advance through the recording; the last one is reused
+		// so idempotent polling keeps returning the final observed state.
+		c.pending[key] = queue[1:]
+	}
+	recorded := c.interactions[index].Response
+	c.mu.Unlock()
+
+	respBody, err := recorded.bodyBytesOrError()
+	if err != nil {
+		return nil, fmt.Errorf("cassette interaction %d: %w", index+1, err)
+	}
+	header := make(http.Header, len(recorded.Headers))
+	for name, value := range recorded.Headers {
+		header.Set(name, value)
+	}
+	status := recorded.StatusCode
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// cassetteMatchKey builds the deterministic replay key from method, path,
+// sorted query and a canonical form of the body. The host is ignored so
+// recordings can be replayed against a different base URL.
+func cassetteMatchKey(method, rawURL string, body []byte) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid cassette URL %q: %w", rawURL, err)
+	}
+	path := parsed.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	query := parsed.Query().Encode()
+	return strings.ToUpper(method) + " " + path + "?" + query + "\n" + canonicalCassetteBody(body), nil
+}
+
+func canonicalCassetteBody(body []byte) string {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return ""
+	}
+	var decoded any
+	if err := json.Unmarshal(trimmed, &decoded); err == nil {
+		// Re-encoding sorts object keys and drops insignificant whitespace.
+		if canonical, err := json.Marshal(decoded); err == nil {
+			return string(canonical)
+		}
+	}
+	return string(trimmed)
+}
+
+func pickCassetteHeaders(header http.Header, names []string) map[string]string {
+	picked := map[string]string{}
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			picked[name] = value
+		}
+	}
+	if len(picked) == 0 {
+		return nil
+	}
+	return picked
+}
+
+func encodeCassetteBody(body []byte) (text string, encoded string) {
+	if len(body) == 0 {
+		return "", ""
+	}
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return "", base64.StdEncoding.EncodeToString(body)
+}
+
+func decodeCassetteBody(text, encoded string) ([]byte, error) {
+	if encoded != "" {
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode body: %w", err)
+		}
+		return data, nil
+	}
+	return []byte(text), nil
+}
+
+func (r CassetteRequest) bodyBytes() []byte {
+	data, err := decodeCassetteBody(r.Body, r.BodyBase64)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (r CassetteResponse) bodyBytesOrError() ([]byte, error) {
+	return decodeCassetteBody(r.Body, r.BodyBase64)
+}
+
+// wrapHTTPClientWithActiveCassette returns httpClient routed through the
+// active cassette, or httpClient unchanged when no cassette is installed.
+func wrapHTTPClientWithActiveCassette(httpClient *http.Client) *http.Client {
+	cassette := ActiveCassette()
+	if cassette == nil || httpClient == nil {
+		return httpClient
+	}
+	wrapped := *httpClient
+	wrapped.Transport = cassette.Transport(httpClient.Transport)
+	return &wrapped
+}
+
+// NewReplayClient creates a client for cassette replay when no credentials are
+// configured. It signs requests with an ephemeral key that is never sent to Apple.
+func NewReplayClient() (*Client, error) {
+	if !CassetteReplayActive() {
+		return nil, fmt.Errorf("replay client requires an active replay cassette")
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate replay key: %w", err)
+	}
+	return newClientWithPrivateKey("REPLAY", "REPLAY", key, newDefaultHTTPClient(ResolveTimeout())), nil
+}
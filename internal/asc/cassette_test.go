@@ -0,0 +1,147 @@
+package asc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newCassetteTestClient(t *testing.T, transport http.RoundTripper) *Client {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error: %v", err)
+	}
+	return newClientWithPrivateKey("KEY123", "ISS456", key, &http.Client{Transport: transport})
+}
+
+func TestCassette_RecordThenReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures", "run.cassette")
+
+	recorder, err := OpenCassette(CassetteModeRecord, path)
+	if err != nil {
+		t.Fatalf("OpenCassette(record) error: %v", err)
+	}
+	SetActiveCassette(recorder)
+	t.Cleanup(func() { SetActiveCassette(nil) })
+
+	var upstreamCalls int
+	recordClient := newCassetteTestClient(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		upstreamCalls++
+		if req.Method == http.MethodPatch {
+			return jsonResponse(http.StatusOK, `{"data":{"type":"apps","id":"1","attributes":{"name":"Renamed"}}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"apps","id":"1","attributes":{"name":"Demo"}}]}`), nil
+	}))
+
+	ctx := context.Background()
+	if _, err := recordClient.do(ctx, http.MethodGet, "/v1/apps?limit=5&fields[apps]=name", nil); err != nil {
+		t.Fatalf("record GET error: %v", err)
+	}
+	body := strings.NewReader(`{"data": {"type": "apps", "id": "1", "attributes": {"name": "Renamed"}}}`)
+	if _, err := recordClient.do(ctx, http.MethodPatch, "/v1/apps/1", body); err != nil {
+		t.Fatalf("record PATCH error: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if upstreamCalls != 2 {
+		t.Fatalf("expected 2 upstream calls while recording, got %d", upstreamCalls)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+	if !strings.Contains(string(data), "Bearer [REDACTED]") {
+		t.Fatalf("expected redacted authorization header in cassette, got %s", data)
+	}
+	if strings.Count(string(data), "eyJ") > 0 {
+		t.Fatalf("expected no JWT material in cassette, got %s", data)
+	}
+
+	player, err := OpenCassette(CassetteModeReplay, path)
+	if err != nil {
+		t.Fatalf("OpenCassette(replay) error: %v", err)
+	}
+	SetActiveCassette(player)
+
+	replayClient := newCassetteTestClient(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected network request in replay mode: %s %s", req.Method, req.URL)
+		return nil, nil
+	}))
+
+	// Query parameter order and JSON whitespace must not affect matching.
+	got, err := replayClient.do(ctx, http.MethodGet, "/v1/apps?fields[apps]=name&limit=5", nil)
+	if err != nil {
+		t.Fatalf("replay GET error: %v", err)
+	}
+	if !strings.Contains(string(got), `"Demo"`) {
+		t.Fatalf("unexpected replayed GET body: %s", got)
+	}
+	got, err = replayClient.do(ctx, http.MethodPatch, "/v1/apps/1", strings.NewReader(`{"data":{"attributes":{"name":"Renamed"},"id":"1","type":"apps"}}`))
+	if err != nil {
+		t.Fatalf("replay PATCH error: %v", err)
+	}
+	if !strings.Contains(string(got), `"Renamed"`) {
+		t.Fatalf("unexpected replayed PATCH body: %s", got)
+	}
+}
+
+func TestCassette_ReplayMissReturnsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.cassette")
+	if err := os.WriteFile(path, []byte(`{"version":1,"interactions":[]}`), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	player, err := OpenCassette(CassetteModeReplay, path)
+	if err != nil {
+		t.Fatalf("OpenCassette(replay) error: %v", err)
+	}
+	SetActiveCassette(player)
+	t.Cleanup(func() { SetActiveCassette(nil) })
+
+	client := newCassetteTestClient(t, nil)
+	_, err = client.do(context.Background(), http.MethodPost, "/v1/apps", strings.NewReader(`{}`))
+	if !errors.Is(err, ErrCassetteMiss) {
+		t.Fatalf("expected ErrCassetteMiss, got %v", err)
+	}
+}
+
+func TestCassette_ReplayAdvancesThroughRepeatedRequests(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "poll.cassette")
+	content := `{"version":1,"interactions":[
+{"request":{"method":"GET","url":"https://api.appstoreconnect.apple.com/v1/builds/1"},"response":{"statusCode":200,"body":"{\"state\":\"PROCESSING\"}"}},
+{"request":{"method":"GET","url":"https://api.appstoreconnect.apple.com/v1/builds/1"},"response":{"statusCode":200,"body":"{\"state\":\"VALID\"}"}}
+]}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	player, err := OpenCassette(CassetteModeReplay, path)
+	if err != nil {
+		t.Fatalf("OpenCassette(replay) error: %v", err)
+	}
+	SetActiveCassette(player)
+	t.Cleanup(func() { SetActiveCassette(nil) })
+
+	client := newCassetteTestClient(t, nil)
+	want := []string{"PROCESSING", "VALID", "VALID"}
+	for i, state := range want {
+		got, err := client.do(context.Background(), http.MethodGet, "/v1/builds/1", nil)
+		if err != nil {
+			t.Fatalf("call %d error: %v", i+1, err)
+		}
+		if !strings.Contains(string(got), state) {
+			t.Fatalf("call %d: expected %s, got %s", i+1, state, got)
+		}
+	}
+}
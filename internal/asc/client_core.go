@@ -72,6 +72,11 @@ var debugOverride struct {
 	verboseHTTP *bool
 }
 
+var baseURLOverride struct {
+	mu  sync.RWMutex
+	val string
+}
+
 var (
 	loadConfigFn = config.Load
 	loadConfigMu sync.Mutex
@@ -108,6 +113,40 @@ func SetDebugHTTPOverride(value *bool) {
 	debugOverride.verboseHTTP = value
 }
 
+// SetBaseURLOverride sets an explicit API base URL override.
+// When set, it takes precedence over env/config. An empty value falls back to env/config.
+func SetBaseURLOverride(value string) {
+	baseURLOverride.mu.Lock()
+	defer baseURLOverride.mu.Unlock()
+	baseURLOverride.val = strings.TrimSpace(value)
+}
+
+// ResolveBaseURL returns the App Store Connect API base URL without a trailing slash.
+// Precedence: explicit override > ASC_BASE_URL > config base_url > BaseURL.
+func ResolveBaseURL() (string, error) {
+	baseURLOverride.mu.RLock()
+	value := baseURLOverride.val
+	baseURLOverride.mu.RUnlock()
+
+	source := "base URL override"
+	if value == "" {
+		if override, ok := envValue("ASC_BASE_URL"); ok && override != "" {
+			value = override
+			source = "ASC_BASE_URL"
+		} else if cfg := loadConfig(); cfg != nil && strings.TrimSpace(cfg.BaseURL) != "" {
+			value = strings.TrimSpace(cfg.BaseURL)
+			source = "config base_url"
+		}
+	}
+	if value == "" {
+		return BaseURL, nil
+	}
+	if err := config.ValidateBaseURL(value); err != nil {
+		return "", fmt.Errorf("%s: %w", source, err)
+	}
+	return strings.TrimRight(value, "/"), nil
+}
+
 // ResolveRetryLogEnabled returns whether retry logging should be enabled.
 // Precedence: explicit override > env > config.
 func ResolveRetryLogEnabled() bool {
@@ -383,6 +422,14 @@ func WithRetry[T any](ctx context.Context, fn func() (T, error), opts RetryOptio
 		if ResolveRetryLogEnabled() {
 			logRetry(delay, retryCount+1, opts.MaxRetries, err)
 		}
+		if span := spanFromContext(ctx); span != nil {
+			span.SetAttribute("asc.retries", retryCount+1)
+			span.AddEvent("retry", map[string]any{
+				"attempt": retryCount + 1,
+				"delay":   delay.String(),
+				"error":   err.Error(),
+			})
+		}
 
 		if debugEnabled {
 			debugLogger.Info("⟳ Retrying request",
@@ -485,6 +532,14 @@ type Client struct {
 
 	mutatingRequestLimiterOnce sync.Once
 	mutatingRequestLimiter     chan struct{}
+
+	rateLimiterOnce sync.Once
+	rateLimiter     *rateLimiter
+
+	responseCache *ResponseCache
+	auditLog      *AuditLog
+
+	readOnly bool
 }
 
 // NewClient creates a new ASC client.
@@ -562,7 +617,7 @@ func newClientFromPEMWithHTTPClient(keyID, issuerID, privateKeyPEM string, httpC
 
 func newClientWithPrivateKey(keyID, issuerID string, privateKey *ecdsa.PrivateKey, httpClient *http.Client) *Client {
 	return &Client{
-		httpClient: httpClient,
+		httpClient: wrapHTTPClientWithActiveCassette(httpClient),
 		keyID:      keyID,
 		issuerID:   issuerID,
 		privateKey: privateKey,
@@ -0,0 +1,78 @@
+package asc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/config"
+)
+
+func TestResolveBaseURL_Precedence(t *testing.T) {
+	SetBaseURLOverride("")
+	t.Cleanup(func() { SetBaseURLOverride("") })
+	setConfigLoaderForTest(func() (*config.Config, error) {
+		return &config.Config{BaseURL: "https://config.example.com/"}, nil
+	})
+	t.Cleanup(resetConfigCacheForTest)
+	t.Setenv("ASC_BASE_URL", "")
+
+	if got, err := ResolveBaseURL(); err != nil || got != "https://config.example.com" {
+		t.Fatalf("ResolveBaseURL() from config = %q, %v", got, err)
+	}
+
+	t.Setenv("ASC_BASE_URL", "http://127.0.0.1:8686")
+	if got, err := ResolveBaseURL(); err != nil || got != "http://127.0.0.1:8686" {
+		t.Fatalf("ResolveBaseURL() from env = %q, %v", got, err)
+	}
+
+	SetBaseURLOverride("https://override.example.com")
+	if got, err := ResolveBaseURL(); err != nil || got != "https://override.example.com" {
+		t.Fatalf("ResolveBaseURL() from override = %q, %v", got, err)
+	}
+}
+
+func TestResolveBaseURL_DefaultsToAppStoreConnect(t *testing.T) {
+	SetBaseURLOverride("")
+	setConfigLoaderForTest(func() (*config.Config, error) { return &config.Config{}, nil })
+	t.Cleanup(resetConfigCacheForTest)
+	t.Setenv("ASC_BASE_URL", "")
+
+	if got, err := ResolveBaseURL(); err != nil || got != BaseURL {
+		t.Fatalf("ResolveBaseURL() = %q, %v; want %q", got, err, BaseURL)
+	}
+}
+
+func TestNewRequest_UsesConfiguredBaseURLAndRejectsInvalidEnv(t *testing.T) {
+	SetBaseURLOverride("")
+	setConfigLoaderForTest(func() (*config.Config, error) { return &config.Config{}, nil })
+	t.Cleanup(resetConfigCacheForTest)
+
+	t.Setenv("ASC_BASE_URL", "http://127.0.0.1:8686")
+	client := newTestClient(t, nil, jsonResponse(http.StatusOK, `{"data":[]}`))
+	req, err := client.newRequest(context.Background(), http.MethodGet, "/v1/apps", nil)
+	if err != nil {
+		t.Fatalf("newRequest() error: %v", err)
+	}
+	if got := req.URL.String(); got != "http://127.0.0.1:8686/v1/apps" {
+		t.Fatalf("request URL = %q", got)
+	}
+
+	t.Setenv("ASC_BASE_URL", "http://asc.example.com")
+	if _, err := client.newRequest(context.Background(), http.MethodGet, "/v1/apps", nil); err == nil || !strings.Contains(err.Error(), "ASC_BASE_URL") {
+		t.Fatalf("expected ASC_BASE_URL validation error, got %v", err)
+	}
+}
+
+func TestValidateNextURL_FollowsConfiguredEmulatorBaseURL(t *testing.T) {
+	SetBaseURLOverride("http://127.0.0.1:8686")
+	t.Cleanup(func() { SetBaseURLOverride("") })
+
+	if err := validateNextURL("http://127.0.0.1:8686/v1/apps?cursor=abc"); err != nil {
+		t.Fatalf("validateNextURL() error: %v", err)
+	}
+	if err := validateNextURL("https://api.appstoreconnect.apple.com/v1/apps?cursor=abc"); err == nil {
+		t.Fatal("expected production host to be rejected while an emulator base URL is configured")
+	}
+}
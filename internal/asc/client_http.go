@@ -23,6 +23,9 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body io.Re
 	if err := validateAPIPath(path); err != nil {
 		return nil, err
 	}
+	if err := c.checkReadOnly(method, path); err != nil {
+		return nil, err
+	}
 
 	// Generate JWT token
 	token, err := c.generateJWT()
@@ -32,7 +35,11 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body io.Re
 
 	url := path
 	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
-		url = BaseURL + path
+		baseURL, err := ResolveBaseURL()
+		if err != nil {
+			return nil, err
+		}
+		url = baseURL + path
 	}
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
@@ -68,13 +75,38 @@ func (c *Client) generateJWT() (string, error) {
 }
 
 // GenerateJWT generates a JWT for ASC API authentication.
+// An empty issuerID produces an Individual API key token.
 func GenerateJWT(keyID, issuerID string, privateKey *ecdsa.PrivateKey) (string, error) {
+	return GenerateScopedJWT(keyID, issuerID, privateKey, nil)
+}
+
+// individualKeySubject is the subject Apple requires for Individual API key tokens.
+const individualKeySubject = "user"
+
+// jwtClaims adds the optional App Store Connect scope claim.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Scope []string `json:"scope,omitempty"`
+}
+
+// GenerateScopedJWT generates a JWT restricted to the given scope entries
+// ("GET /v1/apps", "GET /v1/apps?filter[platform]=IOS"). A nil scope yields
+// an unrestricted token. Team keys set iss; Individual keys (empty issuerID)
+// set sub to "user" instead.
+func GenerateScopedJWT(keyID, issuerID string, privateKey *ecdsa.PrivateKey, scope []string) (string, error) {
 	now := time.Now()
-	claims := jwt.RegisteredClaims{
-		Issuer:    issuerID,
-		Audience:  jwt.ClaimStrings{"appstoreconnect-v1"},
-		IssuedAt:  jwt.NewNumericDate(now),
-		ExpiresAt: jwt.NewNumericDate(now.Add(tokenLifetime)),
+	claims := jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{"appstoreconnect-v1"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(tokenLifetime)),
+		},
+		Scope: scope,
+	}
+	if strings.TrimSpace(issuerID) == "" {
+		claims.Subject = individualKeySubject
+	} else {
+		claims.Issuer = issuerID
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
@@ -91,7 +123,7 @@ func GenerateJWT(keyID, issuerID string, privateKey *ecdsa.PrivateKey) (string,
 
 // do performs an HTTP request and returns the response.
 // GET/HEAD requests use retry logic for rate limiting by default.
-func (c *Client) do(ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) (respBody []byte, err error) {
 	var bodyBytes []byte
 	if body != nil {
 		var err error
@@ -101,22 +133,66 @@ func (c *Client) do(ctx context.Context, method, path string, body io.Reader) ([
 		}
 	}
 
+	if plan := ActivePlan(); plan != nil {
+		if err := validateAPIPath(path); err != nil {
+			return nil, err
+		}
+		if shouldLimitMutatingMethod(method) {
+			return plan.intercept(method, path, bodyBytes), nil
+		}
+		if respBody, ok := plan.lookup(path); ok {
+			return respBody, nil
+		}
+	}
+
+	route := templatedAPIPath(path)
+	ctx, span := startSpan(ctx, method+" "+route, spanKindClient, map[string]any{
+		"http.request.method": method,
+		"http.route":          route,
+	})
+	var status int
+	defer func() {
+		if status != 0 {
+			span.SetAttribute("http.response.status_code", status)
+		} else if err == nil {
+			span.SetAttribute("asc.cache_hit", true)
+		}
+		span.End(err)
+	}()
+
 	request := func(requestCtx context.Context) ([]byte, error) {
 		var reader io.Reader
 		if bodyBytes != nil {
 			reader = bytes.NewReader(bodyBytes)
 		}
-		return c.doOnce(requestCtx, method, path, reader)
+		respBody, respStatus, err := c.doOnceWithStatus(requestCtx, method, path, reader)
+		status = respStatus
+		return respBody, err
 	}
 
 	if shouldRetryMethod(method) {
 		retryOpts := ResolveRetryOptions()
-		return WithRetry(ctx, func() ([]byte, error) {
-			return request(ctx)
-		}, retryOpts)
+		fetch := func() ([]byte, error) {
+			return WithRetry(ctx, func() ([]byte, error) {
+				return request(ctx)
+			}, retryOpts)
+		}
+		if method == http.MethodGet {
+			return c.withResponseCache(path, fetch)
+		}
+		return fetch()
 	}
 	if shouldLimitMutatingMethod(method) {
-		return c.doWithMutatingRequestLimiter(ctx, request)
+		if err := c.checkReadOnly(method, path); err != nil {
+			return nil, err
+		}
+		prior := c.capturePriorState(ctx, method, path)
+		respBody, err := c.doWithMutatingRequestLimiter(ctx, request)
+		if err == nil {
+			c.responseCache.markMutated()
+		}
+		c.auditLog.record(ctx, c.keyID, method, path, bodyBytes, prior, status, respBody, err)
+		return respBody, err
 	}
 
 	return request(ctx)
@@ -179,12 +255,19 @@ func deriveMutatingRequestContext(ctx context.Context, requestTimeout time.Durat
 }
 
 func (c *Client) doOnce(ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
+	respBody, _, err := c.doOnceWithStatus(ctx, method, path, body)
+	return respBody, err
+}
+
+// doOnceWithStatus sends a single request and also returns the HTTP status
+// code (0 when no response was received).
+func (c *Client) doOnceWithStatus(ctx context.Context, method, path string, body io.Reader) ([]byte, int, error) {
 	start := time.Now()
 	debugSettings := resolveDebugSettings()
 
 	req, err := c.newRequest(ctx, method, path, body)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	if debugSettings.verboseHTTP {
@@ -196,8 +279,13 @@ func (c *Client) doOnce(ctx context.Context, method, path string, body io.Reader
 		)
 	}
 
+	limiter := c.getRateLimiter()
+	if err := limiter.wait(ctx); err != nil {
+		return nil, 0, err
+	}
 	resp, err := c.httpClient.Do(req)
 	elapsed := time.Since(start)
+	limiter.observe(resp)
 
 	if err != nil {
 		if debugSettings.verboseHTTP {
@@ -206,7 +294,7 @@ func (c *Client) doOnce(ctx context.Context, method, path string, body io.Reader
 				"elapsed", elapsed.String(),
 			)
 		}
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, 0, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -225,19 +313,20 @@ func (c *Client) doOnce(ctx context.Context, method, path string, body io.Reader
 		// Check for rate limiting (429) or service unavailable (503)
 		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
 			retryAfter := parseRetryAfterHeader(resp.Header.Get("Retry-After"))
-			return nil, &RetryableError{
+			return nil, resp.StatusCode, &RetryableError{
 				Err:        buildRetryableError(resp.StatusCode, retryAfter, respBody),
 				RetryAfter: retryAfter,
 			}
 		}
 
 		if err := ParseErrorWithStatus(respBody, resp.StatusCode); err != nil {
-			return nil, err
+			return nil, resp.StatusCode, err
 		}
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return nil, resp.StatusCode, fmt.Errorf("API request failed with status %d", resp.StatusCode)
 	}
 
-	return io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
+	return respBody, resp.StatusCode, err
 }
 
 // sanitizeAuthHeader redacts the JWT token from Authorization header for logging.
@@ -325,7 +414,8 @@ func parseRetryAfterHeader(value string) time.Duration {
 }
 
 // validateNextURL validates that a pagination URL is safe to use.
-// It ensures the URL is on the same host as BaseURL and uses HTTPS.
+// It ensures the URL is on the same host and scheme as the resolved base URL
+// (HTTPS unless a loopback emulator is configured).
 func validateNextURL(nextURL string) error {
 	if nextURL == "" {
 		return nil
@@ -342,19 +432,23 @@ func validateNextURL(nextURL string) error {
 		return fmt.Errorf("invalid pagination URL: %w", err)
 	}
 
-	baseURL, err := url.Parse(BaseURL)
+	resolvedBaseURL, err := ResolveBaseURL()
+	if err != nil {
+		return err
+	}
+	baseURL, err := url.Parse(resolvedBaseURL)
 	if err != nil {
 		return fmt.Errorf("invalid base URL: %w", err)
 	}
 
-	// Allow URLs on the same host as BaseURL
+	// Allow URLs on the same host as the base URL
 	if parsedURL.Host != baseURL.Host {
 		return fmt.Errorf("rejected pagination URL from untrusted host %q (expected %q)", parsedURL.Host, baseURL.Host)
 	}
 
-	// Require HTTPS for authentication endpoints
-	if parsedURL.Scheme != "https" {
-		return fmt.Errorf("rejected pagination URL with insecure scheme %q (expected https)", parsedURL.Scheme)
+	// Require HTTPS for authentication endpoints; only a loopback base URL may use http.
+	if parsedURL.Scheme != baseURL.Scheme {
+		return fmt.Errorf("rejected pagination URL with insecure scheme %q (expected %s)", parsedURL.Scheme, baseURL.Scheme)
 	}
 
 	return nil
@@ -459,10 +553,15 @@ func (c *Client) doStream(ctx context.Context, path string, accept string) (*htt
 		req.Header.Set("Accept", accept)
 	}
 
+	limiter := c.getRateLimiter()
+	if err := limiter.wait(ctx); err != nil {
+		return nil, err
+	}
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
+	limiter.observe(resp)
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		respBody, _ := io.ReadAll(resp.Body)
 		_ = resp.Body.Close()
@@ -1,8 +1,13 @@
 package asc
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"testing"
 	"time"
+
+	"github.com/golang-jwt/jwt/v5"
 )
 
 func TestGenerateJWT_UsesCachedTokenWhenStillValid(t *testing.T) {
@@ -35,3 +40,43 @@ func TestGenerateJWT_RefreshesExpiredOrNearExpiryToken(t *testing.T) {
 		t.Fatalf("expected client cache to update with fresh token, got %q", client.cachedJWT)
 	}
 }
+
+func TestGenerateScopedJWT_Claims(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error: %v", err)
+	}
+
+	parse := func(t *testing.T, signed string) jwt.MapClaims {
+		t.Helper()
+		claims := jwt.MapClaims{}
+		if _, err := jwt.ParseWithClaims(signed, claims, func(*jwt.Token) (any, error) {
+			return &key.PublicKey, nil
+		}); err != nil {
+			t.Fatalf("ParseWithClaims() error: %v", err)
+		}
+		return claims
+	}
+
+	team, err := GenerateJWT("KEY123", "ISS456", key)
+	if err != nil {
+		t.Fatalf("GenerateJWT() error: %v", err)
+	}
+	teamClaims := parse(t, team)
+	if teamClaims["iss"] != "ISS456" || teamClaims["sub"] != nil || teamClaims["scope"] != nil {
+		t.Fatalf("unexpected team claims: %v", teamClaims)
+	}
+
+	individual, err := GenerateScopedJWT("KEY123", "", key, []string{"GET /v1/apps"})
+	if err != nil {
+		t.Fatalf("GenerateScopedJWT() error: %v", err)
+	}
+	individualClaims := parse(t, individual)
+	if individualClaims["sub"] != "user" || individualClaims["iss"] != nil {
+		t.Fatalf("unexpected individual claims: %v", individualClaims)
+	}
+	scope, ok := individualClaims["scope"].([]any)
+	if !ok || len(scope) != 1 || scope[0] != "GET /v1/apps" {
+		t.Fatalf("unexpected scope claim: %v", individualClaims["scope"])
+	}
+}
@@ -0,0 +1,27 @@
+package asc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// RawRequest sends an arbitrary App Store Connect API request with the same
+// authentication, retries, rate limiting and path checks as typed methods.
+// path is either a relative API path or a pagination URL returned by the API.
+func (c *Client) RawRequest(ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil, fmt.Errorf("unsupported method %q", method)
+	}
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		if err := validateNextURL(path); err != nil {
+			return nil, err
+		}
+	}
+	return c.do(ctx, method, path, body)
+}
@@ -165,6 +165,9 @@ func (c *Client) newNotaryRequest(ctx context.Context, method, path string, body
 	if err := validateAPIPath(path); err != nil {
 		return nil, err
 	}
+	if err := c.checkReadOnly(method, path); err != nil {
+		return nil, err
+	}
 
 	token, err := GenerateNotaryJWT(c.keyID, c.issuerID, c.privateKey)
 	if err != nil {
@@ -189,6 +192,16 @@ func (c *Client) newNotaryRequest(ctx context.Context, method, path string, body
 
 // doNotary performs an HTTP request against the Notary API.
 func (c *Client) doNotary(ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
+	if plan := ActivePlan(); plan != nil && shouldLimitMutatingMethod(method) {
+		var bodyBytes []byte
+		if body != nil {
+			var err error
+			if bodyBytes, err = io.ReadAll(body); err != nil {
+				return nil, fmt.Errorf("failed to read request body: %w", err)
+			}
+		}
+		return plan.intercept(method, c.resolveNotaryBaseURL()+path, bodyBytes), nil
+	}
 	req, err := c.newNotaryRequest(ctx, method, path, body)
 	if err != nil {
 		return nil, err
@@ -335,6 +348,11 @@ func UploadToS3(ctx context.Context, creds S3Credentials, data io.Reader, payloa
 		contentType = "application/octet-stream"
 	}
 
+	if plan := ActivePlan(); plan != nil {
+		plan.recordUpload(http.MethodPut, fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", creds.Bucket, notaryS3Region, creds.Object), contentLength)
+		return nil
+	}
+
 	if contentLength > notaryS3MaxSingleUploadBytes {
 		return uploadMultipartToS3(ctx, creds, data, contentLength, contentType)
 	}
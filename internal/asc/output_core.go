@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 )
 
 func printPrettyRawJSON(data json.RawMessage) error {
@@ -27,6 +28,65 @@ func PrintTable(data any) error {
 	return renderByRegistry(data, RenderTable)
 }
 
+// PrintCSV prints data as CSV with a header row.
+func PrintCSV(data any) error {
+	return renderByRegistry(data, sectionedRenderer(RenderCSV))
+}
+
+// PrintTSV prints data as tab-separated values with a header row.
+func PrintTSV(data any) error {
+	return renderByRegistry(data, sectionedRenderer(RenderTSV))
+}
+
+// sectionedRenderer separates consecutive tables from multi-table (direct)
+// renderers with a blank line so each section keeps its own header row.
+func sectionedRenderer(render func([]string, [][]string)) func([]string, [][]string) {
+	sections := 0
+	return func(headers []string, rows [][]string) {
+		if sections > 0 {
+			_, _ = os.Stdout.WriteString("\n")
+		}
+		sections++
+		render(headers, rows)
+	}
+}
+
+// PrintNDJSON prints data as newline-delimited JSON. Responses with a Data
+// slice emit one line per resource; any other value is written as one line.
+func PrintNDJSON(data any) error {
+	enc := json.NewEncoder(os.Stdout)
+	items, ok := ndjsonItems(data)
+	if !ok {
+		return enc.Encode(data)
+	}
+	for i := 0; i < items.Len(); i++ {
+		if err := enc.Encode(items.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ndjsonItems returns the Data slice of a response struct, if it has one.
+// Raw JSON payloads (json.RawMessage) are not split.
+func ndjsonItems(data any) (reflect.Value, bool) {
+	value := reflect.ValueOf(data)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}, false
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	field := value.FieldByName("Data")
+	if !field.IsValid() || field.Kind() != reflect.Slice || field.Type().Elem().Kind() == reflect.Uint8 {
+		return reflect.Value{}, false
+	}
+	return field, true
+}
+
 // PrintJSON prints data as minified JSON (best for AI agents).
 func PrintJSON(data any) error {
 	enc := json.NewEncoder(os.Stdout)
@@ -0,0 +1,109 @@
+package asc
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestPrintCSV_EscapesFieldsAndKeepsHeaders(t *testing.T) {
+	resp := &AppsResponse{
+		Data: []Resource[AppAttributes]{
+			{ID: "1", Attributes: AppAttributes{Name: `Say "Hi", World`, BundleID: "com.example.hi", SKU: "SKU,1"}},
+			{ID: "2", Attributes: AppAttributes{Name: "Plain", BundleID: "com.example.plain"}},
+		},
+	}
+
+	output := captureStdout(t, func() error {
+		return PrintCSV(resp)
+	})
+	want := "ID,Name,Bundle ID,SKU\n" +
+		`1,"Say ""Hi"", World",com.example.hi,"SKU,1"` + "\n" +
+		"2,Plain,com.example.plain,\n"
+	if output != want {
+		t.Fatalf("unexpected CSV output:\n%s\nwant:\n%s", output, want)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV output does not parse: %v", err)
+	}
+	if records[1][1] != `Say "Hi", World` || records[1][3] != "SKU,1" {
+		t.Fatalf("unexpected round-tripped record %v", records[1])
+	}
+}
+
+func TestPrintCSV_EmptyResponseWritesHeaderRow(t *testing.T) {
+	output := captureStdout(t, func() error {
+		return PrintCSV(&AppsResponse{})
+	})
+	if output != "ID,Name,Bundle ID,SKU\n" {
+		t.Fatalf("expected header-only CSV, got %q", output)
+	}
+}
+
+func TestPrintTSV_UsesTabsAndQuotesEmbeddedTabs(t *testing.T) {
+	resp := &AppsResponse{
+		Data: []Resource[AppAttributes]{
+			{ID: "1", Attributes: AppAttributes{Name: "Tabbed", BundleID: "com.example.tab", SKU: "SKU\t1"}},
+		},
+	}
+
+	output := captureStdout(t, func() error {
+		return PrintTSV(resp)
+	})
+	want := "ID\tName\tBundle ID\tSKU\n1\tTabbed\tcom.example.tab\t\"SKU\t1\"\n"
+	if output != want {
+		t.Fatalf("unexpected TSV output %q, want %q", output, want)
+	}
+}
+
+func TestPadDelimitedRow(t *testing.T) {
+	if got := padDelimitedRow([]string{"a"}, 3); len(got) != 3 || got[0] != "a" {
+		t.Fatalf("expected padded row, got %v", got)
+	}
+	if got := padDelimitedRow([]string{"a", "b", "c"}, 2); len(got) != 2 {
+		t.Fatalf("expected truncated row, got %v", got)
+	}
+}
+
+func TestPrintNDJSON_WritesOneResourcePerLine(t *testing.T) {
+	resp := &AppsResponse{
+		Data: []Resource[AppAttributes]{
+			{Type: ResourceTypeApps, ID: "1", Attributes: AppAttributes{Name: "One"}},
+			{Type: ResourceTypeApps, ID: "2", Attributes: AppAttributes{Name: "Two"}},
+		},
+		Links: Links{Next: "https://example.com/next"},
+	}
+
+	output := captureStdout(t, func() error {
+		return PrintNDJSON(resp)
+	})
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), output)
+	}
+	if !strings.Contains(lines[0], `"id":"1"`) || !strings.Contains(lines[1], `"id":"2"`) {
+		t.Fatalf("unexpected NDJSON lines: %q", lines)
+	}
+	if strings.Contains(output, "links") {
+		t.Fatalf("expected envelope fields to be dropped, got %q", output)
+	}
+}
+
+func TestPrintNDJSON_NonListValuesUseSingleLine(t *testing.T) {
+	single := &AppResponse{Data: Resource[AppAttributes]{Type: ResourceTypeApps, ID: "1"}}
+	output := captureStdout(t, func() error {
+		return PrintNDJSON(single)
+	})
+	if strings.Count(output, "\n") != 1 || !strings.HasPrefix(output, `{"data":`) {
+		t.Fatalf("expected one JSON line for single resource, got %q", output)
+	}
+
+	empty := captureStdout(t, func() error {
+		return PrintNDJSON(&AppsResponse{})
+	})
+	if empty != "" {
+		t.Fatalf("expected no output for empty list, got %q", empty)
+	}
+}
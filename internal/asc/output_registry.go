@@ -362,3 +362,14 @@ func renderByRegistry(data any, render func([]string, [][]string)) error {
 
 	return PrintJSON(data)
 }
+
+// HasRowRenderer reports whether data's type has a registered row renderer,
+// which CSV and TSV output require.
+func HasRowRenderer(data any) bool {
+	t := reflect.TypeOf(data)
+	if _, ok := directRenderRegistry[t]; ok {
+		return true
+	}
+	_, ok := outputRegistry[t]
+	return ok
+}
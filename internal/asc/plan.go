@@ -0,0 +1,193 @@
+package asc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// planIDPrefix marks the synthetic resource IDs handed out in plan mode.
+const planIDPrefix = "plan-"
+
+// ErrPlanUnsupported is returned when plan mode cannot preview a mutating
+// request (for example web session requests) and refuses to send it instead.
+var ErrPlanUnsupported = errors.New("request cannot be previewed in plan mode")
+
+// PlannedRequest is a mutating request intercepted in plan mode.
+type PlannedRequest struct {
+	Step       int             `json:"step"`
+	Method     string          `json:"method"`
+	Path       string          `json:"path"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	Bytes      int64           `json:"bytes,omitempty"`
+	ResourceID string          `json:"resourceId,omitempty"`
+}
+
+// Plan records mutating requests instead of sending them and answers them
+// with synthetic responses, so multi-step commands can run to completion.
+type Plan struct {
+	mu        sync.Mutex
+	requests  []PlannedRequest
+	resources map[string]json.RawMessage
+	nextID    int
+}
+
+// NewPlan creates an empty plan.
+func NewPlan() *Plan {
+	return &Plan{resources: map[string]json.RawMessage{}}
+}
+
+var activePlan struct {
+	mu   sync.RWMutex
+	plan *Plan
+}
+
+// SetActivePlan installs plan for every client in the process. Passing nil
+// disables plan mode.
+func SetActivePlan(plan *Plan) {
+	activePlan.mu.Lock()
+	defer activePlan.mu.Unlock()
+	activePlan.plan = plan
+}
+
+// ActivePlan returns the installed plan, if any.
+func ActivePlan() *Plan {
+	activePlan.mu.RLock()
+	defer activePlan.mu.RUnlock()
+	return activePlan.plan
+}
+
+// Requests returns the intercepted requests in the order they were made.
+func (p *Plan) Requests() []PlannedRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlannedRequest(nil), p.requests...)
+}
+
+// CheckPlanSupported refuses mutating requests while plan mode is active.
+// Clients that cannot synthesize responses (the web session clients) call it
+// so a preview never sends anything.
+func CheckPlanSupported(method, path string) error {
+	if ActivePlan() == nil || !shouldLimitMutatingMethod(method) {
+		return nil
+	}
+	path, _, _ = strings.Cut(path, "?")
+	return fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, ErrPlanUnsupported)
+}
+
+type planResource struct {
+	Type          string          `json:"type"`
+	ID            string          `json:"id,omitempty"`
+	Attributes    json.RawMessage `json:"attributes,omitempty"`
+	Relationships json.RawMessage `json:"relationships,omitempty"`
+}
+
+// intercept records an API request and returns the response the API would
+// plausibly send: created and updated resources are echoed back (POST gets a
+// synthetic ID); deletes and relationship changes return no content.
+func (p *Plan) intercept(method, path string, body []byte) []byte {
+	method = strings.ToUpper(method)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	planned := PlannedRequest{Step: len(p.requests) + 1, Method: method, Path: path}
+	if json.Valid(body) {
+		planned.Body = append(json.RawMessage(nil), body...)
+	} else if len(body) > 0 {
+		planned.Bytes = int64(len(body))
+	}
+
+	var response []byte
+	if method == http.MethodPost || method == http.MethodPatch {
+		if resource, ok := requestResource(body); ok {
+			switch {
+			case method == http.MethodPost:
+				p.nextID++
+				resource.ID = fmt.Sprintf("%s%d", planIDPrefix, p.nextID)
+			case resource.ID == "":
+				resource.ID = lastPathSegment(path)
+			}
+			if resource.Type == "" {
+				resource.Type = lastPathSegment(path)
+			}
+			data, err := json.Marshal(resource)
+			if err == nil {
+				p.resources[resource.ID] = data
+				response, _ = json.Marshal(map[string]json.RawMessage{"data": data})
+				planned.ResourceID = resource.ID
+			}
+		}
+	}
+
+	p.requests = append(p.requests, planned)
+	return response
+}
+
+// requestResource extracts the single resource object of a JSON:API request
+// body. Linkage arrays (relationship updates) yield false.
+func requestResource(body []byte) (planResource, bool) {
+	var document struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return planResource{}, false
+	}
+	trimmed := strings.TrimSpace(string(document.Data))
+	if !strings.HasPrefix(trimmed, "{") {
+		return planResource{}, false
+	}
+	var resource planResource
+	if err := json.Unmarshal(document.Data, &resource); err != nil {
+		return planResource{}, false
+	}
+	return resource, true
+}
+
+// lookup answers GET requests that reference a synthetic ID, which the live
+// API would reject: the resource itself is echoed back and anything nested
+// under it is an empty collection.
+func (p *Plan) lookup(path string) ([]byte, bool) {
+	parsed, err := url.Parse(path)
+	if err != nil {
+		return nil, false
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, planIDPrefix) {
+			continue
+		}
+		p.mu.Lock()
+		resource, ok := p.resources[segment]
+		p.mu.Unlock()
+		if ok && i == len(segments)-1 {
+			data, err := json.Marshal(map[string]json.RawMessage{"data": resource})
+			return data, err == nil
+		}
+		return []byte(`{"data":[],"links":{}}`), true
+	}
+	return nil, false
+}
+
+// recordUpload records an upload to a presigned URL. The query string is
+// dropped so signatures never end up in the plan output.
+func (p *Plan) recordUpload(method, rawURL string, length int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	path, _, _ := strings.Cut(rawURL, "?")
+	p.requests = append(p.requests, PlannedRequest{
+		Step:   len(p.requests) + 1,
+		Method: strings.ToUpper(method),
+		Path:   path,
+		Bytes:  length,
+	})
+}
+
+func lastPathSegment(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	return segments[len(segments)-1]
+}
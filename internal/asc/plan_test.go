@@ -0,0 +1,131 @@
+package asc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPlanInterceptsMutatingRequests(t *testing.T) {
+	plan := NewPlan()
+	SetActivePlan(plan)
+	t.Cleanup(func() { SetActivePlan(nil) })
+
+	client := newTestClient(t, func(req *http.Request) {
+		if req.Method != http.MethodGet || req.URL.Path != "/v1/apps/123" {
+			t.Fatalf("unexpected request sent in plan mode: %s %s", req.Method, req.URL.String())
+		}
+	}, jsonResponse(http.StatusOK, `{"data":{"type":"apps","id":"123"}}`))
+	ctx := context.Background()
+
+	if _, err := client.do(ctx, http.MethodGet, "/v1/apps/123", nil); err != nil {
+		t.Fatalf("GET error: %v", err)
+	}
+
+	created, err := client.do(ctx, http.MethodPost, "/v1/betaGroups", strings.NewReader(
+		`{"data":{"type":"betaGroups","attributes":{"name":"QA"},"relationships":{"app":{"data":{"type":"apps","id":"123"}}}}}`))
+	if err != nil {
+		t.Fatalf("POST error: %v", err)
+	}
+	var group struct {
+		Data struct {
+			Type       string            `json:"type"`
+			ID         string            `json:"id"`
+			Attributes map[string]string `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(created, &group); err != nil {
+		t.Fatalf("unmarshal synthetic response: %v", err)
+	}
+	if group.Data.Type != "betaGroups" || group.Data.ID != "plan-1" || group.Data.Attributes["name"] != "QA" {
+		t.Fatalf("unexpected synthetic response %s", created)
+	}
+
+	fetched, err := client.do(ctx, http.MethodGet, "/v1/betaGroups/plan-1", nil)
+	if err != nil {
+		t.Fatalf("GET synthetic resource error: %v", err)
+	}
+	if !strings.Contains(string(fetched), `"id":"plan-1"`) {
+		t.Fatalf("expected synthetic resource, got %s", fetched)
+	}
+	nested, err := client.do(ctx, http.MethodGet, "/v1/betaGroups/plan-1/builds?limit=10", nil)
+	if err != nil {
+		t.Fatalf("GET nested collection error: %v", err)
+	}
+	if !strings.Contains(string(nested), `"data":[]`) {
+		t.Fatalf("expected empty collection, got %s", nested)
+	}
+
+	updated, err := client.do(ctx, http.MethodPatch, "/v1/apps/123", strings.NewReader(
+		`{"data":{"type":"apps","id":"123","attributes":{"primaryLocale":"en-US"}}}`))
+	if err != nil {
+		t.Fatalf("PATCH error: %v", err)
+	}
+	if !strings.Contains(string(updated), `"id":"123"`) {
+		t.Fatalf("expected PATCH echo, got %s", updated)
+	}
+
+	deleted, err := client.do(ctx, http.MethodDelete, "/v1/betaGroups/plan-1/relationships/builds", strings.NewReader(
+		`{"data":[{"type":"builds","id":"b1"}]}`))
+	if err != nil {
+		t.Fatalf("DELETE error: %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("expected empty DELETE response, got %s", deleted)
+	}
+
+	requests := plan.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 planned requests, got %+v", requests)
+	}
+	want := []struct{ method, path, id string }{
+		{http.MethodPost, "/v1/betaGroups", "plan-1"},
+		{http.MethodPatch, "/v1/apps/123", "123"},
+		{http.MethodDelete, "/v1/betaGroups/plan-1/relationships/builds", ""},
+	}
+	for i, expected := range want {
+		got := requests[i]
+		if got.Step != i+1 || got.Method != expected.method || got.Path != expected.path || got.ResourceID != expected.id {
+			t.Fatalf("request %d = %+v, want %+v", i, got, expected)
+		}
+		if len(got.Body) == 0 {
+			t.Fatalf("request %d missing body", i)
+		}
+	}
+}
+
+func TestPlanRecordsUploadsWithoutSignatures(t *testing.T) {
+	plan := NewPlan()
+	plan.recordUpload("put", "https://upload.example.com/part?X-Amz-Signature=secret", 512)
+
+	requests := plan.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected one planned upload, got %+v", requests)
+	}
+	if requests[0].Method != http.MethodPut || requests[0].Path != "https://upload.example.com/part" || requests[0].Bytes != 512 {
+		t.Fatalf("unexpected planned upload %+v", requests[0])
+	}
+}
+
+func TestCheckPlanSupported(t *testing.T) {
+	if err := CheckPlanSupported(http.MethodPost, "/apps"); err != nil {
+		t.Fatalf("expected no error without a plan, got %v", err)
+	}
+
+	SetActivePlan(NewPlan())
+	t.Cleanup(func() { SetActivePlan(nil) })
+
+	if err := CheckPlanSupported(http.MethodGet, "/apps"); err != nil {
+		t.Fatalf("expected reads to be allowed, got %v", err)
+	}
+	err := CheckPlanSupported(http.MethodPost, "/apps?token=secret")
+	if !errors.Is(err, ErrPlanUnsupported) {
+		t.Fatalf("expected ErrPlanUnsupported, got %v", err)
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Fatalf("expected query to be stripped, got %q", err.Error())
+	}
+}
@@ -8,23 +8,34 @@ import (
 
 // PollUntil repeatedly executes check until it returns done=true, an error,
 // or the context is canceled. It executes check immediately before waiting.
-func PollUntil[T any](ctx context.Context, interval time.Duration, check func(context.Context) (T, bool, error)) (T, error) {
-	var zero T
-
+func PollUntil[T any](ctx context.Context, interval time.Duration, check func(context.Context) (T, bool, error)) (value T, err error) {
 	if interval <= 0 {
-		return zero, fmt.Errorf("poll interval must be greater than zero")
+		return value, fmt.Errorf("poll interval must be greater than zero")
 	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
+	ctx, span := StartSpan(ctx, "poll", map[string]any{"poll.interval": interval.String()})
+	attempts := 0
+	defer func() {
+		span.SetAttribute("poll.attempts", attempts)
+		span.End(err)
+	}()
+	poll := func() (T, bool, error) {
+		attempts++
+		return check(ctx)
+	}
+
+	var zero T
+
 	select {
 	case <-ctx.Done():
 		return zero, ctx.Err()
 	default:
 	}
 
-	value, done, err := check(ctx)
+	value, done, err := poll()
 	if err != nil {
 		return zero, err
 	}
@@ -40,7 +51,7 @@ func PollUntil[T any](ctx context.Context, interval time.Duration, check func(co
 		case <-ctx.Done():
 			return zero, ctx.Err()
 		case <-ticker.C:
-			value, done, err = check(ctx)
+			value, done, err = poll()
 			if err != nil {
 				return zero, err
 			}
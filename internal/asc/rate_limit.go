@@ -0,0 +1,236 @@
+package asc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// rateLimitHeader is the App Store Connect quota header, e.g.
+	// "user-hour-lim:3600;user-hour-rem:3545;".
+	rateLimitHeader = "X-Rate-Limit"
+	// rateLimitWindow is the rolling window Apple applies to the hourly quota.
+	rateLimitWindow = time.Hour
+	// rateLimitLowWatermark is the fraction of the quota below which requests
+	// are paced at the sustainable refill rate instead of sent immediately.
+	rateLimitLowWatermark = 0.10
+)
+
+// RateLimitStatus is the most recently observed App Store Connect quota for a key.
+type RateLimitStatus struct {
+	Limit      int       `json:"limit"`
+	Remaining  int       `json:"remaining"`
+	ObservedAt time.Time `json:"observedAt"`
+}
+
+// rateLimiter is a token bucket shared by every client and goroutine using
+// the same API key. It stays out of the way while the quota is healthy and
+// paces requests at limit/hour once the remaining budget is low.
+type rateLimiter struct {
+	mu          sync.Mutex
+	status      RateLimitStatus
+	known       bool
+	nextAllowed time.Time
+
+	now   func() time.Time
+	sleep func(context.Context, time.Duration) error
+}
+
+var rateLimiters struct {
+	mu    sync.Mutex
+	byKey map[string]*rateLimiter
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{now: time.Now, sleep: sleepContext}
+}
+
+// sharedRateLimiter returns the limiter for an API key, creating it on first use.
+func sharedRateLimiter(key string) *rateLimiter {
+	rateLimiters.mu.Lock()
+	defer rateLimiters.mu.Unlock()
+
+	if rateLimiters.byKey == nil {
+		rateLimiters.byKey = map[string]*rateLimiter{}
+	}
+	limiter, ok := rateLimiters.byKey[key]
+	if !ok {
+		limiter = newRateLimiter()
+		rateLimiters.byKey[key] = limiter
+	}
+	return limiter
+}
+
+func (c *Client) getRateLimiter() *rateLimiter {
+	c.rateLimiterOnce.Do(func() {
+		if c.rateLimiter == nil {
+			c.rateLimiter = sharedRateLimiter(c.issuerID + "/" + c.keyID)
+		}
+	})
+	return c.rateLimiter
+}
+
+// RateLimitStatus returns the last quota observed for this client's API key.
+func (c *Client) RateLimitStatus() (RateLimitStatus, bool) {
+	return c.getRateLimiter().snapshot()
+}
+
+// ProbeRateLimit performs a minimal read request and returns the quota
+// reported by App Store Connect for this client's API key.
+func (c *Client) ProbeRateLimit(ctx context.Context) (RateLimitStatus, error) {
+	if _, err := c.do(ctx, http.MethodGet, "/v1/apps?limit=1&fields[apps]=name", nil); err != nil {
+		return RateLimitStatus{}, err
+	}
+	status, ok := c.RateLimitStatus()
+	if !ok {
+		return RateLimitStatus{}, fmt.Errorf("App Store Connect response did not include an %s header", rateLimitHeader)
+	}
+	return status, nil
+}
+
+func (l *rateLimiter) snapshot() (RateLimitStatus, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.status, l.known
+}
+
+// observe records quota information from a response.
+func (l *rateLimiter) observe(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	if limit, remaining, ok := parseRateLimitHeader(resp.Header.Get(rateLimitHeader)); ok {
+		l.record(limit, remaining)
+		return
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		l.exhaust()
+	}
+}
+
+func (l *rateLimiter) record(limit, remaining int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.status = RateLimitStatus{Limit: limit, Remaining: remaining, ObservedAt: l.now()}
+	l.known = true
+}
+
+// exhaust marks the quota as spent after a 429 without a quota header.
+func (l *rateLimiter) exhaust() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.known {
+		return
+	}
+	l.status.Remaining = 0
+	l.status.ObservedAt = l.now()
+}
+
+// wait blocks until the caller may send a request under the current budget.
+// Replayed cassettes never reach App Store Connect, so they are not paced.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if CassetteReplayActive() {
+		return nil
+	}
+
+	l.mu.Lock()
+	if !l.known || l.status.Limit <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+
+	lowWatermark := int(float64(l.status.Limit) * rateLimitLowWatermark)
+	if l.status.Remaining > lowWatermark {
+		// Track our own consumption between responses so concurrent workers
+		// notice the budget shrinking before the next header arrives.
+		l.status.Remaining--
+		l.mu.Unlock()
+		return nil
+	}
+
+	interval := rateLimitWindow / time.Duration(l.status.Limit)
+	now := l.now()
+	start := l.nextAllowed
+	if start.Before(now) {
+		start = now
+	}
+	l.nextAllowed = start.Add(interval)
+	if l.status.Remaining > 0 {
+		l.status.Remaining--
+	}
+	l.mu.Unlock()
+
+	delay := start.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	if ResolveRetryLogEnabled() {
+		retryLogger.Info("throttling request", "delay", delay.String(), "reason", "App Store Connect rate limit budget low")
+	}
+	if err := l.sleep(ctx, delay); err != nil {
+		return fmt.Errorf("wait for rate limit budget: %w", err)
+	}
+	return nil
+}
+
+// parseRateLimitHeader parses "user-hour-lim:3600;user-hour-rem:3545;".
+// The user-hour pair is preferred; otherwise the first complete pair wins.
+func parseRateLimitHeader(value string) (limit, remaining int, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, 0, false
+	}
+
+	limits := map[string]int{}
+	remainders := map[string]int{}
+	var prefixes []string
+	for part := range strings.SplitSeq(value, ";") {
+		name, raw, found := strings.Cut(strings.TrimSpace(part), ":")
+		if !found {
+			continue
+		}
+		parsed, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil || parsed < 0 {
+			continue
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case strings.HasSuffix(name, "-lim"):
+			prefix := strings.TrimSuffix(name, "-lim")
+			if _, seen := limits[prefix]; !seen {
+				prefixes = append(prefixes, prefix)
+			}
+			limits[prefix] = parsed
+		case strings.HasSuffix(name, "-rem"):
+			remainders[strings.TrimSuffix(name, "-rem")] = parsed
+		}
+	}
+
+	if lim, okLim := limits["user-hour"]; okLim {
+		if rem, okRem := remainders["user-hour"]; okRem {
+			return lim, rem, true
+		}
+	}
+	for _, prefix := range prefixes {
+		if rem, okRem := remainders[prefix]; okRem {
+			return limits[prefix], rem, true
+		}
+	}
+	return 0, 0, false
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,128 @@
+package asc
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimitHeader(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		wantLimit     int
+		wantRemaining int
+		wantOK        bool
+	}{
+		{name: "apple format", value: "user-hour-lim:3600;user-hour-rem:3545;", wantLimit: 3600, wantRemaining: 3545, wantOK: true},
+		{name: "prefers user hour", value: "app-minute-lim:60;app-minute-rem:1;user-hour-lim:3600;user-hour-rem:10", wantLimit: 3600, wantRemaining: 10, wantOK: true},
+		{name: "falls back to first pair", value: "team-hour-lim:500;team-hour-rem:499", wantLimit: 500, wantRemaining: 499, wantOK: true},
+		{name: "missing remaining", value: "user-hour-lim:3600;", wantOK: false},
+		{name: "garbage", value: "nonsense", wantOK: false},
+		{name: "empty", value: "", wantOK: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limit, remaining, ok := parseRateLimitHeader(test.value)
+			if ok != test.wantOK || limit != test.wantLimit || remaining != test.wantRemaining {
+				t.Fatalf("parseRateLimitHeader(%q) = (%d, %d, %v), want (%d, %d, %v)", test.value, limit, remaining, ok, test.wantLimit, test.wantRemaining, test.wantOK)
+			}
+		})
+	}
+}
+
+func newFakeClockRateLimiter() (*rateLimiter, *[]time.Duration) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var (
+		mu     sync.Mutex
+		sleeps []time.Duration
+	)
+	limiter := newRateLimiter()
+	limiter.now = func() time.Time { return now }
+	limiter.sleep = func(_ context.Context, delay time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sleeps = append(sleeps, delay)
+		return nil
+	}
+	return limiter, &sleeps
+}
+
+func TestRateLimiter_DoesNotWaitWhileBudgetHealthy(t *testing.T) {
+	limiter, sleeps := newFakeClockRateLimiter()
+	limiter.record(3600, 3000)
+
+	for range 5 {
+		if err := limiter.wait(context.Background()); err != nil {
+			t.Fatalf("wait() error: %v", err)
+		}
+	}
+	if len(*sleeps) != 0 {
+		t.Fatalf("expected no throttling, got sleeps %v", *sleeps)
+	}
+	if status, _ := limiter.snapshot(); status.Remaining != 2995 {
+		t.Fatalf("expected local budget estimate to decrease, got %d", status.Remaining)
+	}
+}
+
+func TestRateLimiter_PacesRequestsWhenBudgetLow(t *testing.T) {
+	limiter, sleeps := newFakeClockRateLimiter()
+	limiter.record(3600, 100)
+
+	for range 3 {
+		if err := limiter.wait(context.Background()); err != nil {
+			t.Fatalf("wait() error: %v", err)
+		}
+	}
+	want := []time.Duration{time.Second, 2 * time.Second}
+	if len(*sleeps) != len(want) {
+		t.Fatalf("sleeps = %v, want %v", *sleeps, want)
+	}
+	for i := range want {
+		if (*sleeps)[i] != want[i] {
+			t.Fatalf("sleeps = %v, want %v", *sleeps, want)
+		}
+	}
+}
+
+func TestRateLimiter_ExhaustOnTooManyRequests(t *testing.T) {
+	limiter, _ := newFakeClockRateLimiter()
+	limiter.record(3600, 2000)
+
+	limiter.observe(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}})
+	if status, _ := limiter.snapshot(); status.Remaining != 0 {
+		t.Fatalf("expected 429 to exhaust budget, got remaining %d", status.Remaining)
+	}
+}
+
+func TestClient_ObservesRateLimitHeaderAndSharesLimiterPerKey(t *testing.T) {
+	resp := jsonResponse(http.StatusOK, `{"data":[]}`)
+	resp.Header.Set("X-Rate-Limit", "user-hour-lim:3600;user-hour-rem:3545;")
+	client := newTestClient(t, nil, resp)
+	client.keyID = "RATE-LIMIT-TEST-KEY"
+
+	status, err := client.ProbeRateLimit(context.Background())
+	if err != nil {
+		t.Fatalf("ProbeRateLimit() error: %v", err)
+	}
+	if status.Limit != 3600 || status.Remaining != 3545 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	other := &Client{keyID: client.keyID, issuerID: client.issuerID}
+	if got, ok := other.RateLimitStatus(); !ok || got.Remaining != 3545 {
+		t.Fatalf("expected limiter shared across clients for the same key, got %+v (%v)", got, ok)
+	}
+}
+
+func TestClient_ProbeRateLimitWithoutHeaderFails(t *testing.T) {
+	client := newTestClient(t, nil, jsonResponse(http.StatusOK, `{"data":[]}`))
+	client.keyID = "RATE-LIMIT-MISSING-KEY"
+
+	if _, err := client.ProbeRateLimit(context.Background()); err == nil {
+		t.Fatal("expected error when X-Rate-Limit header is absent")
+	}
+}
@@ -0,0 +1,105 @@
+package asc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// readOnlyEnvVar enables read-only mode for every client in the process.
+const readOnlyEnvVar = "ASC_READ_ONLY"
+
+// ErrReadOnly is returned when read-only mode blocks a mutating request.
+var ErrReadOnly = errors.New("read-only mode")
+
+// ReadOnlyError describes a mutating request refused by read-only mode.
+type ReadOnlyError struct {
+	Method string
+	Path   string
+	Source string
+}
+
+func (e *ReadOnlyError) Error() string {
+	// Drop the query so presigned upload URLs never end up in error output.
+	path, _, _ := strings.Cut(e.Path, "?")
+	return fmt.Sprintf("%s %s blocked: read-only mode is enabled (%s)", e.Method, path, e.Source)
+}
+
+// Is reports whether target is ErrReadOnly.
+func (e *ReadOnlyError) Is(target error) bool {
+	return target == ErrReadOnly
+}
+
+var readOnlyOverride struct {
+	mu  sync.RWMutex
+	val *bool
+}
+
+// SetReadOnlyOverride sets an explicit read-only override (--read-only).
+// When set, it takes precedence over ASC_READ_ONLY. When unset (nil), behavior falls back to env.
+func SetReadOnlyOverride(value *bool) {
+	readOnlyOverride.mu.Lock()
+	defer readOnlyOverride.mu.Unlock()
+	readOnlyOverride.val = value
+}
+
+// resolveReadOnly returns whether process-wide read-only mode is enabled and
+// where the setting came from.
+// Precedence: explicit override > ASC_READ_ONLY.
+func resolveReadOnly() (bool, string) {
+	readOnlyOverride.mu.RLock()
+	override := readOnlyOverride.val
+	readOnlyOverride.mu.RUnlock()
+	if override != nil {
+		return *override, "--read-only"
+	}
+	if value, ok := envValue(readOnlyEnvVar); ok {
+		switch strings.ToLower(value) {
+		case "1", "true", "yes", "y", "on":
+			return true, readOnlyEnvVar
+		}
+	}
+	return false, ""
+}
+
+// ResolveReadOnly reports whether process-wide read-only mode is enabled.
+func ResolveReadOnly() bool {
+	enabled, _ := resolveReadOnly()
+	return enabled
+}
+
+// CheckReadOnly returns a ReadOnlyError when process-wide read-only mode is
+// enabled and method would mutate state. The web clients use it to apply the
+// same guard as the App Store Connect API client.
+func CheckReadOnly(method, path string) error {
+	if !shouldLimitMutatingMethod(method) {
+		return nil
+	}
+	if enabled, source := resolveReadOnly(); enabled {
+		return &ReadOnlyError{Method: strings.ToUpper(method), Path: path, Source: source}
+	}
+	return nil
+}
+
+// SetReadOnly marks the client read-only, e.g. for a profile configured with
+// read_only_profiles. Process-wide settings apply regardless.
+func (c *Client) SetReadOnly(readOnly bool) {
+	c.readOnly = readOnly
+}
+
+// ReadOnly reports whether the client refuses mutating requests.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly || ResolveReadOnly()
+}
+
+// checkReadOnly refuses mutating requests before anything is sent.
+func (c *Client) checkReadOnly(method, path string) error {
+	if err := CheckReadOnly(method, path); err != nil {
+		return err
+	}
+	if c.readOnly && shouldLimitMutatingMethod(method) {
+		return &ReadOnlyError{Method: strings.ToUpper(method), Path: path, Source: "profile"}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package asc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestReadOnlyBlocksMutatingRequestsBeforeSending(t *testing.T) {
+	SetReadOnlyOverride(nil)
+	t.Setenv(readOnlyEnvVar, "1")
+
+	client := newTestClient(t, func(req *http.Request) {
+		if req.Method != http.MethodGet {
+			t.Fatalf("unexpected %s request sent in read-only mode", req.Method)
+		}
+	}, jsonResponse(http.StatusOK, `{"data":[]}`))
+
+	if _, err := client.do(context.Background(), http.MethodGet, "/v1/apps", nil); err != nil {
+		t.Fatalf("GET error: %v", err)
+	}
+
+	_, err := client.do(context.Background(), http.MethodPatch, "/v1/apps/123", strings.NewReader(`{}`))
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "PATCH /v1/apps/123") || !strings.Contains(err.Error(), readOnlyEnvVar) {
+		t.Fatalf("unexpected error message %q", err.Error())
+	}
+}
+
+func TestReadOnlyClientSetting(t *testing.T) {
+	SetReadOnlyOverride(nil)
+	t.Setenv(readOnlyEnvVar, "")
+
+	client := newTestClient(t, nil, jsonResponse(http.StatusOK, `{}`))
+	if client.ReadOnly() {
+		t.Fatal("expected client to allow mutations by default")
+	}
+	client.SetReadOnly(true)
+
+	_, err := client.do(context.Background(), http.MethodDelete, "/v1/betaGroups/1", nil)
+	var readOnlyErr *ReadOnlyError
+	if !errors.As(err, &readOnlyErr) || readOnlyErr.Source != "profile" {
+		t.Fatalf("expected profile ReadOnlyError, got %v", err)
+	}
+}
+
+func TestCheckReadOnly(t *testing.T) {
+	t.Setenv(readOnlyEnvVar, "")
+	enabled := true
+	SetReadOnlyOverride(&enabled)
+	t.Cleanup(func() { SetReadOnlyOverride(nil) })
+
+	if err := CheckReadOnly(http.MethodGet, "/v1/apps"); err != nil {
+		t.Fatalf("GET should be allowed, got %v", err)
+	}
+	err := CheckReadOnly(http.MethodPut, "https://upload.example.com/part?X-Amz-Signature=secret")
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Fatalf("error leaked query string: %q", err.Error())
+	}
+
+	SetReadOnlyOverride(nil)
+	if err := CheckReadOnly(http.MethodPost, "/v1/apps"); err != nil {
+		t.Fatalf("expected mutations allowed without override, got %v", err)
+	}
+}
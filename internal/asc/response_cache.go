@@ -0,0 +1,368 @@
+package asc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	responseCacheEntryExt = ".json"
+
+	// DefaultResponseCacheMaxBytes caps the on-disk response cache size.
+	DefaultResponseCacheMaxBytes int64 = 100 << 20
+)
+
+// Per-resource TTLs for slow-changing lookups. Anything not listed here is
+// never cached, so regular list/get commands always see live data.
+const (
+	referenceDataCacheTTL = 7 * 24 * time.Hour
+	pricePointCacheTTL    = 24 * time.Hour
+	appLookupCacheTTL     = time.Hour
+)
+
+// ResponseCache is a content-addressed on-disk cache for GET responses of
+// slow-changing App Store Connect resources.
+type ResponseCache struct {
+	root     string
+	scope    string
+	maxBytes int64
+	refresh  bool
+
+	// mutated is set after a successful write through the owning client;
+	// later reads in the same process must observe live data.
+	mutated atomic.Bool
+
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// NewResponseCache creates a cache storing entries under root/scope.
+// When refresh is true, cached entries are ignored but fresh responses are
+// still written back. A non-positive maxBytes uses DefaultResponseCacheMaxBytes.
+func NewResponseCache(root, scope string, maxBytes int64, refresh bool) *ResponseCache {
+	if maxBytes <= 0 {
+		maxBytes = DefaultResponseCacheMaxBytes
+	}
+	return &ResponseCache{
+		root:     root,
+		scope:    scope,
+		maxBytes: maxBytes,
+		refresh:  refresh,
+		now:      time.Now,
+	}
+}
+
+// SetResponseCache attaches an on-disk response cache to the client.
+// Passing nil disables caching.
+func (c *Client) SetResponseCache(cache *ResponseCache) {
+	c.responseCache = cache
+}
+
+// markMutated makes the cache skip reads for the rest of the process while
+// still refreshing entries from live responses.
+func (rc *ResponseCache) markMutated() {
+	if rc != nil {
+		rc.mutated.Store(true)
+	}
+}
+
+type responseCacheEntry struct {
+	Request   string          `json:"request"`
+	StoredAt  time.Time       `json:"storedAt"`
+	ExpiresAt time.Time       `json:"expiresAt"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// withResponseCache serves a GET from the cache when possible and stores
+// successful fetches for cacheable resources.
+func (c *Client) withResponseCache(path string, fetch func() ([]byte, error)) ([]byte, error) {
+	cache := c.responseCache
+	if cache == nil || ActiveCassette() != nil {
+		return fetch()
+	}
+	request, ttl, ok := responseCacheRequest(path)
+	if !ok {
+		return fetch()
+	}
+
+	baseURL, err := ResolveBaseURL()
+	if err != nil {
+		return fetch()
+	}
+	key := responseCacheKey(baseURL, c.issuerID, c.keyID, request)
+	if !cache.refresh && !cache.mutated.Load() {
+		if body, hit := cache.get(key); hit {
+			return body, nil
+		}
+	}
+
+	body, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	// Cache write failures must never fail the command.
+	_ = cache.put(key, request, body, ttl)
+	return body, nil
+}
+
+// responseCacheRequest normalizes a request path (or absolute next URL) and
+// returns its TTL when the resource is cacheable.
+func responseCacheRequest(path string) (string, time.Duration, bool) {
+	parsed, err := url.Parse(path)
+	if err != nil {
+		return "", 0, false
+	}
+	query := parsed.Query()
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 {
+		return "", 0, false
+	}
+
+	var ttl time.Duration
+	switch segments[len(segments)-1] {
+	case "territories", "appCategories", "subcategories":
+		ttl = referenceDataCacheTTL
+	case "pricePoints", "appPricePoints", "equalizations":
+		ttl = pricePointCacheTTL
+	case "apps":
+		// Only name/bundle ID lookups; plain app lists stay live.
+		if len(segments) == 2 && (query.Has("filter[bundleId]") || query.Has("filter[name]")) {
+			ttl = appLookupCacheTTL
+		}
+	}
+	if ttl == 0 {
+		return "", 0, false
+	}
+
+	request := parsed.Path
+	if encoded := query.Encode(); encoded != "" {
+		request += "?" + encoded
+	}
+	return request, ttl, true
+}
+
+// responseCacheKey binds an entry to the API host and credentials so cached
+// data from one account can never be served to another.
+func responseCacheKey(baseURL, issuerID, keyID, request string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{baseURL, issuerID, keyID, "GET", request}, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func (rc *ResponseCache) entryPath(key string) string {
+	return filepath.Join(rc.root, rc.scope, key+responseCacheEntryExt)
+}
+
+func (rc *ResponseCache) get(key string) ([]byte, bool) {
+	path := rc.entryPath(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry responseCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		_ = os.Remove(path)
+		return nil, false
+	}
+	now := rc.now()
+	if !now.Before(entry.ExpiresAt) {
+		_ = os.Remove(path)
+		return nil, false
+	}
+	// Touch the entry so size-cap eviction drops least recently used first.
+	_ = os.Chtimes(path, now, now)
+	return entry.Body, true
+}
+
+func (rc *ResponseCache) put(key, request string, body []byte, ttl time.Duration) error {
+	if !json.Valid(body) {
+		return fmt.Errorf("response is not JSON")
+	}
+	now := rc.now()
+	data, err := json.Marshal(responseCacheEntry{
+		Request:   request,
+		StoredAt:  now.UTC(),
+		ExpiresAt: now.Add(ttl).UTC(),
+		Body:      body,
+	})
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) > rc.maxBytes {
+		return fmt.Errorf("response exceeds cache size cap")
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	dir := filepath.Join(rc.root, rc.scope)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), rc.entryPath(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return rc.evictLocked()
+}
+
+type responseCacheFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// evictLocked removes least recently used entries across all scopes until
+// the cache fits within maxBytes.
+func (rc *ResponseCache) evictLocked() error {
+	files, err := listResponseCacheFiles(rc.root, "")
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, file := range files {
+		total += file.size
+	}
+	if total <= rc.maxBytes {
+		return nil
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for _, file := range files {
+		if total <= rc.maxBytes {
+			break
+		}
+		if err := os.Remove(file.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		total -= file.size
+	}
+	return nil
+}
+
+func listResponseCacheFiles(root, scope string) ([]responseCacheFile, error) {
+	dir := root
+	if scope != "" {
+		dir = filepath.Join(root, scope)
+	}
+	var files []responseCacheFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), responseCacheEntryExt) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		files = append(files, responseCacheFile{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	return files, err
+}
+
+// ResponseCacheScopeStats summarizes cached entries for one scope (profile).
+type ResponseCacheScopeStats struct {
+	Scope   string `json:"scope"`
+	Entries int    `json:"entries"`
+	Expired int    `json:"expired"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// ReadResponseCacheStats reports per-scope usage of the cache stored under root.
+func ReadResponseCacheStats(root string) ([]ResponseCacheScopeStats, error) {
+	files, err := listResponseCacheFiles(root, "")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	byScope := map[string]*ResponseCacheScopeStats{}
+	for _, file := range files {
+		rel, err := filepath.Rel(root, filepath.Dir(file.path))
+		if err != nil {
+			continue
+		}
+		stats, ok := byScope[rel]
+		if !ok {
+			stats = &ResponseCacheScopeStats{Scope: rel}
+			byScope[rel] = stats
+		}
+		stats.Entries++
+		stats.Bytes += file.size
+		if responseCacheFileExpired(file.path, now) {
+			stats.Expired++
+		}
+	}
+
+	result := make([]ResponseCacheScopeStats, 0, len(byScope))
+	for _, stats := range byScope {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Scope < result[j].Scope
+	})
+	return result, nil
+}
+
+func responseCacheFileExpired(path string, now time.Time) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return true
+	}
+	var entry struct {
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return true
+	}
+	return !now.Before(entry.ExpiresAt)
+}
+
+// ClearResponseCache removes cached entries under root for scope, or for
+// every scope when scope is empty. It returns the number of entries removed.
+func ClearResponseCache(root, scope string) (int, error) {
+	files, err := listResponseCacheFiles(root, scope)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, file := range files {
+		if err := os.Remove(file.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
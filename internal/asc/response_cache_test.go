@@ -0,0 +1,196 @@
+package asc
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestResponseCacheRequest(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantTTL time.Duration
+		wantOK  bool
+	}{
+		{path: "/v1/territories?limit=200", want: "/v1/territories?limit=200", wantTTL: referenceDataCacheTTL, wantOK: true},
+		{path: "/v1/appCategories?limit=200&exists[parent]=false", want: "/v1/appCategories?exists%5Bparent%5D=false&limit=200", wantTTL: referenceDataCacheTTL, wantOK: true},
+		{path: "https://api.appstoreconnect.apple.com/v1/subscriptions/sub-1/pricePoints?cursor=abc", want: "/v1/subscriptions/sub-1/pricePoints?cursor=abc", wantTTL: pricePointCacheTTL, wantOK: true},
+		{path: "/v1/apps/app-1/appPricePoints", want: "/v1/apps/app-1/appPricePoints", wantTTL: pricePointCacheTTL, wantOK: true},
+		{path: "/v1/apps?filter[bundleId]=com.example.app&limit=2", want: "/v1/apps?filter%5BbundleId%5D=com.example.app&limit=2", wantTTL: appLookupCacheTTL, wantOK: true},
+		{path: "/v1/apps?limit=200"},
+		{path: "/v1/builds?filter[app]=1"},
+		{path: "/v1/appCategories/GAMES"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			got, ttl, ok := responseCacheRequest(test.path)
+			if ok != test.wantOK || got != test.want || ttl != test.wantTTL {
+				t.Fatalf("responseCacheRequest(%q) = (%q, %v, %v), want (%q, %v, %v)", test.path, got, ttl, ok, test.want, test.wantTTL, test.wantOK)
+			}
+		})
+	}
+}
+
+func TestClient_ResponseCacheServesRepeatedLookups(t *testing.T) {
+	root := t.TempDir()
+	client := newTestClient(t, nil, jsonResponse(http.StatusOK, `{"data":[{"type":"territories","id":"USA"}]}`))
+	client.SetResponseCache(NewResponseCache(root, "default", 0, false))
+
+	for range 2 {
+		body, err := client.do(context.Background(), http.MethodGet, "/v1/territories?limit=200", nil)
+		if err != nil {
+			t.Fatalf("do() error: %v", err)
+		}
+		if string(body) != `{"data":[{"type":"territories","id":"USA"}]}` {
+			t.Fatalf("unexpected body %s", body)
+		}
+	}
+
+	stats, err := ReadResponseCacheStats(root)
+	if err != nil {
+		t.Fatalf("ReadResponseCacheStats() error: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Scope != "default" || stats[0].Entries != 1 || stats[0].Expired != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestClient_ResponseCacheSkipsUncacheableAndRefreshes(t *testing.T) {
+	root := t.TempDir()
+	client := newTestClient(t, nil,
+		jsonResponse(http.StatusOK, `{"data":[]}`),
+		jsonResponse(http.StatusOK, `{"data":[]}`),
+		jsonResponse(http.StatusOK, `{"data":[{"id":"1"}]}`),
+		jsonResponse(http.StatusOK, `{"data":[{"id":"2"}]}`),
+	)
+	client.SetResponseCache(NewResponseCache(root, "default", 0, false))
+
+	for range 2 {
+		if _, err := client.do(context.Background(), http.MethodGet, "/v1/builds?limit=1", nil); err != nil {
+			t.Fatalf("do() error: %v", err)
+		}
+	}
+
+	client.SetResponseCache(NewResponseCache(root, "default", 0, true))
+	if _, err := client.do(context.Background(), http.MethodGet, "/v1/territories", nil); err != nil {
+		t.Fatalf("do() error: %v", err)
+	}
+	body, err := client.do(context.Background(), http.MethodGet, "/v1/territories", nil)
+	if err != nil {
+		t.Fatalf("do() error: %v", err)
+	}
+	if string(body) != `{"data":[{"id":"2"}]}` {
+		t.Fatalf("refresh mode should bypass cached entry, got %s", body)
+	}
+}
+
+func TestResponseCache_ExpiredEntriesAreRefetched(t *testing.T) {
+	root := t.TempDir()
+	cache := NewResponseCache(root, "default", 0, false)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	if err := cache.put("key", "/v1/territories", []byte(`{"data":[]}`), time.Hour); err != nil {
+		t.Fatalf("put() error: %v", err)
+	}
+	if _, ok := cache.get("key"); !ok {
+		t.Fatal("expected fresh entry to hit")
+	}
+	now = now.Add(2 * time.Hour)
+	if _, ok := cache.get("key"); ok {
+		t.Fatal("expected expired entry to miss")
+	}
+	if _, err := os.Stat(cache.entryPath("key")); !os.IsNotExist(err) {
+		t.Fatalf("expected expired entry to be removed, stat err=%v", err)
+	}
+}
+
+func TestResponseCacheKey_IsScopedToCredentialsAndHost(t *testing.T) {
+	base := responseCacheKey(BaseURL, "ISS", "KEY1", "/v1/territories")
+	if base == responseCacheKey(BaseURL, "ISS", "KEY2", "/v1/territories") {
+		t.Fatal("expected different keys for different API keys")
+	}
+	if base == responseCacheKey("http://127.0.0.1:8686", "ISS", "KEY1", "/v1/territories") {
+		t.Fatal("expected different keys for different API hosts")
+	}
+}
+
+func TestResponseCache_EvictsLeastRecentlyUsedOverCap(t *testing.T) {
+	root := t.TempDir()
+	body := []byte(`{"data":"0123456789012345678901234567890123456789"}`)
+	cache := NewResponseCache(root, "default", 400, false)
+
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	for i, key := range []string{"a", "b", "c"} {
+		if err := cache.put(key, "/v1/territories", body, time.Hour); err != nil {
+			t.Fatalf("put(%q) error: %v", key, err)
+		}
+		stamp := now.Add(time.Duration(i-3) * time.Minute)
+		if err := os.Chtimes(cache.entryPath(key), stamp, stamp); err != nil {
+			t.Fatalf("Chtimes() error: %v", err)
+		}
+	}
+	if err := cache.put("d", "/v1/territories", body, time.Hour); err != nil {
+		t.Fatalf("put(d) error: %v", err)
+	}
+
+	if _, err := os.Stat(cache.entryPath("a")); !os.IsNotExist(err) {
+		t.Fatalf("expected least recently used entry to be evicted, stat err=%v", err)
+	}
+	if _, err := os.Stat(cache.entryPath("d")); err != nil {
+		t.Fatalf("expected newest entry to remain: %v", err)
+	}
+}
+
+func TestClearResponseCache_ByScopeAndAll(t *testing.T) {
+	root := t.TempDir()
+	for _, scope := range []string{"first", "second"} {
+		cache := NewResponseCache(root, scope, 0, false)
+		if err := cache.put("key", "/v1/territories", []byte(`{}`), time.Hour); err != nil {
+			t.Fatalf("put() error: %v", err)
+		}
+	}
+
+	removed, err := ClearResponseCache(root, "first")
+	if err != nil || removed != 1 {
+		t.Fatalf("ClearResponseCache(first) = (%d, %v), want (1, nil)", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "second", "key.json")); err != nil {
+		t.Fatalf("expected other profile to be untouched: %v", err)
+	}
+
+	removed, err = ClearResponseCache(root, "")
+	if err != nil || removed != 1 {
+		t.Fatalf("ClearResponseCache(all) = (%d, %v), want (1, nil)", removed, err)
+	}
+}
+
+func TestClient_ResponseCacheReadsLiveDataAfterMutation(t *testing.T) {
+	root := t.TempDir()
+	client := newTestClient(t, nil,
+		jsonResponse(http.StatusOK, `{"data":[{"id":"before"}]}`),
+		jsonResponse(http.StatusOK, `{"data":{"type":"apps","id":"1"}}`),
+		jsonResponse(http.StatusOK, `{"data":[{"id":"after"}]}`),
+	)
+	client.SetResponseCache(NewResponseCache(root, "default", 0, false))
+
+	if _, err := client.do(context.Background(), http.MethodGet, "/v1/apps/1/appPricePoints", nil); err != nil {
+		t.Fatalf("do() error: %v", err)
+	}
+	if _, err := client.do(context.Background(), http.MethodPatch, "/v1/apps/1", nil); err != nil {
+		t.Fatalf("do() error: %v", err)
+	}
+	body, err := client.do(context.Background(), http.MethodGet, "/v1/apps/1/appPricePoints", nil)
+	if err != nil {
+		t.Fatalf("do() error: %v", err)
+	}
+	if string(body) != `{"data":[{"id":"after"}]}` {
+		t.Fatalf("expected live read after mutation, got %s", body)
+	}
+}
@@ -1,6 +1,7 @@
 package asc
 
 import (
+	"encoding/csv"
 	"os"
 
 	"github.com/olekukonko/tablewriter"
@@ -52,3 +53,39 @@ func RenderMarkdown(headers []string, rows [][]string) {
 	_ = table.Bulk(rows)
 	_ = table.Render()
 }
+
+// RenderCSV writes an RFC 4180 CSV table to stdout. The header row is always
+// written, even when rows is empty, so downstream tools see stable columns.
+func RenderCSV(headers []string, rows [][]string) {
+	renderDelimited(',', headers, rows)
+}
+
+// RenderTSV writes a tab-separated table to stdout using the same quoting
+// rules as RenderCSV.
+func RenderTSV(headers []string, rows [][]string) {
+	renderDelimited('\t', headers, rows)
+}
+
+func renderDelimited(comma rune, headers []string, rows [][]string) {
+	writer := csv.NewWriter(os.Stdout)
+	writer.Comma = comma
+	_ = writer.Write(headers)
+	for _, row := range rows {
+		_ = writer.Write(padDelimitedRow(row, len(headers)))
+	}
+	writer.Flush()
+}
+
+// padDelimitedRow pads or truncates row so every record has one field per
+// header; spreadsheet importers reject ragged records.
+func padDelimitedRow(row []string, width int) []string {
+	if width == 0 || len(row) == width {
+		return row
+	}
+	if len(row) > width {
+		return row[:width]
+	}
+	padded := make([]string, width)
+	copy(padded, row)
+	return padded
+}
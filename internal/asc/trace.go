@@ -0,0 +1,406 @@
+package asc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceFormat selects the trace file format.
+type TraceFormat string
+
+const (
+	// TraceFormatOTLP is OTLP/JSON (ExportTraceServiceRequest), loadable by Jaeger.
+	TraceFormatOTLP TraceFormat = "otlp"
+	// TraceFormatChrome is the Chrome trace event format, loadable by Perfetto.
+	TraceFormatChrome TraceFormat = "chrome"
+
+	traceServiceName = "asc"
+)
+
+// Span kinds, using the OTLP enum values.
+const (
+	spanKindInternal = 1
+	spanKindClient   = 3
+)
+
+// Tracer collects spans for one CLI run in memory until Export is called.
+type Tracer struct {
+	traceID string
+
+	mu    sync.Mutex
+	spans []*Span
+	now   func() time.Time
+}
+
+// Span is a timed operation within a trace. A nil *Span is a valid no-op
+// span, so instrumented code does not need to check whether tracing is on.
+type Span struct {
+	tracer   *Tracer
+	id       string
+	parentID string
+	name     string
+	kind     int
+	start    time.Time
+	end      time.Time
+
+	mu         sync.Mutex
+	attributes map[string]any
+	events     []spanEvent
+	errMessage string
+}
+
+type spanEvent struct {
+	name       string
+	time       time.Time
+	attributes map[string]any
+}
+
+// NewTracer creates a tracer with a random trace ID.
+func NewTracer() *Tracer {
+	return &Tracer{traceID: randomTraceHex(16), now: time.Now}
+}
+
+var activeTracer struct {
+	mu     sync.RWMutex
+	tracer *Tracer
+}
+
+// SetActiveTracer installs tracer for the whole process. Passing nil
+// disables tracing.
+func SetActiveTracer(tracer *Tracer) {
+	activeTracer.mu.Lock()
+	defer activeTracer.mu.Unlock()
+	activeTracer.tracer = tracer
+}
+
+// ActiveTracer returns the installed tracer, if any.
+func ActiveTracer() *Tracer {
+	activeTracer.mu.RLock()
+	defer activeTracer.mu.RUnlock()
+	return activeTracer.tracer
+}
+
+type spanContextKey struct{}
+
+// StartSpan starts a span as a child of the span in ctx, if any. It returns
+// a nil span when tracing is disabled.
+func StartSpan(ctx context.Context, name string, attributes map[string]any) (context.Context, *Span) {
+	return startSpan(ctx, name, spanKindInternal, attributes)
+}
+
+func startSpan(ctx context.Context, name string, kind int, attributes map[string]any) (context.Context, *Span) {
+	tracer := ActiveTracer()
+	if tracer == nil {
+		return ctx, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	span := &Span{
+		tracer:     tracer,
+		id:         randomTraceHex(8),
+		name:       name,
+		kind:       kind,
+		start:      tracer.now(),
+		attributes: map[string]any{},
+	}
+	if parent := spanFromContext(ctx); parent != nil {
+		span.parentID = parent.id
+	}
+	for key, value := range attributes {
+		span.attributes[key] = value
+	}
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+func spanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// SetAttribute records a key/value on the span.
+func (s *Span) SetAttribute(key string, value any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes[key] = value
+}
+
+// AddEvent records a point-in-time event on the span.
+func (s *Span) AddEvent(name string, attributes map[string]any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, spanEvent{name: name, time: s.tracer.now(), attributes: attributes})
+}
+
+// End finishes the span, marking it failed when err is non-nil.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.end = s.tracer.now()
+	if err != nil {
+		s.errMessage = err.Error()
+	}
+	s.mu.Unlock()
+
+	s.tracer.mu.Lock()
+	s.tracer.spans = append(s.tracer.spans, s)
+	s.tracer.mu.Unlock()
+}
+
+func randomTraceHex(size int) string {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return strings.Repeat("0", size*2-1) + "1"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// templatedAPIPath replaces resource IDs in an API path with {id} so spans
+// for the same endpoint group together: /v1/apps/123/builds becomes
+// /v1/apps/{id}/builds.
+func templatedAPIPath(path string) string {
+	if parsed, err := url.Parse(path); err == nil {
+		path = parsed.Path
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) >= 3 {
+		segments[2] = "{id}"
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// finishedSpans returns a snapshot of the ended spans ordered by start time.
+// Spans starting together are ordered longest first so parents precede
+// their children.
+func (t *Tracer) finishedSpans() []*Span {
+	t.mu.Lock()
+	spans := append([]*Span(nil), t.spans...)
+	t.mu.Unlock()
+	sort.SliceStable(spans, func(i, j int) bool {
+		if !spans[i].start.Equal(spans[j].start) {
+			return spans[i].start.Before(spans[j].start)
+		}
+		return spans[i].end.After(spans[j].end)
+	})
+	return spans
+}
+
+// Export writes every ended span to w in the given format.
+func (t *Tracer) Export(w io.Writer, format TraceFormat) error {
+	var document any
+	switch format {
+	case TraceFormatOTLP, "":
+		document = t.otlpDocument()
+	case TraceFormatChrome:
+		document = t.chromeDocument()
+	default:
+		return fmt.Errorf("unsupported trace format %q", format)
+	}
+	encoder := json.NewEncoder(w)
+	return encoder.Encode(document)
+}
+
+type otlpKeyValue struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+func otlpAttributes(attributes map[string]any) []otlpKeyValue {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := make([]otlpKeyValue, 0, len(keys))
+	for _, key := range keys {
+		var value map[string]any
+		switch typed := attributes[key].(type) {
+		case bool:
+			value = map[string]any{"boolValue": typed}
+		case int:
+			value = map[string]any{"intValue": strconv.Itoa(typed)}
+		case int64:
+			value = map[string]any{"intValue": strconv.FormatInt(typed, 10)}
+		case float64:
+			value = map[string]any{"doubleValue": typed}
+		default:
+			value = map[string]any{"stringValue": fmt.Sprint(typed)}
+		}
+		values = append(values, otlpKeyValue{Key: key, Value: value})
+	}
+	return values
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func (t *Tracer) otlpDocument() any {
+	spans := t.finishedSpans()
+	out := make([]map[string]any, 0, len(spans))
+	for _, span := range spans {
+		span.mu.Lock()
+		item := map[string]any{
+			"traceId":           t.traceID,
+			"spanId":            span.id,
+			"name":              span.name,
+			"kind":              span.kind,
+			"startTimeUnixNano": unixNano(span.start),
+			"endTimeUnixNano":   unixNano(span.end),
+			"attributes":        otlpAttributes(span.attributes),
+			"status":            map[string]any{"code": 1},
+		}
+		if span.parentID != "" {
+			item["parentSpanId"] = span.parentID
+		}
+		if span.errMessage != "" {
+			item["status"] = map[string]any{"code": 2, "message": span.errMessage}
+		}
+		if len(span.events) > 0 {
+			events := make([]map[string]any, 0, len(span.events))
+			for _, event := range span.events {
+				events = append(events, map[string]any{
+					"timeUnixNano": unixNano(event.time),
+					"name":         event.name,
+					"attributes":   otlpAttributes(event.attributes),
+				})
+			}
+			item["events"] = events
+		}
+		span.mu.Unlock()
+		out = append(out, item)
+	}
+
+	return map[string]any{
+		"resourceSpans": []any{
+			map[string]any{
+				"resource": map[string]any{
+					"attributes": otlpAttributes(map[string]any{"service.name": traceServiceName}),
+				},
+				"scopeSpans": []any{
+					map[string]any{
+						"scope": map[string]any{"name": traceServiceName},
+						"spans": out,
+					},
+				},
+			},
+		},
+	}
+}
+
+type chromeEvent struct {
+	Name  string         `json:"name"`
+	Cat   string         `json:"cat,omitempty"`
+	Phase string         `json:"ph"`
+	TS    int64          `json:"ts"`
+	Dur   int64          `json:"dur,omitempty"`
+	PID   int            `json:"pid"`
+	TID   int            `json:"tid"`
+	Scope string         `json:"s,omitempty"`
+	Args  map[string]any `json:"args,omitempty"`
+}
+
+// chromeDocument lays spans out on "threads" so that every lane holds
+// properly nested spans; concurrent work (parallel uploads) gets its own lane.
+func (t *Tracer) chromeDocument() any {
+	spans := t.finishedSpans()
+
+	type lane struct {
+		open []*Span
+	}
+	var lanes []*lane
+	laneOf := map[string]int{}
+	events := make([]chromeEvent, 0, len(spans))
+
+	fits := func(l *lane, span *Span) bool {
+		for len(l.open) > 0 && !l.open[len(l.open)-1].end.After(span.start) {
+			l.open = l.open[:len(l.open)-1]
+		}
+		if len(l.open) == 0 {
+			return true
+		}
+		top := l.open[len(l.open)-1]
+		return top.id == span.parentID && !span.end.After(top.end)
+	}
+
+	for _, span := range spans {
+		index := -1
+		if parentLane, ok := laneOf[span.parentID]; ok && fits(lanes[parentLane], span) {
+			index = parentLane
+		}
+		for i := 0; index < 0 && i < len(lanes); i++ {
+			if fits(lanes[i], span) {
+				index = i
+			}
+		}
+		if index < 0 {
+			lanes = append(lanes, &lane{})
+			index = len(lanes) - 1
+		}
+		lanes[index].open = append(lanes[index].open, span)
+		laneOf[span.id] = index
+
+		span.mu.Lock()
+		args := map[string]any{}
+		for key, value := range span.attributes {
+			args[key] = value
+		}
+		if span.errMessage != "" {
+			args["error"] = span.errMessage
+		}
+		category := "internal"
+		if span.kind == spanKindClient {
+			category = "http"
+		}
+		events = append(events, chromeEvent{
+			Name:  span.name,
+			Cat:   category,
+			Phase: "X",
+			TS:    span.start.UnixMicro(),
+			Dur:   max(span.end.Sub(span.start).Microseconds(), 1),
+			PID:   1,
+			TID:   index + 1,
+			Args:  args,
+		})
+		for _, event := range span.events {
+			events = append(events, chromeEvent{
+				Name:  event.name,
+				Cat:   category,
+				Phase: "i",
+				TS:    event.time.UnixMicro(),
+				PID:   1,
+				TID:   index + 1,
+				Scope: "t",
+				Args:  event.attributes,
+			})
+		}
+		span.mu.Unlock()
+	}
+
+	return map[string]any{
+		"traceEvents":     events,
+		"displayTimeUnit": "ms",
+	}
+}
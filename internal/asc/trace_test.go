@@ -0,0 +1,216 @@
+package asc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type otlpTestDocument struct {
+	ResourceSpans []struct {
+		ScopeSpans []struct {
+			Spans []struct {
+				TraceID      string `json:"traceId"`
+				SpanID       string `json:"spanId"`
+				ParentSpanID string `json:"parentSpanId"`
+				Name         string `json:"name"`
+				Kind         int    `json:"kind"`
+				Attributes   []struct {
+					Key   string         `json:"key"`
+					Value map[string]any `json:"value"`
+				} `json:"attributes"`
+				Events []struct {
+					Name string `json:"name"`
+				} `json:"events"`
+				Status struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+func TestTracerRecordsRequestsPollsAndUploads(t *testing.T) {
+	tracer := NewTracer()
+	SetActiveTracer(tracer)
+	t.Cleanup(func() { SetActiveTracer(nil) })
+	t.Setenv("ASC_MAX_RETRIES", "2")
+	t.Setenv("ASC_BASE_DELAY", "1ms")
+	t.Setenv("ASC_MAX_DELAY", "1ms")
+
+	client := newTestClient(t, nil,
+		jsonResponse(http.StatusTooManyRequests, `{"errors":[{"status":"429"}]}`),
+		jsonResponse(http.StatusOK, `{"data":{"type":"builds","id":"b1"}}`),
+	)
+
+	ctx, root := StartSpan(context.Background(), "asc builds wait", nil)
+	_, err := PollUntil(ctx, time.Millisecond, func(ctx context.Context) (struct{}, bool, error) {
+		_, err := client.do(ctx, http.MethodGet, "/v1/builds/b1?include=app", nil)
+		return struct{}{}, true, err
+	})
+	if err != nil {
+		t.Fatalf("PollUntil() error: %v", err)
+	}
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "payload.bin")
+	if err := os.WriteFile(filePath, []byte("abcdefgh"), 0o600); err != nil {
+		t.Fatalf("write payload: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	err = ExecuteUploadOperations(ctx, filePath, []UploadOperation{
+		{Method: "PUT", URL: server.URL + "/a", Offset: 0, Length: 4},
+		{Method: "PUT", URL: server.URL + "/b", Offset: 4, Length: 4},
+	}, WithUploadConcurrency(2), WithUploadHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("ExecuteUploadOperations() error: %v", err)
+	}
+	root.End(errors.New("boom"))
+
+	var buf bytes.Buffer
+	if err := tracer.Export(&buf, TraceFormatOTLP); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	var document otlpTestDocument
+	if err := json.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatalf("unmarshal OTLP: %v", err)
+	}
+	spans := document.ResourceSpans[0].ScopeSpans[0].Spans
+
+	byName := map[string]int{}
+	for i, span := range spans {
+		byName[span.Name] = i
+		if span.TraceID != tracer.traceID {
+			t.Fatalf("span %q has trace ID %q, want %q", span.Name, span.TraceID, tracer.traceID)
+		}
+	}
+	for _, name := range []string{"asc builds wait", "poll", "GET /v1/builds/{id}", "upload", "upload part 1", "upload part 2"} {
+		if _, ok := byName[name]; !ok {
+			t.Fatalf("missing span %q in %+v", name, byName)
+		}
+	}
+	parentOf := func(name string) string {
+		parentID := spans[byName[name]].ParentSpanID
+		for _, span := range spans {
+			if span.SpanID == parentID {
+				return span.Name
+			}
+		}
+		return ""
+	}
+	for child, parent := range map[string]string{
+		"poll":                "asc builds wait",
+		"GET /v1/builds/{id}": "poll",
+		"upload":              "asc builds wait",
+		"upload part 2":       "upload",
+	} {
+		if got := parentOf(child); got != parent {
+			t.Fatalf("parent of %q = %q, want %q", child, got, parent)
+		}
+	}
+
+	request := spans[byName["GET /v1/builds/{id}"]]
+	if request.Kind != spanKindClient || len(request.Events) != 1 || request.Events[0].Name != "retry" {
+		t.Fatalf("expected client span with one retry event, got %+v", request)
+	}
+	attributes := map[string]map[string]any{}
+	for _, attribute := range request.Attributes {
+		attributes[attribute.Key] = attribute.Value
+	}
+	if attributes["http.response.status_code"]["intValue"] != "200" || attributes["asc.retries"]["intValue"] != "1" {
+		t.Fatalf("unexpected request attributes %+v", attributes)
+	}
+	if status := spans[byName["asc builds wait"]].Status; status.Code != 2 || status.Message != "boom" {
+		t.Fatalf("expected failed root span, got %+v", status)
+	}
+}
+
+func TestTracerChromeExportSeparatesConcurrentSpans(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	current := base
+	tracer := NewTracer()
+	tracer.now = func() time.Time { return current }
+	SetActiveTracer(tracer)
+	t.Cleanup(func() { SetActiveTracer(nil) })
+
+	ctx, root := StartSpan(context.Background(), "root", nil)
+	_, first := StartSpan(ctx, "part 1", nil)
+	current = current.Add(time.Millisecond)
+	_, second := StartSpan(ctx, "part 2", nil)
+	current = current.Add(time.Millisecond)
+	first.End(nil)
+	current = current.Add(time.Millisecond)
+	second.End(nil)
+	root.End(nil)
+
+	var buf bytes.Buffer
+	if err := tracer.Export(&buf, TraceFormatChrome); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	var document struct {
+		TraceEvents []struct {
+			Name  string `json:"name"`
+			Phase string `json:"ph"`
+			TS    int64  `json:"ts"`
+			Dur   int64  `json:"dur"`
+			TID   int    `json:"tid"`
+		} `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatalf("unmarshal chrome trace: %v", err)
+	}
+	lanes := map[string]int{}
+	for _, event := range document.TraceEvents {
+		if event.Phase != "X" {
+			t.Fatalf("unexpected event phase %q", event.Phase)
+		}
+		lanes[event.Name] = event.TID
+	}
+	if lanes["root"] != lanes["part 1"] {
+		t.Fatalf("expected first child nested on the root lane, got %+v", lanes)
+	}
+	if lanes["part 2"] == lanes["part 1"] {
+		t.Fatalf("expected overlapping sibling on its own lane, got %+v", lanes)
+	}
+	if document.TraceEvents[0].Name != "root" || document.TraceEvents[0].Dur != 3000 {
+		t.Fatalf("unexpected root event %+v", document.TraceEvents[0])
+	}
+}
+
+func TestTemplatedAPIPath(t *testing.T) {
+	tests := map[string]string{
+		"/v1/apps":                    "/v1/apps",
+		"/v1/apps/123":                "/v1/apps/{id}",
+		"/v1/apps/123/builds?limit=5": "/v1/apps/{id}/builds",
+		"/v1/betaGroups/g1/relationships/testers":                    "/v1/betaGroups/{id}/relationships/testers",
+		"https://api.appstoreconnect.apple.com/v1/builds/9?cursor=x": "/v1/builds/{id}",
+	}
+	for input, want := range tests {
+		if got := templatedAPIPath(input); got != want {
+			t.Fatalf("templatedAPIPath(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestStartSpanWithoutTracerIsNoop(t *testing.T) {
+	SetActiveTracer(nil)
+	ctx := context.Background()
+	got, span := StartSpan(ctx, "noop", nil)
+	if span != nil || got != ctx {
+		t.Fatalf("expected no-op span without a tracer")
+	}
+	span.SetAttribute("key", "value")
+	span.AddEvent("event", nil)
+	span.End(nil)
+}
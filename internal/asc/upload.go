@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 )
@@ -20,6 +21,7 @@ type UploadOptions struct {
 	Concurrency int
 	Client      *http.Client
 	RetryOpts   RetryOptions
+	State       *UploadState
 }
 
 // UploadOption configures upload options.
@@ -115,6 +117,35 @@ func ExecuteUploadOperations(ctx context.Context, filePath string, operations []
 		}
 	}
 
+	var totalBytes, resumedBytes int64
+	pending := 0
+	for i, op := range operations {
+		if uploadOpts.State.isCompleted(i) {
+			resumedBytes += op.Length
+			continue
+		}
+		totalBytes += op.Length
+		pending++
+	}
+	if pending == 0 {
+		return nil
+	}
+	if uploadOpts.Concurrency > pending {
+		uploadOpts.Concurrency = pending
+	}
+	ctx, span := StartSpan(ctx, "upload", map[string]any{
+		"upload.file":        filepath.Base(filePath),
+		"upload.operations":  pending,
+		"upload.resumed":     len(operations) - pending,
+		"upload.bytes":       totalBytes,
+		"upload.concurrency": uploadOpts.Concurrency,
+	})
+
+	var tracker *uploadTracker
+	if ActivePlan() == nil {
+		tracker = newUploadTracker(filePath, totalBytes+resumedBytes, len(operations), resumedBytes, len(operations)-pending)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -136,10 +167,11 @@ func ExecuteUploadOperations(ctx context.Context, filePath string, operations []
 			if ctx.Err() != nil {
 				return
 			}
-			if err := executeUploadOperation(ctx, file, task, uploadOpts); err != nil {
+			if err := executeUploadOperation(ctx, file, task, uploadOpts, tracker); err != nil {
 				setErr(err)
 				return
 			}
+			uploadOpts.State.markCompleted(task.index)
 		}
 	}
 
@@ -150,6 +182,9 @@ func ExecuteUploadOperations(ctx context.Context, filePath string, operations []
 
 sendLoop:
 	for i, op := range operations {
+		if uploadOpts.State.isCompleted(i) {
+			continue
+		}
 		select {
 		case <-ctx.Done():
 			break sendLoop
@@ -159,6 +194,10 @@ sendLoop:
 	close(jobs)
 
 	wg.Wait()
+	if firstErr == nil {
+		tracker.complete()
+	}
+	span.End(firstErr)
 	return firstErr
 }
 
@@ -187,15 +226,35 @@ func openUploadSourceFile(filePath string) (*os.File, error) {
 	return file, nil
 }
 
-func executeUploadOperation(ctx context.Context, file *os.File, task uploadTask, uploadOpts UploadOptions) error {
+func executeUploadOperation(ctx context.Context, file *os.File, task uploadTask, uploadOpts UploadOptions, tracker *uploadTracker) (err error) {
 	method := strings.ToUpper(strings.TrimSpace(task.op.Method))
 	if method == "" {
 		method = http.MethodPut
 	}
+	ctx, span := startSpan(ctx, fmt.Sprintf("upload part %d", task.index+1), spanKindClient, map[string]any{
+		"http.request.method": method,
+		"upload.index":        task.index,
+		"upload.offset":       task.op.Offset,
+		"upload.length":       task.op.Length,
+	})
+	defer func() { span.End(err) }()
+	if plan := ActivePlan(); plan != nil {
+		plan.recordUpload(method, task.op.URL, task.op.Length)
+		return nil
+	}
+	if err := CheckReadOnly(method, task.op.URL); err != nil {
+		return err
+	}
 
-	_, err := WithRetry(ctx, func() (struct{}, error) {
-		reader := io.NewSectionReader(file, task.op.Offset, task.op.Length)
-		req, err := http.NewRequestWithContext(ctx, method, task.op.URL, reader)
+	var attempt *uploadProgressReader
+	_, err = WithRetry(ctx, func() (struct{}, error) {
+		var body io.Reader = io.NewSectionReader(file, task.op.Offset, task.op.Length)
+		if tracker != nil {
+			attempt.rewind()
+			attempt = tracker.reader(ctx, body)
+			body = attempt
+		}
+		req, err := http.NewRequestWithContext(ctx, method, task.op.URL, body)
 		if err != nil {
 			return struct{}{}, err
 		}
@@ -213,6 +272,7 @@ func executeUploadOperation(ctx context.Context, file *os.File, task uploadTask,
 		}
 		defer resp.Body.Close()
 		_, _ = io.Copy(io.Discard, resp.Body)
+		span.SetAttribute("http.response.status_code", resp.StatusCode)
 
 		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
 			retryAfter := parseRetryAfterHeader(resp.Header.Get("Retry-After"))
@@ -228,8 +288,10 @@ func executeUploadOperation(ctx context.Context, file *os.File, task uploadTask,
 		return struct{}{}, nil
 	}, uploadOpts.RetryOpts)
 	if err != nil {
+		attempt.rewind()
 		return fmt.Errorf("upload operation %d: %w", task.index, err)
 	}
+	tracker.partDone(attempt, task.op.Length)
 	return nil
 }
 
@@ -0,0 +1,271 @@
+package asc
+
+import (
+	"context"
+	"io"
+	"math"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Upload progress event names.
+const (
+	UploadProgressEventProgress = "upload.progress"
+	UploadProgressEventComplete = "upload.complete"
+)
+
+// uploadProgressInterval throttles progress events between part completions.
+const uploadProgressInterval = 500 * time.Millisecond
+
+// uploadReadChunk caps a single body read so the limiter paces small slices.
+const uploadReadChunk = 32 * 1024
+
+// UploadProgress describes how far an upload has progressed.
+type UploadProgress struct {
+	Event          string  `json:"event"`
+	File           string  `json:"file"`
+	BytesSent      int64   `json:"bytesSent"`
+	TotalBytes     int64   `json:"totalBytes"`
+	PartsDone      int     `json:"partsDone"`
+	TotalParts     int     `json:"totalParts"`
+	BytesPerSecond float64 `json:"bytesPerSecond"`
+	ETASeconds     float64 `json:"etaSeconds"`
+}
+
+// UploadProgressReporter receives upload progress. Calls are serialized.
+type UploadProgressReporter func(UploadProgress)
+
+var activeUploadProgress struct {
+	mu       sync.RWMutex
+	reporter UploadProgressReporter
+	limiter  *uploadLimiter
+}
+
+// SetUploadProgressReporter installs reporter for every upload in the
+// process. Passing nil disables progress reporting.
+func SetUploadProgressReporter(reporter UploadProgressReporter) {
+	activeUploadProgress.mu.Lock()
+	defer activeUploadProgress.mu.Unlock()
+	activeUploadProgress.reporter = reporter
+}
+
+// SetMaxUploadRate caps the combined upload bandwidth of the process, across
+// all concurrent parts, in bytes per second. Zero removes the cap.
+func SetMaxUploadRate(bytesPerSecond int64) {
+	activeUploadProgress.mu.Lock()
+	defer activeUploadProgress.mu.Unlock()
+	if bytesPerSecond <= 0 {
+		activeUploadProgress.limiter = nil
+		return
+	}
+	activeUploadProgress.limiter = newUploadLimiter(bytesPerSecond, time.Now)
+}
+
+func activeUploadReporter() (UploadProgressReporter, *uploadLimiter) {
+	activeUploadProgress.mu.RLock()
+	defer activeUploadProgress.mu.RUnlock()
+	return activeUploadProgress.reporter, activeUploadProgress.limiter
+}
+
+// uploadLimiter is a token bucket shared by every upload part. Readers take
+// tokens before handing bytes to the transport; a reader that overdraws the
+// bucket sleeps until the debt is repaid, so the long-run rate never exceeds the
+// cap even with many concurrent parts.
+type uploadLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newUploadLimiter(bytesPerSecond int64, now func() time.Time) *uploadLimiter {
+	return &uploadLimiter{rate: float64(bytesPerSecond), now: now, last: now()}
+}
+
+// reserve takes n tokens and returns how long the caller must wait before
+// handing them to the transport.
+func (l *uploadLimiter) reserve(n int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.tokens = math.Min(l.tokens+now.Sub(l.last).Seconds()*l.rate, l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+func (l *uploadLimiter) wait(ctx context.Context, n int) error {
+	if l == nil {
+		return nil
+	}
+	delay := l.reserve(n)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// chunk returns the largest read that keeps the limiter responsive.
+func (l *uploadLimiter) chunk(n int) int {
+	if n > uploadReadChunk {
+		n = uploadReadChunk
+	}
+	if l != nil && float64(n) > l.rate {
+		n = int(math.Max(l.rate, 1))
+	}
+	return n
+}
+
+// uploadTracker aggregates byte and part counts for one upload and forwards
+// throttled snapshots to the reporter.
+type uploadTracker struct {
+	reporter   UploadProgressReporter
+	limiter    *uploadLimiter
+	file       string
+	totalBytes int64
+	totalParts int
+	baseBytes  int64
+	now        func() time.Time
+	start      time.Time
+
+	sent atomic.Int64
+
+	mu        sync.Mutex
+	partsDone int
+	lastEmit  time.Time
+}
+
+// newUploadTracker returns nil when no reporter or rate cap is installed.
+// alreadySent and partsDone account for parts finished by an earlier run.
+func newUploadTracker(filePath string, totalBytes int64, totalParts int, alreadySent int64, partsDone int) *uploadTracker {
+	reporter, limiter := activeUploadReporter()
+	if reporter == nil && limiter == nil {
+		return nil
+	}
+	t := &uploadTracker{
+		reporter:   reporter,
+		limiter:    limiter,
+		file:       filepath.Base(filePath),
+		totalBytes: totalBytes,
+		totalParts: totalParts,
+		baseBytes:  alreadySent,
+		partsDone:  partsDone,
+		now:        time.Now,
+	}
+	t.start = t.now()
+	t.sent.Store(alreadySent)
+	return t
+}
+
+// reader wraps a part body so reads are paced and counted.
+func (t *uploadTracker) reader(ctx context.Context, r io.Reader) *uploadProgressReader {
+	return &uploadProgressReader{ctx: ctx, r: r, tracker: t}
+}
+
+func (t *uploadTracker) add(n int64) {
+	if t == nil || n == 0 {
+		return
+	}
+	t.sent.Add(n)
+	t.emit(UploadProgressEventProgress, false)
+}
+
+// partDone records a finished part. The part's bytes are settled against
+// what was actually read, in case the transport did not drain the body.
+func (t *uploadTracker) partDone(attempt *uploadProgressReader, length int64) {
+	if t == nil {
+		return
+	}
+	if attempt != nil {
+		t.sent.Add(length - attempt.n.Swap(length))
+	}
+	t.mu.Lock()
+	t.partsDone++
+	t.mu.Unlock()
+	t.emit(UploadProgressEventProgress, true)
+}
+
+func (t *uploadTracker) complete() {
+	if t == nil {
+		return
+	}
+	t.emit(UploadProgressEventComplete, true)
+}
+
+func (t *uploadTracker) emit(event string, force bool) {
+	if t.reporter == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if !force && now.Sub(t.lastEmit) < uploadProgressInterval {
+		return
+	}
+	t.lastEmit = now
+
+	sent := t.sent.Load()
+	progress := UploadProgress{
+		Event:      event,
+		File:       t.file,
+		BytesSent:  sent,
+		TotalBytes: t.totalBytes,
+		PartsDone:  t.partsDone,
+		TotalParts: t.totalParts,
+	}
+	if elapsed := now.Sub(t.start).Seconds(); elapsed > 0 {
+		progress.BytesPerSecond = math.Round(float64(sent-t.baseBytes) / elapsed)
+	}
+	if progress.BytesPerSecond > 0 && sent < t.totalBytes {
+		progress.ETASeconds = math.Ceil(float64(t.totalBytes-sent) / progress.BytesPerSecond)
+	}
+	t.reporter(progress)
+}
+
+// uploadProgressReader counts bytes read by the transport. A retried part
+// gets a fresh reader, and the failed attempt's bytes are handed back with
+// rewind so bytesSent never double counts.
+type uploadProgressReader struct {
+	ctx     context.Context
+	r       io.Reader
+	tracker *uploadTracker
+	n       atomic.Int64
+}
+
+func (r *uploadProgressReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	p = p[:r.tracker.limiter.chunk(len(p))]
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if waitErr := r.tracker.limiter.wait(r.ctx, n); waitErr != nil {
+			return 0, waitErr
+		}
+		r.n.Add(int64(n))
+		r.tracker.add(int64(n))
+	}
+	return n, err
+}
+
+func (r *uploadProgressReader) rewind() {
+	if r == nil {
+		return
+	}
+	r.tracker.add(-r.n.Swap(0))
+}
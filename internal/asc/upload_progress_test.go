@@ -0,0 +1,184 @@
+package asc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func captureUploadProgress(t *testing.T) func() []UploadProgress {
+	t.Helper()
+	var mu sync.Mutex
+	var events []UploadProgress
+	SetUploadProgressReporter(func(progress UploadProgress) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, progress)
+	})
+	t.Cleanup(func() { SetUploadProgressReporter(nil) })
+	return func() []UploadProgress {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]UploadProgress(nil), events...)
+	}
+}
+
+func TestExecuteUploadOperationsReportsProgress(t *testing.T) {
+	events := captureUploadProgress(t)
+	filePath := filepath.Join(t.TempDir(), "app.ipa")
+	if err := os.WriteFile(filePath, []byte("abcdefghijkl"), 0o600); err != nil {
+		t.Fatalf("write payload: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	operations := []UploadOperation{
+		{Method: http.MethodPut, URL: server.URL + "/a", Offset: 0, Length: 4},
+		{Method: http.MethodPut, URL: server.URL + "/b", Offset: 4, Length: 4},
+		{Method: http.MethodPut, URL: server.URL + "/c", Offset: 8, Length: 4},
+	}
+	if err := ExecuteUploadOperations(context.Background(), filePath, operations, WithUploadConcurrency(2), WithUploadHTTPClient(server.Client())); err != nil {
+		t.Fatalf("ExecuteUploadOperations() error: %v", err)
+	}
+
+	got := events()
+	if len(got) < 4 {
+		t.Fatalf("expected a progress event per part plus completion, got %+v", got)
+	}
+	parts := 0
+	for _, event := range got[:len(got)-1] {
+		if event.Event != UploadProgressEventProgress {
+			t.Fatalf("unexpected intermediate event %+v", event)
+		}
+		if event.PartsDone > parts {
+			parts = event.PartsDone
+		}
+	}
+	if parts != 3 {
+		t.Fatalf("expected intermediate events to reach 3 parts, got %d", parts)
+	}
+	last := got[len(got)-1]
+	want := UploadProgress{Event: UploadProgressEventComplete, File: "app.ipa", BytesSent: 12, TotalBytes: 12, PartsDone: 3, TotalParts: 3}
+	last.BytesPerSecond = 0
+	if last != want {
+		t.Fatalf("final event = %+v, want %+v", last, want)
+	}
+}
+
+func TestUploadAssetFromFileProgressCountsResumedParts(t *testing.T) {
+	events := captureUploadProgress(t)
+	file := createTempAssetFile(t, []byte("abcdef"))
+	defer file.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	operations := []UploadOperation{
+		{Method: http.MethodPut, URL: server.URL + "/part1", Offset: 0, Length: 3},
+		{Method: http.MethodPut, URL: server.URL + "/part2", Offset: 3, Length: 3},
+	}
+	state, err := NewUploadStateStore(t.TempDir()).Begin(UploadStateKindScreenshot, "set-1", "sum", 6, "shot-1", "", operations)
+	if err != nil {
+		t.Fatalf("Begin() error: %v", err)
+	}
+	state.markCompleted(0)
+
+	if err := UploadAssetFromFile(context.Background(), file, 6, operations, WithUploadState(state)); err != nil {
+		t.Fatalf("UploadAssetFromFile() error: %v", err)
+	}
+	got := events()
+	if len(got) == 0 {
+		t.Fatal("expected progress events")
+	}
+	first, last := got[0], got[len(got)-1]
+	if first.BytesSent < 3 || first.PartsDone < 1 {
+		t.Fatalf("expected the first event to include the resumed part, got %+v", first)
+	}
+	if last.Event != UploadProgressEventComplete || last.BytesSent != 6 || last.PartsDone != 2 || last.ETASeconds != 0 {
+		t.Fatalf("unexpected final event %+v", last)
+	}
+}
+
+func TestUploadProgressReaderRewindsFailedAttempts(t *testing.T) {
+	captureUploadProgress(t)
+	tracker := newUploadTracker("app.ipa", 6, 1, 0, 0)
+
+	attempt := tracker.reader(context.Background(), strings.NewReader("abcdef"))
+	if _, err := io.Copy(io.Discard, attempt); err != nil {
+		t.Fatalf("read attempt: %v", err)
+	}
+	if got := tracker.sent.Load(); got != 6 {
+		t.Fatalf("sent after first attempt = %d, want 6", got)
+	}
+	attempt.rewind()
+	retry := tracker.reader(context.Background(), strings.NewReader("abcdef"))
+	if _, err := io.CopyN(io.Discard, retry, 2); err != nil {
+		t.Fatalf("read retry: %v", err)
+	}
+	if got := tracker.sent.Load(); got != 2 {
+		t.Fatalf("sent after rewind and partial retry = %d, want 2", got)
+	}
+}
+
+func TestUploadLimiterPacesAcrossReaders(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newUploadLimiter(1000, func() time.Time { return now })
+
+	if delay := limiter.reserve(500); delay != 500*time.Millisecond {
+		t.Fatalf("first reserve delay = %v, want 500ms", delay)
+	}
+	// A second reader queues behind the first one's debt.
+	if delay := limiter.reserve(500); delay != time.Second {
+		t.Fatalf("second reserve delay = %v, want 1s", delay)
+	}
+	now = now.Add(time.Second)
+	if delay := limiter.reserve(0); delay != 0 {
+		t.Fatalf("expected the debt to be repaid after 1s, got %v", delay)
+	}
+	// Idle time only accrues up to one second of burst.
+	now = now.Add(10 * time.Second)
+	if delay := limiter.reserve(1500); delay != 500*time.Millisecond {
+		t.Fatalf("burst reserve delay = %v, want 500ms", delay)
+	}
+
+	if got := limiter.chunk(64 * 1024); got != 1000 {
+		t.Fatalf("chunk() = %d, want the per-second rate", got)
+	}
+	var unlimited *uploadLimiter
+	if got := unlimited.chunk(64 * 1024); got != uploadReadChunk {
+		t.Fatalf("nil chunk() = %d, want %d", got, uploadReadChunk)
+	}
+}
+
+func TestSetMaxUploadRateThrottlesUploads(t *testing.T) {
+	SetMaxUploadRate(4096)
+	t.Cleanup(func() { SetMaxUploadRate(0) })
+
+	payload := make([]byte, 6*1024)
+	file := createTempAssetFile(t, payload)
+	defer file.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	operations := []UploadOperation{{Method: http.MethodPut, URL: server.URL + "/part1", Offset: 0, Length: int64(len(payload))}}
+	start := time.Now()
+	if err := UploadAssetFromFile(context.Background(), file, int64(len(payload)), operations); err != nil {
+		t.Fatalf("UploadAssetFromFile() error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 1400*time.Millisecond {
+		t.Fatalf("expected 6KiB at 4KiB/s to take about 1.5s, took %v", elapsed)
+	}
+}
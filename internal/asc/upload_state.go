@@ -0,0 +1,210 @@
+package asc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Upload state kinds.
+const (
+	UploadStateKindBuild      = "build"
+	UploadStateKindScreenshot = "screenshot"
+	UploadStateKindPreview    = "preview"
+)
+
+// UploadState records the progress of one reserved upload so that a later
+// run can upload only the remaining parts and commit the same reservation.
+type UploadState struct {
+	Kind string `json:"kind"`
+	// Scope identifies where the file is being uploaded (app/version/build,
+	// or the screenshot/preview set ID).
+	Scope    string `json:"scope"`
+	Checksum string `json:"checksum"`
+	FileSize int64  `json:"fileSize"`
+	// UploadID is the reservation: the build upload ID, or the screenshot
+	// or preview asset ID.
+	UploadID   string            `json:"uploadId"`
+	FileID     string            `json:"fileId,omitempty"`
+	Operations []UploadOperation `json:"operations"`
+	Completed  []int             `json:"completed,omitempty"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+
+	path string
+	mu   sync.Mutex
+}
+
+// UploadStateStore persists upload states as one JSON file per reservation,
+// named after the kind, scope and file checksum. A nil store is valid and
+// disables resuming.
+type UploadStateStore struct {
+	dir string
+}
+
+// NewUploadStateStore creates a store rooted at dir.
+func NewUploadStateStore(dir string) *UploadStateStore {
+	return &UploadStateStore{dir: dir}
+}
+
+func (s *UploadStateStore) statePath(kind, scope, checksum string) string {
+	sum := sha256.Sum256([]byte(kind + "\x00" + scope + "\x00" + strings.ToLower(checksum)))
+	return filepath.Join(s.dir, kind+"-"+hex.EncodeToString(sum[:16])+".json")
+}
+
+// Load returns the saved state for a file, or nil when there is nothing to
+// resume. States whose remaining upload URLs have expired are discarded.
+func (s *UploadStateStore) Load(kind, scope, checksum string, fileSize int64) (*UploadState, error) {
+	if s == nil || ActivePlan() != nil {
+		return nil, nil
+	}
+	path := s.statePath(kind, scope, checksum)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read upload state: %w", err)
+	}
+
+	state := &UploadState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		_ = os.Remove(path)
+		return nil, nil
+	}
+	state.path = path
+	if state.Kind != kind || state.Scope != scope || !strings.EqualFold(state.Checksum, checksum) ||
+		state.FileSize != fileSize || strings.TrimSpace(state.UploadID) == "" || len(state.Operations) == 0 ||
+		state.expired(time.Now()) {
+		_ = state.Remove()
+		return nil, nil
+	}
+	return state, nil
+}
+
+// Begin saves a new reservation before any part is uploaded.
+func (s *UploadStateStore) Begin(kind, scope, checksum string, fileSize int64, uploadID, fileID string, operations []UploadOperation) (*UploadState, error) {
+	if s == nil || ActivePlan() != nil {
+		return nil, nil
+	}
+	state := &UploadState{
+		Kind:       kind,
+		Scope:      scope,
+		Checksum:   strings.ToLower(checksum),
+		FileSize:   fileSize,
+		UploadID:   uploadID,
+		FileID:     fileID,
+		Operations: operations,
+		path:       s.statePath(kind, scope, checksum),
+	}
+	if err := state.save(); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// expired reports whether any remaining operation's presigned URL has expired.
+func (s *UploadState) expired(now time.Time) bool {
+	for i, op := range s.Operations {
+		if slices.Contains(s.Completed, i) || op.Expiration == nil {
+			continue
+		}
+		expiration, err := time.Parse(time.RFC3339, strings.TrimSpace(*op.Expiration))
+		if err == nil && !expiration.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompletedCount returns how many operations have already been uploaded.
+func (s *UploadState) CompletedCount() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.Completed)
+}
+
+func (s *UploadState) isCompleted(index int) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Contains(s.Completed, index)
+}
+
+// markCompleted records a finished operation. Persisting is best effort: a
+// failed write only means the part is uploaded again on resume.
+func (s *UploadState) markCompleted(index int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if !slices.Contains(s.Completed, index) {
+		s.Completed = append(s.Completed, index)
+		slices.Sort(s.Completed)
+	}
+	s.mu.Unlock()
+	_ = s.save()
+}
+
+func (s *UploadState) save() error {
+	s.mu.Lock()
+	s.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(s, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("create upload state dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".upload-state-*")
+	if err != nil {
+		return fmt.Errorf("write upload state: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write upload state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write upload state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write upload state: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes the state file once the upload has been committed.
+func (s *UploadState) Remove() error {
+	if s == nil || s.path == "" {
+		return nil
+	}
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// WithUploadState resumes from and records progress to state: completed
+// operations are skipped and each finished operation is persisted.
+func WithUploadState(state *UploadState) UploadOption {
+	return func(opts *UploadOptions) {
+		opts.State = state
+	}
+}
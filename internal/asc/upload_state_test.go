@@ -0,0 +1,138 @@
+package asc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExecuteUploadOperationsResumesFromState(t *testing.T) {
+	t.Setenv("ASC_MAX_RETRIES", "0")
+	filePath := filepath.Join(t.TempDir(), "app.ipa")
+	if err := os.WriteFile(filePath, []byte("abcdefghijkl"), 0o600); err != nil {
+		t.Fatalf("write payload: %v", err)
+	}
+
+	var mu sync.Mutex
+	var requested []string
+	failPart := "/c"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		fail := r.URL.Path == failPart
+		mu.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	operations := []UploadOperation{
+		{Method: http.MethodPut, URL: server.URL + "/a", Offset: 0, Length: 4},
+		{Method: http.MethodPut, URL: server.URL + "/b", Offset: 4, Length: 4},
+		{Method: http.MethodPut, URL: server.URL + "/c", Offset: 8, Length: 4},
+	}
+	store := NewUploadStateStore(filepath.Join(t.TempDir(), "uploads"))
+	state, err := store.Begin(UploadStateKindBuild, "app/IOS/1.0/1", "ABC123", 12, "upload-1", "file-1", operations)
+	if err != nil {
+		t.Fatalf("Begin() error: %v", err)
+	}
+
+	err = ExecuteUploadOperations(context.Background(), filePath, operations, WithUploadState(state), WithUploadHTTPClient(server.Client()))
+	if err == nil {
+		t.Fatal("expected first run to fail")
+	}
+
+	resumed, err := store.Load(UploadStateKindBuild, "app/IOS/1.0/1", "abc123", 12)
+	if err != nil || resumed == nil {
+		t.Fatalf("Load() = %v, %v; want saved state", resumed, err)
+	}
+	if resumed.UploadID != "upload-1" || resumed.FileID != "file-1" || !slices.Equal(resumed.Completed, []int{0, 1}) {
+		t.Fatalf("unexpected resumed state %+v", resumed)
+	}
+
+	mu.Lock()
+	requested = nil
+	failPart = ""
+	mu.Unlock()
+	if err := ExecuteUploadOperations(context.Background(), filePath, resumed.Operations, WithUploadState(resumed), WithUploadHTTPClient(server.Client())); err != nil {
+		t.Fatalf("resumed upload error: %v", err)
+	}
+	if !slices.Equal(requested, []string{"/c"}) {
+		t.Fatalf("expected only the remaining part to be uploaded, got %v", requested)
+	}
+
+	if err := resumed.Remove(); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	if again, _ := store.Load(UploadStateKindBuild, "app/IOS/1.0/1", "abc123", 12); again != nil {
+		t.Fatalf("expected no state after Remove, got %+v", again)
+	}
+}
+
+func TestUploadStateStoreLoadDiscardsStaleState(t *testing.T) {
+	store := NewUploadStateStore(t.TempDir())
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	if _, err := store.Begin(UploadStateKindScreenshot, "set-1", "sum", 4, "shot-1", "", []UploadOperation{{URL: "https://example.com/a", Length: 4, Expiration: &future}}); err != nil {
+		t.Fatalf("Begin() error: %v", err)
+	}
+	if state, _ := store.Load(UploadStateKindScreenshot, "set-2", "sum", 4); state != nil {
+		t.Fatalf("expected other scopes not to match, got %+v", state)
+	}
+	if state, _ := store.Load(UploadStateKindScreenshot, "set-1", "sum", 5); state != nil {
+		t.Fatalf("expected a changed file size to discard state, got %+v", state)
+	}
+	if state, _ := store.Load(UploadStateKindScreenshot, "set-1", "sum", 4); state != nil {
+		t.Fatalf("expected mismatched state to have been removed, got %+v", state)
+	}
+
+	if _, err := store.Begin(UploadStateKindPreview, "set-1", "sum", 4, "preview-1", "", []UploadOperation{{URL: "https://example.com/a", Length: 4, Expiration: &past}}); err != nil {
+		t.Fatalf("Begin() error: %v", err)
+	}
+	if state, _ := store.Load(UploadStateKindPreview, "set-1", "sum", 4); state != nil {
+		t.Fatalf("expected expired upload URLs to discard state, got %+v", state)
+	}
+}
+
+func TestUploadAssetFromFileSkipsCompletedOperations(t *testing.T) {
+	file := createTempAssetFile(t, []byte("abcdef"))
+	defer file.Close()
+
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	operations := []UploadOperation{
+		{Method: http.MethodPut, URL: server.URL + "/part1", Offset: 0, Length: 3},
+		{Method: http.MethodPut, URL: server.URL + "/part2", Offset: 3, Length: 3},
+	}
+	store := NewUploadStateStore(t.TempDir())
+	state, err := store.Begin(UploadStateKindScreenshot, "set-1", "sum", 6, "shot-1", "", operations)
+	if err != nil {
+		t.Fatalf("Begin() error: %v", err)
+	}
+	state.markCompleted(0)
+
+	if err := UploadAssetFromFile(context.Background(), file, 6, operations, WithUploadState(state)); err != nil {
+		t.Fatalf("UploadAssetFromFile() error: %v", err)
+	}
+	if !slices.Equal(requested, []string{"/part2"}) {
+		t.Fatalf("expected only part2 to be uploaded, got %v", requested)
+	}
+	if state.CompletedCount() != 2 {
+		t.Fatalf("expected both parts completed, got %d", state.CompletedCount())
+	}
+}
@@ -0,0 +1,140 @@
+package ascmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+)
+
+// Linkage identifies a related resource.
+type Linkage struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Relationship is a JSON:API relationship holding either a single linkage
+// (to-one) or a list of linkages (to-many).
+type Relationship struct {
+	Data   []Linkage
+	ToMany bool
+}
+
+// MarshalJSON encodes the relationship in JSON:API form.
+func (r Relationship) MarshalJSON() ([]byte, error) {
+	if r.ToMany {
+		data := r.Data
+		if data == nil {
+			data = []Linkage{}
+		}
+		return json.Marshal(struct {
+			Data []Linkage `json:"data"`
+		}{Data: data})
+	}
+	if len(r.Data) == 0 {
+		return []byte(`{"data":null}`), nil
+	}
+	return json.Marshal(struct {
+		Data Linkage `json:"data"`
+	}{Data: r.Data[0]})
+}
+
+// UnmarshalJSON decodes a JSON:API relationship object.
+func (r *Relationship) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	linkages, toMany, err := decodeLinkageData(raw.Data)
+	if err != nil {
+		return err
+	}
+	r.Data = linkages
+	r.ToMany = toMany
+	return nil
+}
+
+func decodeLinkageData(data json.RawMessage) ([]Linkage, bool, error) {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")):
+		return nil, false, nil
+	case trimmed[0] == '[':
+		var linkages []Linkage
+		if err := json.Unmarshal(trimmed, &linkages); err != nil {
+			return nil, false, fmt.Errorf("relationship data: %w", err)
+		}
+		return linkages, true, nil
+	default:
+		var linkage Linkage
+		if err := json.Unmarshal(trimmed, &linkage); err != nil {
+			return nil, false, fmt.Errorf("relationship data: %w", err)
+		}
+		return []Linkage{linkage}, false, nil
+	}
+}
+
+// Resource is a JSON:API resource object held by the emulator.
+type Resource struct {
+	Type          string                  `json:"type"`
+	ID            string                  `json:"id"`
+	Attributes    map[string]any          `json:"attributes,omitempty"`
+	Relationships map[string]Relationship `json:"relationships,omitempty"`
+}
+
+func (r *Resource) clone() *Resource {
+	cloned := &Resource{Type: r.Type, ID: r.ID}
+	if r.Attributes != nil {
+		cloned.Attributes = maps.Clone(r.Attributes)
+	}
+	if r.Relationships != nil {
+		cloned.Relationships = make(map[string]Relationship, len(r.Relationships))
+		for name, rel := range r.Relationships {
+			rel.Data = append([]Linkage(nil), rel.Data...)
+			cloned.Relationships[name] = rel
+		}
+	}
+	return cloned
+}
+
+// linksTo reports whether any relationship of r points at target.
+func (r *Resource) linksTo(target Linkage) bool {
+	for _, rel := range r.Relationships {
+		for _, linkage := range rel.Data {
+			if linkage == target {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// document is a JSON:API request document whose data is decoded by the caller.
+type document struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// parseSeed accepts either a bare resource array or {"data": [...]}.
+func parseSeed(data []byte) ([]Resource, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	if trimmed[0] == '[' {
+		var resources []Resource
+		if err := json.Unmarshal(trimmed, &resources); err != nil {
+			return nil, fmt.Errorf("parse seed: %w", err)
+		}
+		return resources, nil
+	}
+	var doc struct {
+		Data     []Resource `json:"data"`
+		Included []Resource `json:"included"`
+	}
+	if err := json.Unmarshal(trimmed, &doc); err != nil {
+		return nil, fmt.Errorf("parse seed: %w", err)
+	}
+	return append(doc.Data, doc.Included...), nil
+}
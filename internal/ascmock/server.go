@@ -0,0 +1,669 @@
+// Package ascmock implements a local, stateful App Store Connect API emulator.
+//
+// The emulator accepts every operation listed in the bundled schema index and
+// keeps an in-memory JSON:API resource graph, so POST/PATCH/DELETE requests
+// mutate state that later GET requests observe.
+package ascmock
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+	firstResourceID  = 1000000001
+)
+
+// Endpoint is an API operation the emulator accepts, e.g. GET /v1/apps/{id}.
+type Endpoint struct {
+	Method string
+	Path   string
+}
+
+type route struct {
+	method   string
+	segments []string
+}
+
+// Server is an http.Handler that emulates the App Store Connect API.
+type Server struct {
+	routes []route
+
+	mu        sync.Mutex
+	resources map[string]map[string]*Resource
+	order     map[string][]string
+	nextID    int
+}
+
+// NewServer creates an emulator accepting the given endpoints.
+func NewServer(endpoints []Endpoint) *Server {
+	routes := make([]route, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		routes = append(routes, route{
+			method:   strings.ToUpper(strings.TrimSpace(endpoint.Method)),
+			segments: splitPath(endpoint.Path),
+		})
+	}
+	return &Server{
+		routes:    routes,
+		resources: map[string]map[string]*Resource{},
+		order:     map[string][]string{},
+		nextID:    firstResourceID,
+	}
+}
+
+// Seed loads initial resources into the emulator.
+func (s *Server) Seed(resources []Resource) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range resources {
+		resource := resources[i]
+		if strings.TrimSpace(resource.Type) == "" {
+			return fmt.Errorf("seed resource %d: type is required", i+1)
+		}
+		if strings.TrimSpace(resource.ID) == "" {
+			resource.ID = s.allocateID()
+		}
+		s.put(resource.clone())
+	}
+	return nil
+}
+
+// SeedFile loads initial resources from a JSON file containing either an
+// array of resources or a JSON:API document with data/included arrays.
+func (s *Server) SeedFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read seed file: %w", err)
+	}
+	resources, err := parseSeed(data)
+	if err != nil {
+		return err
+	}
+	return s.Seed(resources)
+}
+
+// Resources returns a snapshot of all stored resources of the given type.
+func (s *Server) Resources(resourceType string) []Resource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := s.order[resourceType]
+	out := make([]Resource, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, *s.resources[resourceType][id].clone())
+	}
+	return out
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, http.StatusUnauthorized, "NOT_AUTHORIZED", "Authentication credentials are missing or invalid.", "Provide a properly configured and signed bearer token, and make sure that it has not expired.")
+		return
+	}
+
+	segments := splitPath(r.URL.Path)
+	matched, methodAllowed := s.match(r.Method, segments)
+	if !matched {
+		if methodAllowed {
+			writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "The request method is not allowed.", fmt.Sprintf("The resource does not allow the %s method.", r.Method))
+			return
+		}
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "The specified resource does not exist", "The path provided does not match a defined resource type.")
+		return
+	}
+
+	// segments[0] is the API version; the rest follow the resource shapes
+	// used by every operation in the schema index.
+	parts := segments[1:]
+	switch {
+	case len(parts) == 1:
+		s.handleCollection(w, r, parts[0])
+	case len(parts) == 2:
+		s.handleInstance(w, r, parts[0], parts[1])
+	case len(parts) == 3 && r.Method == http.MethodGet:
+		s.handleRelated(w, r, parts[0], parts[1], parts[2])
+	case len(parts) == 4 && parts[2] == "relationships":
+		s.handleLinkage(w, r, parts[0], parts[1], parts[3])
+	default:
+		writeError(w, http.StatusNotImplemented, "NOT_IMPLEMENTED", "Endpoint not emulated", fmt.Sprintf("%s %s is not supported by the emulator.", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) match(method string, segments []string) (matched bool, otherMethod bool) {
+	method = strings.ToUpper(method)
+	for _, rt := range s.routes {
+		if !segmentsMatch(rt.segments, segments) {
+			continue
+		}
+		if rt.method == method {
+			return true, true
+		}
+		otherMethod = true
+	}
+	return false, otherMethod
+}
+
+func segmentsMatch(template, segments []string) bool {
+	if len(template) != len(segments) {
+		return false
+	}
+	for i, part := range template {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if part != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) handleCollection(w http.ResponseWriter, r *http.Request, resourceType string) {
+	switch r.Method {
+	case http.MethodGet:
+		s.mu.Lock()
+		items := s.filtered(resourceType, r.URL.Query())
+		s.mu.Unlock()
+		s.writeList(w, r, items)
+	case http.MethodPost:
+		resource, ok := readResource(w, r, resourceType, "")
+		if !ok {
+			return
+		}
+		s.mu.Lock()
+		if resource.ID == "" {
+			resource.ID = s.allocateID()
+		}
+		if s.get(resourceType, resource.ID) != nil {
+			s.mu.Unlock()
+			writeError(w, http.StatusConflict, "ENTITY_ERROR.ATTRIBUTE.INVALID.DUPLICATE", "The provided entity includes a duplicate value", fmt.Sprintf("A %s resource with id %q already exists.", resourceType, resource.ID))
+			return
+		}
+		s.put(resource)
+		created := resource.clone()
+		s.mu.Unlock()
+		writeJSON(w, http.StatusCreated, map[string]any{
+			"data":  created,
+			"links": map[string]string{"self": absoluteURL(r, r.URL.Path+"/"+url.PathEscape(created.ID), nil)},
+		})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "The request method is not allowed.", fmt.Sprintf("The resource does not allow the %s method.", r.Method))
+	}
+}
+
+func (s *Server) handleInstance(w http.ResponseWriter, r *http.Request, resourceType, id string) {
+	switch r.Method {
+	case http.MethodGet:
+		s.mu.Lock()
+		resource := s.get(resourceType, id)
+		var included []*Resource
+		if resource != nil {
+			resource = resource.clone()
+			included = s.included([]*Resource{resource}, r.URL.Query().Get("include"))
+		}
+		s.mu.Unlock()
+		if resource == nil {
+			writeNotFound(w, resourceType, id)
+			return
+		}
+		body := map[string]any{
+			"data":  resource,
+			"links": map[string]string{"self": absoluteURL(r, r.URL.Path, nil)},
+		}
+		if len(included) > 0 {
+			body["included"] = included
+		}
+		writeJSON(w, http.StatusOK, body)
+	case http.MethodPatch:
+		update, ok := readResource(w, r, resourceType, id)
+		if !ok {
+			return
+		}
+		s.mu.Lock()
+		resource := s.get(resourceType, id)
+		if resource == nil {
+			s.mu.Unlock()
+			writeNotFound(w, resourceType, id)
+			return
+		}
+		if resource.Attributes == nil && len(update.Attributes) > 0 {
+			resource.Attributes = map[string]any{}
+		}
+		for name, value := range update.Attributes {
+			resource.Attributes[name] = value
+		}
+		if resource.Relationships == nil && len(update.Relationships) > 0 {
+			resource.Relationships = map[string]Relationship{}
+		}
+		for name, rel := range update.Relationships {
+			resource.Relationships[name] = rel
+		}
+		updated := resource.clone()
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]any{
+			"data":  updated,
+			"links": map[string]string{"self": absoluteURL(r, r.URL.Path, nil)},
+		})
+	case http.MethodDelete:
+		s.mu.Lock()
+		deleted := s.delete(resourceType, id)
+		s.mu.Unlock()
+		if !deleted {
+			writeNotFound(w, resourceType, id)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "The request method is not allowed.", fmt.Sprintf("The resource does not allow the %s method.", r.Method))
+	}
+}
+
+func (s *Server) handleRelated(w http.ResponseWriter, r *http.Request, resourceType, id, relationship string) {
+	s.mu.Lock()
+	parent := s.get(resourceType, id)
+	if parent == nil {
+		s.mu.Unlock()
+		writeNotFound(w, resourceType, id)
+		return
+	}
+
+	if rel, ok := parent.Relationships[relationship]; ok {
+		related := s.resolve(rel.Data)
+		s.mu.Unlock()
+		if rel.ToMany {
+			s.writeList(w, r, related)
+			return
+		}
+		var data any
+		if len(related) > 0 {
+			data = related[0]
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"data":  data,
+			"links": map[string]string{"self": absoluteURL(r, r.URL.Path, nil)},
+		})
+		return
+	}
+
+	// Without an explicit linkage on the parent, treat the relationship name
+	// as a resource type and return resources that point back at the parent.
+	target := Linkage{Type: resourceType, ID: id}
+	var related []*Resource
+	for _, candidate := range s.filtered(relationship, r.URL.Query()) {
+		if candidate.linksTo(target) {
+			related = append(related, candidate)
+		}
+	}
+	_, isType := s.resources[relationship]
+	s.mu.Unlock()
+
+	if !isType && len(related) == 0 && !strings.HasSuffix(relationship, "s") {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"data":  nil,
+			"links": map[string]string{"self": absoluteURL(r, r.URL.Path, nil)},
+		})
+		return
+	}
+	s.writeList(w, r, related)
+}
+
+func (s *Server) handleLinkage(w http.ResponseWriter, r *http.Request, resourceType, id, relationship string) {
+	if r.Method == http.MethodGet {
+		s.mu.Lock()
+		parent := s.get(resourceType, id)
+		var rel Relationship
+		if parent != nil {
+			rel = parent.Relationships[relationship]
+		}
+		s.mu.Unlock()
+		if parent == nil {
+			writeNotFound(w, resourceType, id)
+			return
+		}
+		body, err := json.Marshal(rel)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "UNEXPECTED_ERROR", "An unexpected error occurred.", err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+		return
+	}
+
+	var doc document
+	if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
+		writeError(w, http.StatusBadRequest, "PARAMETER_ERROR.INVALID", "A parameter has an invalid value", "The request body is not a valid JSON:API document.")
+		return
+	}
+	linkages, toMany, err := decodeLinkageData(doc.Data)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "PARAMETER_ERROR.INVALID", "A parameter has an invalid value", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	parent := s.get(resourceType, id)
+	if parent == nil {
+		writeNotFound(w, resourceType, id)
+		return
+	}
+	if parent.Relationships == nil {
+		parent.Relationships = map[string]Relationship{}
+	}
+	current := parent.Relationships[relationship]
+
+	switch r.Method {
+	case http.MethodPatch:
+		current = Relationship{Data: linkages, ToMany: toMany}
+	case http.MethodPost:
+		current.ToMany = true
+		for _, linkage := range linkages {
+			if !slices.Contains(current.Data, linkage) {
+				current.Data = append(current.Data, linkage)
+			}
+		}
+	case http.MethodDelete:
+		current.ToMany = true
+		current.Data = slices.DeleteFunc(current.Data, func(linkage Linkage) bool {
+			return slices.Contains(linkages, linkage)
+		})
+	}
+	parent.Relationships[relationship] = current
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// filtered returns resources of a type matching filter[...] query parameters.
+// Attribute filters compare string forms; other filters match relationship
+// linkage IDs. The caller must hold s.mu.
+func (s *Server) filtered(resourceType string, query url.Values) []*Resource {
+	var out []*Resource
+	for _, id := range s.order[resourceType] {
+		resource := s.resources[resourceType][id]
+		if matchesFilters(resource, query) {
+			out = append(out, resource.clone())
+		}
+	}
+	return out
+}
+
+func matchesFilters(resource *Resource, query url.Values) bool {
+	for key, values := range query {
+		if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "filter["), "]")
+		var allowed []string
+		for _, value := range values {
+			for part := range strings.SplitSeq(value, ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					allowed = append(allowed, part)
+				}
+			}
+		}
+		if len(allowed) == 0 {
+			continue
+		}
+		if name == "id" {
+			if !slices.Contains(allowed, resource.ID) {
+				return false
+			}
+			continue
+		}
+		if value, ok := resource.Attributes[name]; ok {
+			if !slices.Contains(allowed, fmt.Sprint(value)) {
+				return false
+			}
+			continue
+		}
+		// Filters like filter[app] or filter[build.id] match relationships.
+		relName := strings.TrimSuffix(name, ".id")
+		rel, ok := resource.Relationships[relName]
+		if !ok {
+			return false
+		}
+		if !slices.ContainsFunc(rel.Data, func(linkage Linkage) bool {
+			return slices.Contains(allowed, linkage.ID)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, items []*Resource) {
+	query := r.URL.Query()
+	limit := defaultPageLimit
+	if raw := strings.TrimSpace(query.Get("limit")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxPageLimit {
+			writeError(w, http.StatusBadRequest, "PARAMETER_ERROR.INVALID", "A parameter has an invalid value", fmt.Sprintf("'%s' is not a valid value for 'limit'. Must be between 1 and %d.", raw, maxPageLimit))
+			return
+		}
+		limit = parsed
+	}
+	offset := 0
+	if cursor := strings.TrimSpace(query.Get("cursor")); cursor != "" {
+		parsed, ok := decodeCursor(cursor)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "PARAMETER_ERROR.INVALID", "A parameter has an invalid value", fmt.Sprintf("'%s' is not a valid cursor for this request", cursor))
+			return
+		}
+		offset = parsed
+	}
+
+	total := len(items)
+	start := min(offset, total)
+	end := min(start+limit, total)
+	page := items[start:end]
+	if page == nil {
+		page = []*Resource{}
+	}
+
+	links := map[string]string{"self": absoluteURL(r, r.URL.Path, query)}
+	if end < total {
+		next := cloneValues(query)
+		next.Set("cursor", encodeCursor(end))
+		next.Set("limit", strconv.Itoa(limit))
+		links["next"] = absoluteURL(r, r.URL.Path, next)
+	}
+
+	s.mu.Lock()
+	included := s.included(page, query.Get("include"))
+	s.mu.Unlock()
+
+	body := map[string]any{
+		"data":  page,
+		"links": links,
+		"meta": map[string]any{
+			"paging": map[string]int{"total": total, "limit": limit},
+		},
+	}
+	if len(included) > 0 {
+		body["included"] = included
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// included resolves comma-separated include relationships for data.
+// The caller must hold s.mu.
+func (s *Server) included(data []*Resource, include string) []*Resource {
+	if strings.TrimSpace(include) == "" {
+		return nil
+	}
+	seen := map[Linkage]bool{}
+	var out []*Resource
+	for name := range strings.SplitSeq(include, ",") {
+		name = strings.TrimSpace(name)
+		for _, resource := range data {
+			for _, related := range s.resolve(resource.Relationships[name].Data) {
+				key := Linkage{Type: related.Type, ID: related.ID}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				out = append(out, related)
+			}
+		}
+	}
+	return out
+}
+
+// resolve returns stored copies of linked resources, skipping dangling links.
+// The caller must hold s.mu.
+func (s *Server) resolve(linkages []Linkage) []*Resource {
+	out := make([]*Resource, 0, len(linkages))
+	for _, linkage := range linkages {
+		if resource := s.get(linkage.Type, linkage.ID); resource != nil {
+			out = append(out, resource.clone())
+		}
+	}
+	return out
+}
+
+func (s *Server) get(resourceType, id string) *Resource {
+	return s.resources[resourceType][id]
+}
+
+func (s *Server) put(resource *Resource) {
+	byID, ok := s.resources[resource.Type]
+	if !ok {
+		byID = map[string]*Resource{}
+		s.resources[resource.Type] = byID
+	}
+	if _, exists := byID[resource.ID]; !exists {
+		s.order[resource.Type] = append(s.order[resource.Type], resource.ID)
+	}
+	byID[resource.ID] = resource
+}
+
+func (s *Server) delete(resourceType, id string) bool {
+	if s.get(resourceType, id) == nil {
+		return false
+	}
+	delete(s.resources[resourceType], id)
+	s.order[resourceType] = slices.DeleteFunc(s.order[resourceType], func(existing string) bool {
+		return existing == id
+	})
+	return true
+}
+
+func (s *Server) allocateID() string {
+	id := strconv.Itoa(s.nextID)
+	s.nextID++
+	return id
+}
+
+// readResource decodes a single-resource request document and checks that
+// its type (and id, for updates) matches the request path.
+func readResource(w http.ResponseWriter, r *http.Request, resourceType, id string) (*Resource, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "PARAMETER_ERROR.INVALID", "A parameter has an invalid value", "The request body could not be read.")
+		return nil, false
+	}
+	var doc struct {
+		Data *Resource `json:"data"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil || doc.Data == nil {
+		writeError(w, http.StatusBadRequest, "PARAMETER_ERROR.INVALID", "A parameter has an invalid value", "The request body is not a valid JSON:API document.")
+		return nil, false
+	}
+	resource := doc.Data
+	if resource.Type != resourceType {
+		writeError(w, http.StatusConflict, "ENTITY_ERROR.INCLUDED.INVALID_TYPE", "The provided entity has an invalid type", fmt.Sprintf("Expected type %q but got %q.", resourceType, resource.Type))
+		return nil, false
+	}
+	if id != "" && resource.ID != id {
+		writeError(w, http.StatusConflict, "ENTITY_ERROR.INVALID_ID", "The provided entity id is invalid", fmt.Sprintf("The resource id %q does not match the request path id %q.", resource.ID, id))
+		return nil, false
+	}
+	return resource, true
+}
+
+func writeNotFound(w http.ResponseWriter, resourceType, id string) {
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "The specified resource does not exist", fmt.Sprintf("There is no resource of type '%s' with id '%s'", resourceType, id))
+}
+
+func writeError(w http.ResponseWriter, status int, code, title, detail string) {
+	writeJSON(w, status, map[string]any{
+		"errors": []map[string]string{{
+			"status": strconv.Itoa(status),
+			"code":   code,
+			"title":  title,
+			"detail": detail,
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func absoluteURL(r *http.Request, path string, query url.Values) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	u := url.URL{Scheme: scheme, Host: r.Host, Path: path}
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+func cloneValues(values url.Values) url.Values {
+	cloned := make(url.Values, len(values))
+	for key, vals := range values {
+		cloned[key] = append([]string(nil), vals...)
+	}
+	return cloned
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	raw, ok := strings.CutPrefix(string(data), "offset:")
+	if !ok {
+		return 0, false
+	}
+	offset, err := strconv.Atoi(raw)
+	if err != nil || offset < 0 {
+		return 0, false
+	}
+	return offset, true
+}
+
+func splitPath(path string) []string {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "/")
+}
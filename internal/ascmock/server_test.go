@@ -0,0 +1,214 @@
+package ascmock
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+var testEndpoints = []Endpoint{
+	{Method: "GET", Path: "/v1/apps"},
+	{Method: "GET", Path: "/v1/apps/{id}"},
+	{Method: "PATCH", Path: "/v1/apps/{id}"},
+	{Method: "GET", Path: "/v1/apps/{id}/appStoreVersions"},
+	{Method: "POST", Path: "/v1/appStoreVersions"},
+	{Method: "GET", Path: "/v1/appStoreVersions/{id}"},
+	{Method: "DELETE", Path: "/v1/appStoreVersions/{id}"},
+	{Method: "GET", Path: "/v1/betaGroups/{id}/relationships/builds"},
+	{Method: "POST", Path: "/v1/betaGroups/{id}/relationships/builds"},
+	{Method: "DELETE", Path: "/v1/betaGroups/{id}/relationships/builds"},
+}
+
+func newTestServer(t *testing.T) (*Server, *httptest.Server) {
+	t.Helper()
+
+	mock := NewServer(testEndpoints)
+	if err := mock.Seed([]Resource{
+		{Type: "apps", ID: "1", Attributes: map[string]any{"name": "Alpha", "bundleId": "com.example.alpha"}},
+		{Type: "apps", ID: "2", Attributes: map[string]any{"name": "Beta", "bundleId": "com.example.beta"}},
+		{Type: "apps", ID: "3", Attributes: map[string]any{"name": "Gamma", "bundleId": "com.example.gamma"}},
+		{Type: "betaGroups", ID: "g1", Attributes: map[string]any{"name": "Internal"}},
+		{Type: "builds", ID: "b1"},
+	}); err != nil {
+		t.Fatalf("Seed() error: %v", err)
+	}
+	srv := httptest.NewServer(mock)
+	t.Cleanup(srv.Close)
+	return mock, srv
+}
+
+func doRequest(t *testing.T, method, rawURL, body string) (int, map[string]any) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, rawURL, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest() error: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer test")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() error: %v", err)
+	}
+	var decoded map[string]any
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("invalid JSON response %q: %v", data, err)
+		}
+	}
+	return resp.StatusCode, decoded
+}
+
+func TestServer_RejectsMissingAuthorization(t *testing.T) {
+	_, srv := newTestServer(t)
+
+	resp, err := http.Get(srv.URL + "/v1/apps")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestServer_UnknownPathAndMethod(t *testing.T) {
+	_, srv := newTestServer(t)
+
+	if status, _ := doRequest(t, http.MethodGet, srv.URL+"/v1/unknownThings", ""); status != http.StatusNotFound {
+		t.Fatalf("unknown path status = %d, want 404", status)
+	}
+	if status, _ := doRequest(t, http.MethodDelete, srv.URL+"/v1/apps/1", ""); status != http.StatusMethodNotAllowed {
+		t.Fatalf("unsupported method status = %d, want 405", status)
+	}
+}
+
+func TestServer_MutationsAreVisibleToReads(t *testing.T) {
+	_, srv := newTestServer(t)
+
+	status, body := doRequest(t, http.MethodPost, srv.URL+"/v1/appStoreVersions", `{"data":{"type":"appStoreVersions","attributes":{"versionString":"1.0","platform":"IOS"},"relationships":{"app":{"data":{"type":"apps","id":"1"}}}}}`)
+	if status != http.StatusCreated {
+		t.Fatalf("create status = %d, want 201 (%v)", status, body)
+	}
+	versionID := body["data"].(map[string]any)["id"].(string)
+
+	status, body = doRequest(t, http.MethodGet, srv.URL+"/v1/apps/1/appStoreVersions?filter[platform]=IOS", "")
+	if status != http.StatusOK {
+		t.Fatalf("related status = %d", status)
+	}
+	if data := body["data"].([]any); len(data) != 1 || data[0].(map[string]any)["id"] != versionID {
+		t.Fatalf("expected created version in related list, got %v", body["data"])
+	}
+
+	status, body = doRequest(t, http.MethodPatch, srv.URL+"/v1/apps/1", `{"data":{"type":"apps","id":"1","attributes":{"name":"Alpha Renamed"}}}`)
+	if status != http.StatusOK {
+		t.Fatalf("patch status = %d (%v)", status, body)
+	}
+	_, body = doRequest(t, http.MethodGet, srv.URL+"/v1/apps/1", "")
+	attrs := body["data"].(map[string]any)["attributes"].(map[string]any)
+	if attrs["name"] != "Alpha Renamed" || attrs["bundleId"] != "com.example.alpha" {
+		t.Fatalf("expected merged attributes, got %v", attrs)
+	}
+
+	if status, _ := doRequest(t, http.MethodDelete, srv.URL+"/v1/appStoreVersions/"+versionID, ""); status != http.StatusNoContent {
+		t.Fatalf("delete status = %d, want 204", status)
+	}
+	if status, _ := doRequest(t, http.MethodGet, srv.URL+"/v1/appStoreVersions/"+versionID, ""); status != http.StatusNotFound {
+		t.Fatalf("get after delete status = %d, want 404", status)
+	}
+}
+
+func TestServer_RelationshipLinkageMutations(t *testing.T) {
+	_, srv := newTestServer(t)
+	linkageURL := srv.URL + "/v1/betaGroups/g1/relationships/builds"
+
+	if status, _ := doRequest(t, http.MethodPost, linkageURL, `{"data":[{"type":"builds","id":"b1"}]}`); status != http.StatusNoContent {
+		t.Fatalf("add linkage status = %d, want 204", status)
+	}
+	_, body := doRequest(t, http.MethodGet, linkageURL, "")
+	if data := body["data"].([]any); len(data) != 1 {
+		t.Fatalf("expected one linked build, got %v", body["data"])
+	}
+
+	if status, _ := doRequest(t, http.MethodDelete, linkageURL, `{"data":[{"type":"builds","id":"b1"}]}`); status != http.StatusNoContent {
+		t.Fatalf("remove linkage status = %d, want 204", status)
+	}
+	_, body = doRequest(t, http.MethodGet, linkageURL, "")
+	if data := body["data"].([]any); len(data) != 0 {
+		t.Fatalf("expected no linked builds, got %v", body["data"])
+	}
+}
+
+func TestServer_PaginatesWithNextLinks(t *testing.T) {
+	_, srv := newTestServer(t)
+
+	var names []string
+	next := srv.URL + "/v1/apps?limit=2"
+	for next != "" {
+		status, body := doRequest(t, http.MethodGet, next, "")
+		if status != http.StatusOK {
+			t.Fatalf("list status = %d (%v)", status, body)
+		}
+		for _, item := range body["data"].([]any) {
+			names = append(names, item.(map[string]any)["attributes"].(map[string]any)["name"].(string))
+		}
+		next, _ = body["links"].(map[string]any)["next"].(string)
+	}
+	if got := strings.Join(names, ","); got != "Alpha,Beta,Gamma" {
+		t.Fatalf("paginated names = %q", got)
+	}
+}
+
+func TestServer_WorksWithASCClientViaBaseURL(t *testing.T) {
+	_, srv := newTestServer(t)
+	t.Setenv("ASC_BASE_URL", srv.URL)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error: %v", err)
+	}
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	client, err := asc.NewClientFromPEM("KEY", "ISSUER", keyPEM)
+	if err != nil {
+		t.Fatalf("NewClientFromPEM() error: %v", err)
+	}
+
+	ctx := context.Background()
+	first, err := client.GetApps(ctx, asc.WithAppsLimit(2))
+	if err != nil {
+		t.Fatalf("GetApps() error: %v", err)
+	}
+	all, err := asc.PaginateAll(ctx, first, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		return client.GetApps(ctx, asc.WithAppsNextURL(nextURL))
+	})
+	if err != nil {
+		t.Fatalf("PaginateAll() error: %v", err)
+	}
+	apps, ok := all.(*asc.AppsResponse)
+	if !ok {
+		t.Fatalf("PaginateAll() type = %T", all)
+	}
+	if len(apps.Data) != 3 {
+		t.Fatalf("expected 3 apps across pages, got %d", len(apps.Data))
+	}
+}
@@ -51,6 +51,7 @@ type Credential struct {
 	IssuerID              string    `json:"issuer_id"`
 	PrivateKeyPath        string    `json:"private_key_path"`
 	PrivateKeyPEM         string    `json:"-"`
+	KeyType               string    `json:"key_type,omitempty"`
 	IsDefault             bool      `json:"is_default"`
 	Source                string    `json:"source,omitempty"`
 	SourcePath            string    `json:"source_path,omitempty"`
@@ -83,11 +84,13 @@ type credentialPayload struct {
 	IssuerID       string `json:"issuer_id"`
 	PrivateKeyPath string `json:"private_key_path"`
 	PrivateKeyPEM  string `json:"private_key_pem,omitempty"`
+	KeyType        string `json:"key_type,omitempty"`
 }
 
 type credentialMetadata struct {
 	KeyID    string `json:"key_id,omitempty"`
 	IssuerID string `json:"issuer_id,omitempty"`
+	KeyType  string `json:"key_type,omitempty"`
 }
 
 func keyringConfig(keychainName string) keyring.Config {
@@ -277,6 +280,7 @@ func StoreCredentials(name, keyID, issuerID, keyPath string) error {
 		KeyID:          keyID,
 		IssuerID:       issuerID,
 		PrivateKeyPath: keyPath,
+		KeyType:        config.KeyTypeForIssuer(issuerID),
 	}
 	if privateKeyPEM, err := loadPrivateKeyPEMForStorage(keyPath); err == nil && strings.TrimSpace(privateKeyPEM) != "" {
 		payload.PrivateKeyPEM = privateKeyPEM
@@ -314,6 +318,7 @@ func StoreCredentialsConfig(name, keyID, issuerID, keyPath string) error {
 		KeyID:          keyID,
 		IssuerID:       issuerID,
 		PrivateKeyPath: keyPath,
+		KeyType:        config.KeyTypeForIssuer(issuerID),
 	}
 	path, err := config.GlobalPath()
 	if err != nil {
@@ -328,6 +333,7 @@ func StoreCredentialsConfigAt(name, keyID, issuerID, keyPath, configPath string)
 		KeyID:          keyID,
 		IssuerID:       issuerID,
 		PrivateKeyPath: keyPath,
+		KeyType:        config.KeyTypeForIssuer(issuerID),
 	}
 	return storeInConfigAt(name, payload, configPath)
 }
@@ -355,6 +361,7 @@ func clearConfigCredentialsAt(path string) error {
 	cfg.KeyID = ""
 	cfg.IssuerID = ""
 	cfg.PrivateKeyPath = ""
+	cfg.KeyType = ""
 	cfg.DefaultKeyName = ""
 	cfg.Keys = nil
 	cfg.KeychainMetadata = nil
@@ -696,6 +703,7 @@ func configFromCredential(cred Credential) *config.Config {
 		IssuerID:       cred.IssuerID,
 		PrivateKeyPath: cred.PrivateKeyPath,
 		PrivateKeyPEM:  cred.PrivateKeyPEM,
+		KeyType:        cred.KeyType,
 		DefaultKeyName: cred.Name,
 	}
 }
@@ -748,6 +756,7 @@ func credentialMetadataDescription(payload credentialPayload) string {
 	data, err := json.Marshal(credentialMetadata{
 		KeyID:    strings.TrimSpace(payload.KeyID),
 		IssuerID: strings.TrimSpace(payload.IssuerID),
+		KeyType:  strings.TrimSpace(payload.KeyType),
 	})
 	if err != nil {
 		return ""
@@ -1013,6 +1022,7 @@ func listCredentialSummariesFromKeyring(kr keyring.Keyring) ([]Credential, error
 			Name:      name,
 			KeyID:     summary.KeyID,
 			IssuerID:  summary.IssuerID,
+			KeyType:   summary.KeyType,
 			IsDefault: name == defaultName,
 			Source:    "keychain",
 		})
@@ -1079,6 +1089,7 @@ func listFromKeyring(kr keyring.Keyring) ([]Credential, error) {
 			IssuerID:              payload.IssuerID,
 			PrivateKeyPath:        payload.PrivateKeyPath,
 			PrivateKeyPEM:         payload.PrivateKeyPEM,
+			KeyType:               payload.KeyType,
 			IsDefault:             name == defaultName,
 			Source:                "keychain",
 			MetadataNeedsBackfill: metadataNeedsBackfill,
@@ -1096,6 +1107,7 @@ func migrateLegacyCredentials(credentials []Credential) {
 			IssuerID:       cred.IssuerID,
 			PrivateKeyPath: cred.PrivateKeyPath,
 			PrivateKeyPEM:  cred.PrivateKeyPEM,
+			KeyType:        cred.KeyType,
 		}
 		if err := storeInKeychain(cred.Name, payload); err != nil {
 			continue
@@ -1214,6 +1226,7 @@ func storeInConfigAt(name string, payload credentialPayload, configPath string)
 			cfg.Keys[i].KeyID = payload.KeyID
 			cfg.Keys[i].IssuerID = payload.IssuerID
 			cfg.Keys[i].PrivateKeyPath = payload.PrivateKeyPath
+			cfg.Keys[i].KeyType = payload.KeyType
 			updated = true
 			break
 		}
@@ -1224,12 +1237,14 @@ func storeInConfigAt(name string, payload credentialPayload, configPath string)
 			KeyID:          payload.KeyID,
 			IssuerID:       payload.IssuerID,
 			PrivateKeyPath: payload.PrivateKeyPath,
+			KeyType:        payload.KeyType,
 		})
 	}
 
 	cfg.KeyID = payload.KeyID
 	cfg.IssuerID = payload.IssuerID
 	cfg.PrivateKeyPath = payload.PrivateKeyPath
+	cfg.KeyType = payload.KeyType
 	cfg.DefaultKeyName = name
 	return config.SaveAt(configPath, cfg)
 }
@@ -1258,16 +1273,17 @@ func hasAnyCredentials(cfg *config.Config) bool {
 	return false
 }
 
+// isCompleteConfigCredential requires an issuer ID except for Individual keys.
 func isCompleteConfigCredential(cred config.Credential) bool {
 	return strings.TrimSpace(cred.KeyID) != "" &&
-		strings.TrimSpace(cred.IssuerID) != "" &&
+		(strings.TrimSpace(cred.IssuerID) != "" || cred.IsIndividual()) &&
 		strings.TrimSpace(cred.PrivateKeyPath) != ""
 }
 
 func hasLegacyCredentials(cfg *config.Config) bool {
 	return cfg != nil &&
 		strings.TrimSpace(cfg.KeyID) != "" &&
-		strings.TrimSpace(cfg.IssuerID) != "" &&
+		(strings.TrimSpace(cfg.IssuerID) != "" || strings.TrimSpace(cfg.KeyType) == config.KeyTypeIndividual) &&
 		strings.TrimSpace(cfg.PrivateKeyPath) != ""
 }
 
@@ -1301,6 +1317,7 @@ func configCredentialList(cfg *config.Config) []config.Credential {
 				KeyID:          cfg.KeyID,
 				IssuerID:       cfg.IssuerID,
 				PrivateKeyPath: cfg.PrivateKeyPath,
+				KeyType:        cfg.KeyType,
 			})
 		}
 	}
@@ -1335,6 +1352,7 @@ func findConfigCredential(cfg *config.Config, name string) (config.Credential, b
 			KeyID:          cfg.KeyID,
 			IssuerID:       cfg.IssuerID,
 			PrivateKeyPath: cfg.PrivateKeyPath,
+			KeyType:        cfg.KeyType,
 		}
 		return cred, true, isCompleteConfigCredential(cred)
 	}
@@ -1347,6 +1365,7 @@ func applyConfigCredential(cfg *config.Config, cred config.Credential) *config.C
 			KeyID:          cred.KeyID,
 			IssuerID:       cred.IssuerID,
 			PrivateKeyPath: cred.PrivateKeyPath,
+			KeyType:        cred.KeyType,
 			DefaultKeyName: strings.TrimSpace(cred.Name),
 		}
 	}
@@ -1354,6 +1373,7 @@ func applyConfigCredential(cfg *config.Config, cred config.Credential) *config.C
 	copied.KeyID = cred.KeyID
 	copied.IssuerID = cred.IssuerID
 	copied.PrivateKeyPath = cred.PrivateKeyPath
+	copied.KeyType = cred.KeyType
 	if strings.TrimSpace(cred.Name) != "" {
 		copied.DefaultKeyName = strings.TrimSpace(cred.Name)
 	}
@@ -1458,6 +1478,7 @@ func listFromConfig() ([]Credential, error) {
 			KeyID:          cred.KeyID,
 			IssuerID:       cred.IssuerID,
 			PrivateKeyPath: cred.PrivateKeyPath,
+			KeyType:        cred.KeyType,
 			IsDefault:      cred.Name == defaultName,
 			Source:         "config",
 			SourcePath:     path,
@@ -1490,6 +1511,7 @@ func saveDefaultName(name string) error {
 				cfg.KeyID = cred.KeyID
 				cfg.IssuerID = cred.IssuerID
 				cfg.PrivateKeyPath = cred.PrivateKeyPath
+				cfg.KeyType = cred.KeyType
 				return config.Save(cfg)
 			}
 		}
@@ -1533,6 +1555,7 @@ func removeFromConfigAt(name, path string) error {
 		cfg.KeyID = ""
 		cfg.IssuerID = ""
 		cfg.PrivateKeyPath = ""
+		cfg.KeyType = ""
 		cfg.DefaultKeyName = ""
 		cfg.Keys = nil
 		cfg.KeychainMetadata = nil
@@ -1567,6 +1590,7 @@ func removeFromConfigAt(name, path string) error {
 		cfg.KeyID = ""
 		cfg.IssuerID = ""
 		cfg.PrivateKeyPath = ""
+		cfg.KeyType = ""
 		cfg.DefaultKeyName = ""
 		removed = true
 	}
@@ -163,6 +163,44 @@ func TestConfigProfileSelection(t *testing.T) {
 	}
 }
 
+func TestStoreCredentialsConfigAtRecordsIndividualKeyType(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+	t.Setenv("ASC_CONFIG_PATH", configPath)
+	t.Setenv("ASC_BYPASS_KEYCHAIN", "1")
+
+	if err := StoreCredentialsConfigAt("team", "KEY1", "ISSUER1", "/tmp/AuthKey1.p8", configPath); err != nil {
+		t.Fatalf("StoreCredentialsConfigAt(team) error: %v", err)
+	}
+	if err := StoreCredentialsConfigAt("me", "KEY2", "", "/tmp/AuthKey2.p8", configPath); err != nil {
+		t.Fatalf("StoreCredentialsConfigAt(me) error: %v", err)
+	}
+
+	cfg, err := config.LoadAt(configPath)
+	if err != nil {
+		t.Fatalf("LoadAt() error: %v", err)
+	}
+	if len(cfg.Keys) != 2 || cfg.Keys[0].KeyType != config.KeyTypeTeam || cfg.Keys[1].KeyType != config.KeyTypeIndividual {
+		t.Fatalf("unexpected stored keys: %+v", cfg.Keys)
+	}
+
+	individual, err := GetCredentials("me")
+	if err != nil {
+		t.Fatalf("GetCredentials(me) error: %v", err)
+	}
+	if individual.KeyID != "KEY2" || individual.IssuerID != "" || individual.KeyType != config.KeyTypeIndividual {
+		t.Fatalf("unexpected individual credentials: %+v", individual)
+	}
+
+	credentials, err := ListCredentials()
+	if err != nil {
+		t.Fatalf("ListCredentials() error: %v", err)
+	}
+	if len(credentials) != 2 {
+		t.Fatalf("expected individual key to be listed, got %+v", credentials)
+	}
+}
+
 func TestKeychainAvailableBypassSkipsKeyring(t *testing.T) {
 	t.Setenv("ASC_BYPASS_KEYCHAIN", "1")
 
@@ -29,11 +29,13 @@ func AccountCommand() *ffcli.Command {
 Examples:
   asc account status
   asc account status --app "123456789"
-  asc account status --output table`,
+  asc account status --output table
+  asc account rate-limit`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
 			accountStatusCommand(),
+			accountRateLimitCommand(),
 		},
 		Exec: func(_ context.Context, _ []string) error {
 			return flag.ErrHelp
@@ -86,6 +88,102 @@ Examples:
 	}
 }
 
+func accountRateLimitCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("account rate-limit", flag.ExitOnError)
+
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "rate-limit",
+		ShortUsage: "asc account rate-limit [flags]",
+		ShortHelp:  "Show the remaining hourly API request budget for the current key.",
+		LongHelp: `Show the remaining hourly API request budget for the current key.
+
+App Store Connect reports an hourly request quota per API key in the
+X-Rate-Limit response header. This command performs one minimal read request
+and reports the quota it returns. asc uses the same header to slow down
+automatically before the budget runs out.
+
+Examples:
+  asc account rate-limit
+  asc account rate-limit --profile "ci"
+  asc account rate-limit --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) > 0 {
+				return shared.UsageErrorf("unexpected argument(s): %s", strings.Join(args, " "))
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("account rate-limit: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			status, err := client.ProbeRateLimit(requestCtx)
+			if err != nil {
+				return fmt.Errorf("account rate-limit: %w", err)
+			}
+
+			resp := newAccountRateLimitResponse(status)
+			if creds, err := shared.ResolveAuthCredentialsMetadata(shared.ResolveProfileName()); err == nil {
+				resp.KeyID = creds.KeyID
+				resp.Profile = creds.Profile
+			}
+
+			return shared.PrintOutputWithRenderers(
+				resp,
+				*output.Output,
+				*output.Pretty,
+				func() error { renderAccountRateLimit(resp, false); return nil },
+				func() error { renderAccountRateLimit(resp, true); return nil },
+			)
+		},
+	}
+}
+
+type accountRateLimitResponse struct {
+	KeyID            string  `json:"keyId,omitempty"`
+	Profile          string  `json:"profile,omitempty"`
+	Limit            int     `json:"limit"`
+	Remaining        int     `json:"remaining"`
+	Used             int     `json:"used"`
+	PercentRemaining float64 `json:"percentRemaining"`
+	Window           string  `json:"window"`
+	ObservedAt       string  `json:"observedAt"`
+}
+
+func newAccountRateLimitResponse(status asc.RateLimitStatus) *accountRateLimitResponse {
+	resp := &accountRateLimitResponse{
+		Limit:      status.Limit,
+		Remaining:  status.Remaining,
+		Used:       max(status.Limit-status.Remaining, 0),
+		Window:     "1h",
+		ObservedAt: status.ObservedAt.UTC().Format(time.RFC3339),
+	}
+	if status.Limit > 0 {
+		resp.PercentRemaining = float64(int(float64(status.Remaining)/float64(status.Limit)*1000+0.5)) / 10
+	}
+	return resp
+}
+
+func renderAccountRateLimit(resp *accountRateLimitResponse, markdown bool) {
+	rows := [][]string{
+		{"keyId", resp.KeyID},
+		{"profile", resp.Profile},
+		{"limit", strconv.Itoa(resp.Limit)},
+		{"remaining", strconv.Itoa(resp.Remaining)},
+		{"used", strconv.Itoa(resp.Used)},
+		{"percentRemaining", strconv.FormatFloat(resp.PercentRemaining, 'f', 1, 64) + "%"},
+		{"window", resp.Window},
+		{"observedAt", resp.ObservedAt},
+	}
+	shared.RenderSection("Rate Limit", []string{"field", "value"}, rows, markdown)
+}
+
 type accountStatusResponse struct {
 	Summary     accountSummary `json:"summary"`
 	Checks      []accountCheck `json:"checks"`
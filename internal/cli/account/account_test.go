@@ -1,6 +1,11 @@
 package account
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
 
 func TestSummarizeAccountChecks(t *testing.T) {
 	red := summarizeAccountChecks([]accountCheck{
@@ -39,3 +44,25 @@ func TestSummarizeAccountChecks(t *testing.T) {
 		t.Fatalf("unexpected next action %q", green.NextAction)
 	}
 }
+
+func TestNewAccountRateLimitResponse(t *testing.T) {
+	resp := newAccountRateLimitResponse(asc.RateLimitStatus{
+		Limit:      3600,
+		Remaining:  3545,
+		ObservedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	if resp.Used != 55 {
+		t.Fatalf("expected 55 used, got %d", resp.Used)
+	}
+	if resp.PercentRemaining != 98.5 {
+		t.Fatalf("expected 98.5 percent remaining, got %v", resp.PercentRemaining)
+	}
+	if resp.ObservedAt != "2026-01-02T03:04:05Z" {
+		t.Fatalf("unexpected observedAt %q", resp.ObservedAt)
+	}
+
+	empty := newAccountRateLimitResponse(asc.RateLimitStatus{})
+	if empty.PercentRemaining != 0 || empty.Used != 0 {
+		t.Fatalf("expected zero values for empty status, got %+v", empty)
+	}
+}
@@ -102,7 +102,7 @@ Examples:
 					return fmt.Errorf("actors list: failed to fetch: %w", err)
 				}
 
-				actors, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				actors, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetActors(ctx, asc.WithActorsNextURL(nextURL))
 				})
 				if err != nil {
@@ -163,7 +163,7 @@ Examples:
 
 				if *paginate {
 					paginateOpts := append(opts, asc.WithAnalyticsReportRequestsLimit(200))
-					paginated, err := shared.PaginateWithSpinner(requestCtx,
+					paginated, err := shared.PaginateForOutput(requestCtx, *output.Output,
 						func(ctx context.Context) (asc.PaginatedResponse, error) {
 							return client.GetAnalyticsReportRequests(ctx, resolvedAppID, paginateOpts...)
 						},
@@ -0,0 +1,319 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/schema"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// APICommand returns the raw API request command.
+func APICommand() *ffcli.Command {
+	fs := flag.NewFlagSet("api", flag.ExitOnError)
+
+	var (
+		params  shared.MultiStringFlag
+		queries shared.MultiStringFlag
+	)
+	fs.Var(&params, "param", "Path parameter as name=value for a {name} placeholder (repeatable)")
+	fs.Var(&queries, "query", "Query parameter as name=value (repeatable)")
+	body := fs.String("body", "", "Request body: inline JSON, @file.json, or @- for stdin")
+	paginate := fs.Bool("paginate", false, "Follow links.next and merge all pages (GET only)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "api",
+		ShortUsage: "asc api <METHOD> <PATH> [flags]",
+		ShortHelp:  "Send a raw App Store Connect API request validated against the schema.",
+		LongHelp: `Send a raw App Store Connect API request validated against the schema.
+
+Use this for endpoints that have no dedicated command yet. Requests go
+through the regular authenticated client (JWT, retries, rate limiting), and
+before anything is sent the method, path parameters, query parameter names
+and values, and request body attributes are checked against the bundled
+schema index (see asc schema).
+
+Path parameters can be written inline (/v1/apps/123) or as placeholders
+filled with --param (/v1/apps/{id} --param id=123).
+
+Examples:
+  asc api GET /v1/apps --query limit=5
+  asc api GET /v1/apps/{id}/appStoreVersions --param id=123456789 --query filter[platform]=IOS --paginate
+  asc api PATCH /v1/appStoreVersions/VERSION_ID --body @version.json
+  asc api DELETE /v1/betaGroups/GROUP_ID/relationships/builds --body '{"data":[{"type":"builds","id":"BUILD_ID"}]}'
+  asc api GET /v1/apps/123456789 --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) < 2 {
+				return shared.UsageError("method and path are required")
+			}
+			method, path := args[0], args[1]
+			// Allow flags after the positional arguments.
+			if err := shared.ParseTrailingFlags(fs, args[2:]); err != nil {
+				return err
+			}
+
+			payload, err := readBody(*body)
+			if err != nil {
+				return err
+			}
+
+			endpoints, err := schema.LoadEndpoints()
+			if err != nil {
+				return fmt.Errorf("api: %w", err)
+			}
+			request, err := buildRawRequest(endpoints, method, path, params, queries, payload)
+			if err != nil {
+				return err
+			}
+			if *paginate && request.Method != http.MethodGet {
+				return shared.UsageError("--paginate is only supported for GET requests")
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("api: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			var result json.RawMessage
+			if *paginate {
+				result, err = fetchAllPages(requestCtx, client, request.Path)
+			} else {
+				result, err = send(requestCtx, client, request)
+			}
+			if err != nil {
+				return fmt.Errorf("api: %w", err)
+			}
+
+			return shared.PrintOutputWithRenderers(
+				result,
+				*output.Output,
+				*output.Pretty,
+				func() error { return renderDocument(result, false) },
+				func() error { return renderDocument(result, true) },
+			)
+		},
+	}
+}
+
+func readBody(value string) (json.RawMessage, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return nil, nil
+	case value == "@-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("read --body from stdin: %w", err)
+		}
+		return compactBody(data)
+	case strings.HasPrefix(value, "@"):
+		payload, err := shared.ReadJSONFilePayload(strings.TrimPrefix(value, "@"))
+		if err != nil {
+			return nil, shared.UsageErrorf("--body: %v", err)
+		}
+		return compactBody(payload)
+	default:
+		return compactBody([]byte(value))
+	}
+}
+
+func compactBody(data []byte) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil, shared.UsageErrorf("--body must be valid JSON: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func send(ctx context.Context, client *asc.Client, request *rawRequest) (json.RawMessage, error) {
+	var reader io.Reader
+	if len(request.Body) > 0 {
+		reader = bytes.NewReader(request.Body)
+	}
+	data, err := client.RawRequest(ctx, request.Method, request.Path, reader)
+	if err != nil {
+		return nil, err
+	}
+	return responseDocument(data)
+}
+
+func responseDocument(data []byte) (json.RawMessage, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return json.RawMessage(`{}`), nil
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("response is not JSON")
+	}
+	return data, nil
+}
+
+type pagedDocument struct {
+	Data     []json.RawMessage `json:"data"`
+	Included []json.RawMessage `json:"included,omitempty"`
+	Links    struct {
+		Next string `json:"next,omitempty"`
+	} `json:"links"`
+	Meta json.RawMessage `json:"meta,omitempty"`
+}
+
+// fetchAllPages follows links.next and merges data and included resources.
+func fetchAllPages(ctx context.Context, client *asc.Client, path string) (json.RawMessage, error) {
+	merged := struct {
+		Data     []json.RawMessage `json:"data"`
+		Included []json.RawMessage `json:"included,omitempty"`
+		Meta     json.RawMessage   `json:"meta,omitempty"`
+	}{Data: []json.RawMessage{}}
+	seenIncluded := map[string]bool{}
+	seenNext := map[string]bool{}
+
+	next := path
+	for next != "" {
+		data, err := client.RawRequest(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return nil, err
+		}
+		var page pagedDocument
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("--paginate requires a collection response: %w", err)
+		}
+		merged.Data = append(merged.Data, page.Data...)
+		for _, resource := range page.Included {
+			key := resourceKey(resource)
+			if key != "" && seenIncluded[key] {
+				continue
+			}
+			seenIncluded[key] = true
+			merged.Included = append(merged.Included, resource)
+		}
+		merged.Meta = page.Meta
+
+		next = page.Links.Next
+		if seenNext[next] {
+			return nil, fmt.Errorf("pagination loop detected at %s", next)
+		}
+		seenNext[next] = true
+	}
+
+	return json.Marshal(merged)
+}
+
+func resourceKey(raw json.RawMessage) string {
+	var resource struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	}
+	if err := json.Unmarshal(raw, &resource); err != nil || resource.ID == "" {
+		return ""
+	}
+	return resource.Type + "/" + resource.ID
+}
+
+type tableResource struct {
+	Type       string         `json:"type"`
+	ID         string         `json:"id"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+// renderDocument renders a JSON:API document as a table: one row per
+// resource for collections, or field/value rows for a single resource.
+func renderDocument(document json.RawMessage, markdown bool) error {
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(document, &envelope); err != nil {
+		return fmt.Errorf("render: %w", err)
+	}
+	trimmed := bytes.TrimSpace(envelope.Data)
+
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+
+	switch {
+	case len(trimmed) > 0 && trimmed[0] == '[':
+		var resources []tableResource
+		if err := json.Unmarshal(trimmed, &resources); err != nil {
+			return fmt.Errorf("render: %w", err)
+		}
+		attributeNames := map[string]bool{}
+		for _, resource := range resources {
+			for name := range resource.Attributes {
+				attributeNames[name] = true
+			}
+		}
+		names := sortedKeys(attributeNames)
+		headers := append([]string{"type", "id"}, names...)
+		rows := make([][]string, 0, len(resources))
+		for _, resource := range resources {
+			row := []string{resource.Type, resource.ID}
+			for _, name := range names {
+				row = append(row, formatCell(resource.Attributes[name]))
+			}
+			rows = append(rows, row)
+		}
+		render(headers, rows)
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		var resource tableResource
+		if err := json.Unmarshal(trimmed, &resource); err != nil {
+			return fmt.Errorf("render: %w", err)
+		}
+		rows := [][]string{{"type", resource.Type}, {"id", resource.ID}}
+		for _, name := range sortedKeys(resource.Attributes) {
+			rows = append(rows, []string{name, formatCell(resource.Attributes[name])})
+		}
+		render([]string{"field", "value"}, rows)
+	default:
+		var fields map[string]any
+		if err := json.Unmarshal(document, &fields); err != nil {
+			return fmt.Errorf("render: %w", err)
+		}
+		rows := make([][]string, 0, len(fields))
+		for _, name := range sortedKeys(fields) {
+			rows = append(rows, []string{name, formatCell(fields[name])})
+		}
+		render([]string{"field", "value"}, rows)
+	}
+	return nil
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatCell(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	default:
+		data, err := json.Marshal(typed)
+		if err != nil {
+			return fmt.Sprint(typed)
+		}
+		return string(data)
+	}
+}
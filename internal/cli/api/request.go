@@ -0,0 +1,392 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/schema"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// rawRequest is a validated request ready to send.
+type rawRequest struct {
+	Method   string
+	Path     string
+	Body     json.RawMessage
+	Endpoint schema.Endpoint
+}
+
+// buildRawRequest resolves method and path against the schema index, fills
+// path parameters, and validates query parameters and the request body.
+func buildRawRequest(endpoints []schema.Endpoint, method, rawPath string, pathParams, queryParams []string, body json.RawMessage) (*rawRequest, error) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	switch method {
+	case "GET", "POST", "PATCH", "DELETE":
+	default:
+		return nil, shared.UsageErrorf("unsupported method %q (allowed: GET, POST, PATCH, DELETE)", method)
+	}
+
+	rawPath = strings.TrimSpace(rawPath)
+	pathOnly, embeddedQuery, _ := strings.Cut(rawPath, "?")
+	if !strings.HasPrefix(pathOnly, "/v") {
+		return nil, shared.UsageErrorf("path must be an API path such as /v1/apps, got %q", rawPath)
+	}
+	segments := strings.Split(strings.Trim(pathOnly, "/"), "/")
+
+	endpoint, err := matchEndpoint(endpoints, method, segments)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := parseKeyValues("--param", pathParams)
+	if err != nil {
+		return nil, err
+	}
+	resolvedPath, err := fillPathParams(endpoint, segments, params)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := url.ParseQuery(embeddedQuery)
+	if err != nil {
+		return nil, shared.UsageErrorf("invalid query string in path: %v", err)
+	}
+	extra, err := parseKeyValues("--query", queryParams)
+	if err != nil {
+		return nil, err
+	}
+	for _, pair := range extra {
+		query.Add(pair[0], pair[1])
+	}
+	if err := validateQuery(endpoint, query); err != nil {
+		return nil, err
+	}
+	if encoded := query.Encode(); encoded != "" {
+		resolvedPath += "?" + encoded
+	}
+
+	if err := validateBody(endpoint, body); err != nil {
+		return nil, err
+	}
+
+	return &rawRequest{
+		Method:   method,
+		Path:     resolvedPath,
+		Body:     body,
+		Endpoint: endpoint,
+	}, nil
+}
+
+func isPlaceholder(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && len(segment) > 2
+}
+
+// matchEndpoint finds the schema endpoint for method and path segments,
+// preferring templates with the most literal segment matches.
+func matchEndpoint(endpoints []schema.Endpoint, method string, segments []string) (schema.Endpoint, error) {
+	var (
+		best         schema.Endpoint
+		bestLiterals = -1
+		otherMethods []string
+	)
+	for _, endpoint := range endpoints {
+		template := strings.Split(strings.Trim(endpoint.Path, "/"), "/")
+		literals, ok := matchSegments(template, segments)
+		if !ok {
+			continue
+		}
+		if endpoint.Method != method {
+			otherMethods = append(otherMethods, endpoint.Method)
+			continue
+		}
+		if literals > bestLiterals {
+			best = endpoint
+			bestLiterals = literals
+		}
+	}
+
+	path := "/" + strings.Join(segments, "/")
+	if bestLiterals >= 0 {
+		return best, nil
+	}
+	if len(otherMethods) > 0 {
+		slices.Sort(otherMethods)
+		otherMethods = slices.Compact(otherMethods)
+		return schema.Endpoint{}, shared.UsageErrorf("%s is not supported for %s (allowed: %s)", method, path, strings.Join(otherMethods, ", "))
+	}
+	return schema.Endpoint{}, shared.UsageErrorf("unknown endpoint %s %s (see: asc schema --list)", method, path)
+}
+
+func matchSegments(template, segments []string) (int, bool) {
+	if len(template) != len(segments) {
+		return 0, false
+	}
+	literals := 0
+	for i, part := range template {
+		if isPlaceholder(part) {
+			if segments[i] == "" {
+				return 0, false
+			}
+			continue
+		}
+		if part != segments[i] {
+			return 0, false
+		}
+		literals++
+	}
+	return literals, true
+}
+
+func parseKeyValues(flagName string, values []string) ([][2]string, error) {
+	pairs := make([][2]string, 0, len(values))
+	for _, value := range values {
+		name, val, ok := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, shared.UsageErrorf("%s must be name=value, got %q", flagName, value)
+		}
+		pairs = append(pairs, [2]string{name, val})
+	}
+	return pairs, nil
+}
+
+// fillPathParams substitutes {name} placeholders from --param values and
+// validates every path parameter value.
+func fillPathParams(endpoint schema.Endpoint, segments []string, params [][2]string) (string, error) {
+	values := map[string]string{}
+	for _, pair := range params {
+		values[pair[0]] = pair[1]
+	}
+
+	template := strings.Split(strings.Trim(endpoint.Path, "/"), "/")
+	used := map[string]bool{}
+	resolved := make([]string, len(segments))
+	for i, segment := range segments {
+		if !isPlaceholder(template[i]) {
+			resolved[i] = segment
+			continue
+		}
+		name := strings.Trim(template[i], "{}")
+		if isPlaceholder(segment) {
+			name = strings.Trim(segment, "{}")
+			value, ok := values[name]
+			if !ok {
+				return "", shared.UsageErrorf("path parameter {%s} is not set (use --param %s=VALUE)", name, name)
+			}
+			used[name] = true
+			segment = value
+		}
+		if err := validatePathParamValue(name, segment); err != nil {
+			return "", err
+		}
+		resolved[i] = segment
+	}
+
+	for _, pair := range params {
+		if !used[pair[0]] {
+			return "", shared.UsageErrorf("--param %s does not match a {%s} placeholder in the path", pair[0], pair[0])
+		}
+	}
+	return "/" + strings.Join(resolved, "/"), nil
+}
+
+func validatePathParamValue(name, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return shared.UsageErrorf("path parameter {%s} cannot be empty", name)
+	}
+	for _, r := range value {
+		if r <= 0x20 || r == 0x7f || strings.ContainsRune("/?#%\\", r) {
+			return shared.UsageErrorf("path parameter {%s} contains an invalid character: %q", name, value)
+		}
+	}
+	if value == "." || value == ".." {
+		return shared.UsageErrorf("path parameter {%s} cannot be %q", name, value)
+	}
+	return nil
+}
+
+// validateQuery checks query names, enum values and required parameters.
+func validateQuery(endpoint schema.Endpoint, query url.Values) error {
+	known := map[string]schema.Parameter{}
+	for _, param := range endpoint.Parameters {
+		if param.In == "query" {
+			known[param.Name] = param
+		}
+	}
+
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		// cursor comes from links.next and is never listed in the schema.
+		if name == "cursor" {
+			continue
+		}
+		param, ok := known[name]
+		if !ok {
+			allowed := make([]string, 0, len(known))
+			for candidate := range known {
+				allowed = append(allowed, candidate)
+			}
+			sort.Strings(allowed)
+			if len(allowed) == 0 {
+				return shared.UsageErrorf("%s %s does not accept query parameters (got %q)", endpoint.Method, endpoint.Path, name)
+			}
+			return shared.UsageErrorf("unknown query parameter %q for %s %s (allowed: %s)", name, endpoint.Method, endpoint.Path, strings.Join(allowed, ", "))
+		}
+		for _, value := range query[name] {
+			if err := validateQueryValue(param, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, param := range endpoint.Parameters {
+		if param.In == "query" && param.Required && !query.Has(param.Name) {
+			return shared.UsageErrorf("query parameter %q is required for %s %s", param.Name, endpoint.Method, endpoint.Path)
+		}
+	}
+	return nil
+}
+
+func validateQueryValue(param schema.Parameter, value string) error {
+	if param.Name == "limit" || strings.HasPrefix(param.Name, "limit[") {
+		if limit, err := strconv.Atoi(value); err != nil || limit < 1 {
+			return shared.UsageErrorf("query parameter %q must be a positive integer, got %q", param.Name, value)
+		}
+		return nil
+	}
+	if len(param.Enum) == 0 {
+		return nil
+	}
+	for item := range strings.SplitSeq(value, ",") {
+		item = strings.TrimSpace(item)
+		if !slices.Contains(param.Enum, item) {
+			return shared.UsageErrorf("invalid value %q for query parameter %q (allowed: %s)", item, param.Name, strings.Join(param.Enum, ", "))
+		}
+	}
+	return nil
+}
+
+// validateBody checks that a body is present exactly when the endpoint takes
+// one, and that data.attributes match the schema's request attributes.
+func validateBody(endpoint schema.Endpoint, body json.RawMessage) error {
+	if len(body) == 0 {
+		if endpoint.RequestSchema != "" {
+			return shared.UsageErrorf("--body is required for %s %s (request schema %s)", endpoint.Method, endpoint.Path, endpoint.RequestSchema)
+		}
+		return nil
+	}
+	if endpoint.RequestSchema == "" {
+		return shared.UsageErrorf("%s %s does not accept a request body", endpoint.Method, endpoint.Path)
+	}
+
+	var document struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return shared.UsageErrorf("--body must be a JSON object: %v", err)
+	}
+	if len(document.Data) == 0 {
+		return shared.UsageErrorf("--body must contain a top-level \"data\" member (request schema %s)", endpoint.RequestSchema)
+	}
+	if len(endpoint.RequestAttributes) == 0 {
+		return nil
+	}
+
+	var resource struct {
+		Attributes map[string]json.RawMessage `json:"attributes"`
+	}
+	if err := json.Unmarshal(document.Data, &resource); err != nil {
+		// Linkage arrays carry no attributes to validate.
+		return nil
+	}
+
+	names := make([]string, 0, len(resource.Attributes))
+	for name := range resource.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		spec, ok := endpoint.RequestAttributes[name]
+		if !ok {
+			allowed := make([]string, 0, len(endpoint.RequestAttributes))
+			for candidate := range endpoint.RequestAttributes {
+				allowed = append(allowed, candidate)
+			}
+			sort.Strings(allowed)
+			return shared.UsageErrorf("unknown attribute %q for %s (allowed: %s)", name, endpoint.RequestSchema, strings.Join(allowed, ", "))
+		}
+		if err := validateAttributeValue(name, spec, resource.Attributes[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateAttributeValue(name string, spec any, raw json.RawMessage) error {
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return shared.UsageErrorf("attribute %q: %v", name, err)
+	}
+	if value == nil {
+		return nil
+	}
+
+	switch typed := spec.(type) {
+	case string:
+		if !attributeMatchesType(typed, value) {
+			return shared.UsageErrorf("attribute %q must be of type %s", name, typed)
+		}
+	case map[string]any:
+		enum, _ := typed["enum"].([]any)
+		if len(enum) == 0 {
+			return nil
+		}
+		if !slices.Contains(enum, value) {
+			allowed := make([]string, 0, len(enum))
+			for _, candidate := range enum {
+				allowed = append(allowed, fmt.Sprint(candidate))
+			}
+			return shared.UsageErrorf("invalid value %v for attribute %q (allowed: %s)", value, name, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// attributeMatchesType checks primitive schema types; named schema types
+// (enums and objects defined elsewhere in the spec) are not checked.
+func attributeMatchesType(kind string, value any) bool {
+	switch kind {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	default:
+		return true
+	}
+}
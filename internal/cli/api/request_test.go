@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/schema"
+)
+
+var testEndpoints = []schema.Endpoint{
+	{Method: "GET", Path: "/v1/apps/{id}", Parameters: []schema.Parameter{{Name: "id", In: "path", Required: true}}},
+	{
+		Method: "GET",
+		Path:   "/v1/apps/{id}/appStoreVersions",
+		Parameters: []schema.Parameter{
+			{Name: "id", In: "path", Required: true},
+			{Name: "filter[platform]", In: "query", Enum: []string{"IOS", "MAC_OS"}},
+			{Name: "limit", In: "query"},
+		},
+	},
+	{Method: "GET", Path: "/v1/financeReports", Parameters: []schema.Parameter{{Name: "filter[vendorNumber]", In: "query", Required: true}}},
+	{
+		Method:        "PATCH",
+		Path:          "/v1/appStoreVersions/{id}",
+		Parameters:    []schema.Parameter{{Name: "id", In: "path", Required: true}},
+		RequestSchema: "AppStoreVersionUpdateRequest",
+		RequestAttributes: map[string]any{
+			"versionString": "string",
+			"downloadable":  "boolean",
+			"releaseType":   map[string]any{"type": "string", "enum": []any{"MANUAL", "AFTER_APPROVAL"}},
+		},
+	},
+	{Method: "DELETE", Path: "/v1/betaGroups/{id}/relationships/builds", RequestSchema: "BetaGroupBuildsLinkagesRequest"},
+}
+
+func TestBuildRawRequest_ResolvesPathAndQuery(t *testing.T) {
+	request, err := buildRawRequest(testEndpoints, "get", "/v1/apps/{id}/appStoreVersions?limit=5", []string{"id=123"}, []string{"filter[platform]=IOS,MAC_OS"}, nil)
+	if err != nil {
+		t.Fatalf("buildRawRequest() error: %v", err)
+	}
+	if request.Method != "GET" {
+		t.Fatalf("method = %q, want GET", request.Method)
+	}
+	want := "/v1/apps/123/appStoreVersions?filter%5Bplatform%5D=IOS%2CMAC_OS&limit=5"
+	if request.Path != want {
+		t.Fatalf("path = %q, want %q", request.Path, want)
+	}
+}
+
+func TestBuildRawRequest_ValidatesBody(t *testing.T) {
+	valid := json.RawMessage(`{"data":{"type":"appStoreVersions","id":"v1","attributes":{"versionString":"2.0","releaseType":"MANUAL","downloadable":null}}}`)
+	if _, err := buildRawRequest(testEndpoints, "PATCH", "/v1/appStoreVersions/v1", nil, nil, valid); err != nil {
+		t.Fatalf("buildRawRequest() error: %v", err)
+	}
+	linkages := json.RawMessage(`{"data":[{"type":"builds","id":"b1"}]}`)
+	if _, err := buildRawRequest(testEndpoints, "DELETE", "/v1/betaGroups/g1/relationships/builds", nil, nil, linkages); err != nil {
+		t.Fatalf("buildRawRequest() linkage body error: %v", err)
+	}
+}
+
+func TestBuildRawRequest_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		params  []string
+		queries []string
+		body    string
+		want    string
+	}{
+		{name: "unknown endpoint", method: "GET", path: "/v1/nothing", want: "unknown endpoint GET /v1/nothing"},
+		{name: "wrong method", method: "POST", path: "/v1/apps/1", want: "POST is not supported for /v1/apps/1 (allowed: GET)"},
+		{name: "unsupported method", method: "PUT", path: "/v1/apps/1", want: "unsupported method"},
+		{name: "relative path", method: "GET", path: "apps", want: "path must be an API path"},
+		{name: "missing placeholder value", method: "GET", path: "/v1/apps/{id}", want: "path parameter {id} is not set"},
+		{name: "unused param", method: "GET", path: "/v1/apps/1", params: []string{"id=2"}, want: "--param id does not match"},
+		{name: "unsafe path value", method: "GET", path: "/v1/apps/{id}", params: []string{"id=../x"}, want: "invalid character"},
+		{name: "unknown query", method: "GET", path: "/v1/apps/1/appStoreVersions", queries: []string{"filter[bogus]=1"}, want: `unknown query parameter "filter[bogus]"`},
+		{name: "no query allowed", method: "GET", path: "/v1/apps/1", queries: []string{"limit=1"}, want: "does not accept query parameters"},
+		{name: "invalid enum", method: "GET", path: "/v1/apps/1/appStoreVersions", queries: []string{"filter[platform]=IOS,WATCH_OS"}, want: `invalid value "WATCH_OS"`},
+		{name: "invalid limit", method: "GET", path: "/v1/apps/1/appStoreVersions", queries: []string{"limit=zero"}, want: "must be a positive integer"},
+		{name: "malformed query", method: "GET", path: "/v1/apps/1/appStoreVersions", queries: []string{"limit"}, want: "--query must be name=value"},
+		{name: "required query", method: "GET", path: "/v1/financeReports", want: `query parameter "filter[vendorNumber]" is required`},
+		{name: "missing body", method: "PATCH", path: "/v1/appStoreVersions/1", want: "--body is required"},
+		{name: "unexpected body", method: "GET", path: "/v1/apps/1", body: `{"data":{}}`, want: "does not accept a request body"},
+		{name: "missing data", method: "PATCH", path: "/v1/appStoreVersions/1", body: `{"attributes":{}}`, want: `top-level "data" member`},
+		{name: "unknown attribute", method: "PATCH", path: "/v1/appStoreVersions/1", body: `{"data":{"attributes":{"name":"x"}}}`, want: `unknown attribute "name"`},
+		{name: "wrong attribute type", method: "PATCH", path: "/v1/appStoreVersions/1", body: `{"data":{"attributes":{"downloadable":"yes"}}}`, want: `attribute "downloadable" must be of type boolean`},
+		{name: "invalid attribute enum", method: "PATCH", path: "/v1/appStoreVersions/1", body: `{"data":{"attributes":{"releaseType":"LATER"}}}`, want: `invalid value LATER for attribute "releaseType"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var body json.RawMessage
+			if test.body != "" {
+				body = json.RawMessage(test.body)
+			}
+			var err error
+			stderr := captureStderr(t, func() {
+				_, err = buildRawRequest(testEndpoints, test.method, test.path, test.params, test.queries, body)
+			})
+			if !errors.Is(err, flag.ErrHelp) {
+				t.Fatalf("expected usage error, got %v", err)
+			}
+			if !strings.Contains(stderr, test.want) {
+				t.Fatalf("stderr = %q, want substring %q", stderr, test.want)
+			}
+		})
+	}
+}
+
+func TestBuildRawRequest_MatchesBundledSchema(t *testing.T) {
+	endpoints, err := schema.LoadEndpoints()
+	if err != nil {
+		t.Fatalf("LoadEndpoints() error: %v", err)
+	}
+	request, err := buildRawRequest(endpoints, "GET", "/v1/apps/{id}/appStoreVersions", []string{"id=123"}, []string{"filter[platform]=IOS"}, nil)
+	if err != nil {
+		t.Fatalf("buildRawRequest() error: %v", err)
+	}
+	if request.Endpoint.Path != "/v1/apps/{id}/appStoreVersions" {
+		t.Fatalf("matched endpoint %q", request.Endpoint.Path)
+	}
+}
+
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error: %v", err)
+	}
+	original := os.Stderr
+	os.Stderr = writer
+	defer func() { os.Stderr = original }()
+
+	fn()
+
+	_ = writer.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("read stderr: %v", err)
+	}
+	return string(data)
+}
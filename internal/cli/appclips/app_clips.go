@@ -112,7 +112,7 @@ Examples:
 					return fmt.Errorf("app-clips list: failed to fetch: %w", err)
 				}
 
-				resp, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetAppClips(ctx, appValue, asc.WithAppClipsNextURL(nextURL))
 				})
 				if err != nil {
@@ -117,7 +117,7 @@ Examples:
 				if err != nil {
 					return fmt.Errorf("apps app-encryption-declarations list: failed to fetch: %w", err)
 				}
-				pages, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				pages, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetAppEncryptionDeclarations(ctx, resolvedAppID, asc.WithAppEncryptionDeclarationsNextURL(nextURL))
 				})
 				if err != nil {
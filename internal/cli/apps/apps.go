@@ -357,7 +357,7 @@ func appsList(ctx context.Context, output string, pretty bool, bundleID string,
 
 	if paginate {
 		paginateOpts := append(opts, asc.WithAppsLimit(200))
-		apps, err := shared.PaginateWithSpinner(requestCtx,
+		apps, err := shared.PaginateForOutput(requestCtx, output,
 			func(ctx context.Context) (asc.PaginatedResponse, error) {
 				return client.GetApps(ctx, paginateOpts...)
 			},
@@ -66,7 +66,7 @@ Examples:
 }
 
 func appsWallFlags(fs *flag.FlagSet) (output shared.OutputFlags, sortBy *string, limit *int) {
-	output = shared.BindOutputFlagsWith(fs, "output", defaultCommunityWallOutput, "Output format: table (default), json, markdown")
+	output = shared.BindOutputFlagsWith(fs, "output", defaultCommunityWallOutput, "Output format: table (default), json, markdown, csv, tsv, ndjson")
 	sortBy = fs.String("sort", defaultCommunityWallSort, "Sort by name or -name")
 	limit = fs.Int("limit", 0, "Maximum number of apps to include (1-200)")
 	return
@@ -92,6 +92,10 @@ func AssetsPreviewsUploadCommand() *ffcli.Command {
 		ShortHelp:  "Upload previews for a localization.",
 		LongHelp: `Upload previews for a localization.
 
+Each file's reservation and uploaded parts are saved under ~/.asc/uploads (or
+ASC_UPLOAD_STATE_DIR). Re-running after an interruption reuses the reserved
+preview, uploads only the remaining parts, and commits it.
+
 Examples:
   asc video-previews upload --version-localization "LOC_ID" --path "./previews" --device-type "IPHONE_65"
   asc video-previews upload --version-localization "LOC_ID" --path "./previews/preview.mov" --device-type "IPHONE_65"
@@ -187,7 +191,7 @@ func AssetsPreviewsDownloadCommand() *ffcli.Command {
 	outputPath := fs.String("output", "", "Output file path (required with --id)")
 	outputDir := fs.String("output-dir", "", "Output directory (required with --version-localization)")
 	overwrite := fs.Bool("overwrite", false, "Overwrite existing files")
-	format := shared.BindOutputFlagsWith(fs, "format", "json", "Summary output format: json (default), table, markdown")
+	format := shared.BindOutputFlagsWith(fs, "format", "json", "Summary output format: json (default), table, markdown, csv, tsv, ndjson")
 
 	return &ffcli.Command{
 		Name:       "download",
@@ -716,23 +720,39 @@ func uploadPreviewAsset(ctx context.Context, client *asc.Client, setID, filePath
 		return asc.AssetUploadResultItem{}, err
 	}
 
-	created, err := client.CreateAppPreview(ctx, setID, info.Name(), info.Size(), mimeType)
+	reservation, err := reserveResumableAsset(ctx, asc.UploadStateKindPreview, setID, checksum.Hash, info.Size(),
+		func(ctx context.Context, assetID string) (*asc.AssetDeliveryState, error) {
+			resp, err := client.GetAppPreview(ctx, assetID)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Data.Attributes.AssetDeliveryState, nil
+		},
+		func(ctx context.Context) (string, []asc.UploadOperation, error) {
+			created, err := client.CreateAppPreview(ctx, setID, info.Name(), info.Size(), mimeType)
+			if err != nil {
+				return "", nil, err
+			}
+			if len(created.Data.Attributes.UploadOperations) == 0 {
+				return "", nil, fmt.Errorf("no upload operations returned for %q", info.Name())
+			}
+			return created.Data.ID, created.Data.Attributes.UploadOperations, nil
+		},
+	)
 	if err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
-	if len(created.Data.Attributes.UploadOperations) == 0 {
-		return asc.AssetUploadResultItem{}, fmt.Errorf("no upload operations returned for %q", info.Name())
-	}
 
-	if err := asc.UploadAssetFromFile(ctx, file, info.Size(), created.Data.Attributes.UploadOperations); err != nil {
+	if err := asc.UploadAssetFromFile(ctx, file, info.Size(), reservation.Operations, asc.WithUploadState(reservation.State)); err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
 
-	if _, err := client.UpdateAppPreview(ctx, created.Data.ID, true, checksum.Hash); err != nil {
+	if _, err := client.UpdateAppPreview(ctx, reservation.ID, true, checksum.Hash); err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
+	_ = reservation.State.Remove()
 
-	state, err := waitForPreviewDelivery(ctx, client, created.Data.ID)
+	state, err := waitForPreviewDelivery(ctx, client, reservation.ID)
 	if err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
@@ -740,7 +760,7 @@ func uploadPreviewAsset(ctx context.Context, client *asc.Client, setID, filePath
 	return asc.AssetUploadResultItem{
 		FileName: info.Name(),
 		FilePath: filePath,
-		AssetID:  created.Data.ID,
+		AssetID:  reservation.ID,
 		State:    state,
 	}, nil
 }
@@ -418,6 +418,10 @@ matching --device-type are uploaded. This supports layouts like
 ./screenshots/en-US/iphone/*.png, or ./screenshots/iphone/en-US/*.png when
 --path points to ./screenshots/iphone.
 
+Each file's reservation and uploaded parts are saved under ~/.asc/uploads (or
+ASC_UPLOAD_STATE_DIR). Re-running after an interruption reuses the reserved
+screenshot, uploads only the remaining parts, and commits it.
+
 Examples:
   asc screenshots upload --version-localization "LOC_ID" --path "./screenshots" --device-type "IPHONE_65"
   asc screenshots upload --version-localization "LOC_ID" --path "./screenshots" --device-type "IPHONE_65" --skip-existing
@@ -1014,7 +1018,7 @@ func AssetsScreenshotsDownloadCommand() *ffcli.Command {
 	outputPath := fs.String("output", "", "Output file path (required with --id)")
 	outputDir := fs.String("output-dir", "", "Output directory (required with --version-localization)")
 	overwrite := fs.Bool("overwrite", false, "Overwrite existing files")
-	format := shared.BindOutputFlagsWith(fs, "format", "json", "Summary output format: json (default), table, markdown")
+	format := shared.BindOutputFlagsWith(fs, "format", "json", "Summary output format: json (default), table, markdown, csv, tsv, ndjson")
 
 	return &ffcli.Command{
 		Name:       "download",
@@ -1626,23 +1630,39 @@ func uploadScreenshotAsset(ctx context.Context, client *asc.Client, setID, fileP
 		return asc.AssetUploadResultItem{}, err
 	}
 
-	created, err := client.CreateAppScreenshot(ctx, setID, info.Name(), info.Size())
+	reservation, err := reserveResumableAsset(ctx, asc.UploadStateKindScreenshot, setID, checksum.Hash, info.Size(),
+		func(ctx context.Context, assetID string) (*asc.AssetDeliveryState, error) {
+			resp, err := client.GetAppScreenshot(ctx, assetID)
+			if err != nil {
+				return nil, err
+			}
+			return resp.Data.Attributes.AssetDeliveryState, nil
+		},
+		func(ctx context.Context) (string, []asc.UploadOperation, error) {
+			created, err := client.CreateAppScreenshot(ctx, setID, info.Name(), info.Size())
+			if err != nil {
+				return "", nil, err
+			}
+			if len(created.Data.Attributes.UploadOperations) == 0 {
+				return "", nil, fmt.Errorf("no upload operations returned for %q", info.Name())
+			}
+			return created.Data.ID, created.Data.Attributes.UploadOperations, nil
+		},
+	)
 	if err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
-	if len(created.Data.Attributes.UploadOperations) == 0 {
-		return asc.AssetUploadResultItem{}, fmt.Errorf("no upload operations returned for %q", info.Name())
-	}
 
-	if err := asc.UploadAssetFromFile(ctx, file, info.Size(), created.Data.Attributes.UploadOperations); err != nil {
+	if err := asc.UploadAssetFromFile(ctx, file, info.Size(), reservation.Operations, asc.WithUploadState(reservation.State)); err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
 
-	if _, err := client.UpdateAppScreenshot(ctx, created.Data.ID, true, checksum.Hash); err != nil {
+	if _, err := client.UpdateAppScreenshot(ctx, reservation.ID, true, checksum.Hash); err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
+	_ = reservation.State.Remove()
 
-	state, err := waitForScreenshotDelivery(ctx, client, created.Data.ID)
+	state, err := waitForScreenshotDelivery(ctx, client, reservation.ID)
 	if err != nil {
 		return asc.AssetUploadResultItem{}, err
 	}
@@ -1650,7 +1670,7 @@ func uploadScreenshotAsset(ctx context.Context, client *asc.Client, setID, fileP
 	return asc.AssetUploadResultItem{
 		FileName: info.Name(),
 		FilePath: filePath,
-		AssetID:  created.Data.ID,
+		AssetID:  reservation.ID,
 		State:    state,
 	}, nil
 }
@@ -0,0 +1,52 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// assetReservation is an asset ID with the upload operations to send to it.
+type assetReservation struct {
+	ID         string
+	Operations []asc.UploadOperation
+	State      *asc.UploadState
+}
+
+// reserveResumableAsset reuses the reservation saved by an interrupted upload
+// of the same file to the same set while the asset is still awaiting upload,
+// and otherwise creates a new reservation and saves it.
+func reserveResumableAsset(
+	ctx context.Context,
+	kind, setID, checksum string,
+	fileSize int64,
+	deliveryState func(ctx context.Context, assetID string) (*asc.AssetDeliveryState, error),
+	create func(ctx context.Context) (string, []asc.UploadOperation, error),
+) (assetReservation, error) {
+	store := shared.UploadStateStore()
+	state, err := store.Load(kind, setID, checksum, fileSize)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring upload state: %v\n", err)
+	}
+	if state != nil {
+		current, err := deliveryState(ctx, state.UploadID)
+		if err == nil && (current == nil || strings.EqualFold(current.State, "AWAITING_UPLOAD")) {
+			return assetReservation{ID: state.UploadID, Operations: state.Operations, State: state}, nil
+		}
+		_ = state.Remove()
+	}
+
+	assetID, operations, err := create(ctx)
+	if err != nil {
+		return assetReservation{}, err
+	}
+	state, err = store.Begin(kind, setID, checksum, fileSize, assetID, "", operations)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: upload will not be resumable: %v\n", err)
+	}
+	return assetReservation{ID: assetID, Operations: operations, State: state}, nil
+}
@@ -430,6 +430,7 @@ func AuthLoginCommand() *ffcli.Command {
 	keyID := fs.String("key-id", "", "App Store Connect API Key ID")
 	issuerID := fs.String("issuer-id", "", "App Store Connect Issuer ID")
 	keyPath := fs.String("private-key", "", "Path to private key (.p8) file")
+	individual := fs.Bool("individual", false, "Register an Individual API key (no issuer ID)")
 	bypassKeychain := fs.Bool("bypass-keychain", false, "Store credentials in config.json instead of keychain")
 	local := fs.Bool("local", false, "When bypassing keychain, write to ./.asc/config.json")
 	network := fs.Bool("network", false, "Validate credentials with a lightweight API request")
@@ -446,8 +447,12 @@ with a local config fallback (restricted permissions). Use --bypass-keychain to
 explicitly bypass keychain and write credentials to ~/.asc/config.json instead.
 Add --local to write ./.asc/config.json for the current repo.
 
+Individual API keys belong to a single user and have no issuer ID; register
+them with --individual instead of --issuer-id.
+
 Examples:
   asc auth login --name "MyKey" --key-id "ABC123" --issuer-id "DEF456" --private-key /path/to/AuthKey.p8
+  asc auth login --individual --name "Me" --key-id "ABC123" --private-key /path/to/AuthKey.p8
   asc auth login --bypass-keychain --local --name "MyKey" --key-id "ABC123" --issuer-id "DEF456" --private-key /path/to/AuthKey.p8
   asc auth login --network --name "MyKey" --key-id "ABC123" --issuer-id "DEF456" --private-key /path/to/AuthKey.p8
   asc auth login --skip-validation --name "MyKey" --key-id "ABC123" --issuer-id "DEF456" --private-key /path/to/AuthKey.p8
@@ -469,8 +474,11 @@ so commands continue to work even if the original .p8 file is removed.`,
 				fmt.Fprintln(os.Stderr, "Error: --key-id is required")
 				return flag.ErrHelp
 			}
-			if *issuerID == "" {
-				fmt.Fprintln(os.Stderr, "Error: --issuer-id is required")
+			if *individual && strings.TrimSpace(*issuerID) != "" {
+				return shared.UsageError("--issuer-id cannot be used with --individual")
+			}
+			if *issuerID == "" && !*individual {
+				fmt.Fprintln(os.Stderr, "Error: --issuer-id is required (or --individual for an Individual API key)")
 				return flag.ErrHelp
 			}
 			if *keyPath == "" {
@@ -749,6 +757,7 @@ Examples:
 					credentialEntry := authStatusCredentialOutput{
 						Name:      cred.Name,
 						KeyID:     cred.KeyID,
+						KeyType:   cred.KeyType,
 						IsDefault: cred.IsDefault,
 						StoredIn:  credentialStorageLabel(cred),
 					}
@@ -840,6 +849,7 @@ func credentialStorageLabel(cred authsvc.Credential) string {
 type authStatusCredentialOutput struct {
 	Name             string `json:"name"`
 	KeyID            string `json:"keyId"`
+	KeyType          string `json:"keyType,omitempty"`
 	IsDefault        bool   `json:"isDefault"`
 	StoredIn         string `json:"storedIn"`
 	Validation       string `json:"validation,omitempty"`
@@ -966,6 +976,8 @@ func AuthTokenCommand() *ffcli.Command {
 
 	name := fs.String("name", "", "Profile name (uses default profile if omitted)")
 	confirm := fs.Bool("confirm", false, "Confirm printing a live JWT to stdout")
+	var scopes shared.MultiStringFlag
+	fs.Var(&scopes, "scope", `Restrict the token to a request, e.g. "GET /v1/apps" (repeatable)`)
 	output := shared.BindOutputFlagsWithAllowed(fs, "output", "text", "Output format: text (raw token), json", "text", "json")
 
 	return &ffcli.Command{
@@ -977,11 +989,17 @@ func AuthTokenCommand() *ffcli.Command {
 The token is valid for 10 minutes and printed to stdout so it can be used
 in shell pipelines.
 
+Use --scope to mint a least-privilege token that App Store Connect only
+accepts for the listed method and path pairs (query strings included), e.g.
+to hand to a downstream CI job. Individual API keys produce tokens with
+sub "user" instead of an issuer.
+
 Requires --confirm because this prints a live bearer token to stdout.
 
 Examples:
   asc auth token --confirm
   asc auth token --name "MyKey" --confirm
+  asc auth token --confirm --scope "GET /v1/apps" --scope "GET /v1/builds?filter[app]=123456789"
   asc auth token --confirm --output json
   curl -H "Authorization: Bearer $(asc auth token --confirm)" https://api.appstoreconnect.apple.com/v1/apps`,
 		FlagSet:   fs,
@@ -1001,6 +1019,10 @@ Examples:
 			if !*confirm {
 				return shared.UsageError("--confirm is required")
 			}
+			scope, err := normalizeTokenScopes(scopes)
+			if err != nil {
+				return shared.UsageError(err.Error())
+			}
 
 			cred, err := shared.ResolveAuthCredentials(trimmedName)
 			if err != nil {
@@ -1012,20 +1034,24 @@ Examples:
 				return fmt.Errorf("auth token: %w", err)
 			}
 
-			token, err := asc.GenerateJWT(cred.KeyID, cred.IssuerID, privateKey)
+			token, err := asc.GenerateScopedJWT(cred.KeyID, cred.IssuerID, privateKey, scope)
 			if err != nil {
 				return fmt.Errorf("auth token: failed to generate JWT: %w", err)
 			}
 
 			if normalizedOutput == "json" {
 				return shared.PrintOutput(struct {
-					Token   string `json:"token"`
-					KeyID   string `json:"keyId"`
-					Profile string `json:"profile,omitempty"`
+					Token   string   `json:"token"`
+					KeyID   string   `json:"keyId"`
+					KeyType string   `json:"keyType,omitempty"`
+					Profile string   `json:"profile,omitempty"`
+					Scope   []string `json:"scope,omitempty"`
 				}{
 					Token:   token,
 					KeyID:   cred.KeyID,
+					KeyType: cred.KeyType,
 					Profile: cred.Profile,
+					Scope:   scope,
 				}, "json", *output.Pretty)
 			}
 
@@ -1035,6 +1061,33 @@ Examples:
 	}
 }
 
+// normalizeTokenScopes validates "METHOD /path" scope entries and uppercases
+// the method.
+func normalizeTokenScopes(values []string) ([]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	scope := make([]string, 0, len(values))
+	for _, value := range values {
+		method, path, ok := strings.Cut(strings.TrimSpace(value), " ")
+		path = strings.TrimSpace(path)
+		if !ok || path == "" {
+			return nil, fmt.Errorf(`--scope must be "METHOD /path", got %q`, value)
+		}
+		method = strings.ToUpper(method)
+		switch method {
+		case "GET", "POST", "PATCH", "DELETE":
+		default:
+			return nil, fmt.Errorf("--scope method must be GET, POST, PATCH, or DELETE, got %q", method)
+		}
+		if !strings.HasPrefix(path, "/v") || strings.ContainsAny(path, " \t") {
+			return nil, fmt.Errorf("--scope path must be an API path such as /v1/apps, got %q", path)
+		}
+		scope = append(scope, method+" "+path)
+	}
+	return scope, nil
+}
+
 func loadCredentialKey(cred shared.ResolvedAuthCredentials) (*ecdsa.PrivateKey, error) {
 	if pemValue := strings.TrimSpace(cred.KeyPEM); pemValue != "" {
 		return authsvc.LoadPrivateKeyFromPEM([]byte(pemValue))
@@ -130,7 +130,7 @@ Examples:
 				paginateOpts := append(opts, asc.WithBetaBuildLocalizationsLimit(200))
 				requestCtx, cancel := shared.ContextWithTimeout(ctx)
 				defer cancel()
-				resp, err := shared.PaginateWithSpinner(requestCtx,
+				resp, err := shared.PaginateForOutput(requestCtx, *output.Output,
 					func(ctx context.Context) (asc.PaginatedResponse, error) {
 						return client.ListBetaBuildLocalizations(ctx, paginateOpts...)
 					},
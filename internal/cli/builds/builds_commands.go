@@ -51,6 +51,10 @@ post-commit processing failures, or --wait for full build discovery and
 processing.
 Use --dry-run to only reserve the upload operations.
 
+Upload progress is saved under ~/.asc/uploads (or ASC_UPLOAD_STATE_DIR). If
+an upload is interrupted, re-running the same command with the same file
+resumes the reservation, uploads only the remaining parts, and commits it.
+
 Use --ipa for iOS, tvOS, and visionOS apps. Use --pkg for macOS apps.
 When using --pkg, the platform is automatically set to MAC_OS.
 
@@ -225,7 +229,16 @@ Examples:
 			requestCtx, cancel := shared.ContextWithTimeoutDuration(ctx, timeoutValue)
 			defer cancel()
 
-			uploadResp, fileResp, err := shared.PrepareBuildUpload(requestCtx, client, resolvedAppID, fileInfo, versionValue, buildNumberValue, platformValue, fileUTI)
+			var (
+				uploadResp  *asc.BuildUploadResponse
+				fileResp    *asc.BuildUploadFileResponse
+				uploadState *asc.UploadState
+			)
+			if *dryRun {
+				uploadResp, fileResp, err = shared.PrepareBuildUpload(requestCtx, client, resolvedAppID, fileInfo, versionValue, buildNumberValue, platformValue, fileUTI)
+			} else {
+				uploadResp, fileResp, uploadState, err = shared.ReserveResumableBuildUpload(requestCtx, client, resolvedAppID, filePath, fileInfo, versionValue, buildNumberValue, platformValue, fileUTI)
+			}
 			if err != nil {
 				return fmt.Errorf("builds upload: %w", err)
 			}
@@ -246,6 +259,7 @@ Examples:
 
 				uploadOpts := []asc.UploadOption{
 					asc.WithUploadConcurrency(*concurrency),
+					asc.WithUploadState(uploadState),
 				}
 				fmt.Fprintf(os.Stderr, "Uploading %s (%d bytes) to App Store Connect...\n", fileInfo.Name(), fileInfo.Size())
 				uploadCtx, uploadCancel := shared.ContextWithUploadTimeout(ctx)
@@ -278,6 +292,7 @@ Examples:
 				if err != nil {
 					return fmt.Errorf("builds upload: %w", err)
 				}
+				_ = uploadState.Remove()
 
 				if commitResp != nil && commitResp.Data.Attributes.Uploaded != nil {
 					result.Uploaded = commitResp.Data.Attributes.Uploaded
@@ -260,7 +260,7 @@ Examples:
 
 			if *paginate {
 				paginateOpts := append(opts, asc.WithBuildIconsLimit(200))
-				resp, err := shared.PaginateWithSpinner(requestCtx,
+				resp, err := shared.PaginateForOutput(requestCtx, *output.Output,
 					func(ctx context.Context) (asc.PaginatedResponse, error) {
 						return client.GetBuildIcons(ctx, buildID, paginateOpts...)
 					},
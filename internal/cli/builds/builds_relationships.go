@@ -146,7 +146,7 @@ Examples:
 
 				if *paginate {
 					paginateOpts := append(opts, asc.WithLinkagesLimit(200))
-					resp, err := shared.PaginateWithSpinner(requestCtx,
+					resp, err := shared.PaginateForOutput(requestCtx, *output.Output,
 						func(ctx context.Context) (asc.PaginatedResponse, error) {
 							return getBuildRelationshipList(ctx, client, relationshipType, buildID, paginateOpts...)
 						},
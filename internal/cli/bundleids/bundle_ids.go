@@ -99,7 +99,7 @@ Examples:
 					return fmt.Errorf("bundle-ids list: failed to fetch: %w", err)
 				}
 
-				paginated, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				paginated, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetBundleIDs(ctx, asc.WithBundleIDsNextURL(nextURL))
 				})
 				if err != nil {
@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// CacheCommand returns the cache command group.
+func CacheCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("cache", flag.ExitOnError)
+
+	return &ffcli.Command{
+		Name:       "cache",
+		ShortUsage: "asc cache <subcommand> [flags]",
+		ShortHelp:  "Inspect and clear the on-disk API response cache.",
+		LongHelp: `Inspect and clear the on-disk API response cache.
+
+asc caches GET responses for slow-changing lookups so repeated commands skip
+the network:
+  - territories and app categories (7 days)
+  - app, subscription and in-app purchase price points (24 hours)
+  - app lookups by bundle ID or name (1 hour)
+
+Entries are stored per profile and keyed by API host and credentials, so
+cached data is never shared between accounts. Use --no-cache to bypass the
+cache for one command or --refresh-cache to re-fetch and update it.
+
+Examples:
+  asc cache stats
+  asc cache clear
+  asc cache clear --all`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			cacheStatsCommand(),
+			cacheClearCommand(),
+		},
+		Exec: func(_ context.Context, _ []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+type cacheStatsResponse struct {
+	Path     string                        `json:"path"`
+	MaxBytes int64                         `json:"maxBytes"`
+	Entries  int                           `json:"entries"`
+	Expired  int                           `json:"expired"`
+	Bytes    int64                         `json:"bytes"`
+	Profiles []asc.ResponseCacheScopeStats `json:"profiles"`
+}
+
+func cacheStatsCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("cache stats", flag.ExitOnError)
+
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "stats",
+		ShortUsage: "asc cache stats [flags]",
+		ShortHelp:  "Show response cache usage per profile.",
+		LongHelp: `Show response cache usage per profile.
+
+Examples:
+  asc cache stats
+  asc cache stats --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(_ context.Context, args []string) error {
+			if len(args) > 0 {
+				return shared.UsageErrorf("unexpected argument(s): %s", strings.Join(args, " "))
+			}
+
+			root, err := shared.ResponseCacheRoot()
+			if err != nil {
+				return fmt.Errorf("cache stats: %w", err)
+			}
+			profiles, err := asc.ReadResponseCacheStats(root)
+			if err != nil {
+				return fmt.Errorf("cache stats: %w", err)
+			}
+
+			resp := &cacheStatsResponse{
+				Path:     root,
+				MaxBytes: shared.ResponseCacheMaxBytes(),
+				Profiles: profiles,
+			}
+			for _, profile := range profiles {
+				resp.Entries += profile.Entries
+				resp.Expired += profile.Expired
+				resp.Bytes += profile.Bytes
+			}
+
+			return shared.PrintOutputWithRenderers(
+				resp,
+				*output.Output,
+				*output.Pretty,
+				func() error { renderCacheStats(resp, false); return nil },
+				func() error { renderCacheStats(resp, true); return nil },
+			)
+		},
+	}
+}
+
+func renderCacheStats(resp *cacheStatsResponse, markdown bool) {
+	summaryRows := [][]string{
+		{"path", resp.Path},
+		{"maxBytes", strconv.FormatInt(resp.MaxBytes, 10)},
+		{"entries", strconv.Itoa(resp.Entries)},
+		{"expired", strconv.Itoa(resp.Expired)},
+		{"bytes", strconv.FormatInt(resp.Bytes, 10)},
+	}
+	shared.RenderSection("Summary", []string{"field", "value"}, summaryRows, markdown)
+
+	profileRows := make([][]string, 0, len(resp.Profiles))
+	for _, profile := range resp.Profiles {
+		profileRows = append(profileRows, []string{
+			profile.Scope,
+			strconv.Itoa(profile.Entries),
+			strconv.Itoa(profile.Expired),
+			strconv.FormatInt(profile.Bytes, 10),
+		})
+	}
+	shared.RenderSection("Profiles", []string{"profile", "entries", "expired", "bytes"}, profileRows, markdown)
+}
+
+type cacheClearResponse struct {
+	Profile string `json:"profile,omitempty"`
+	All     bool   `json:"all"`
+	Removed int    `json:"removed"`
+}
+
+func cacheClearCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("cache clear", flag.ExitOnError)
+
+	all := fs.Bool("all", false, "Clear cached responses for every profile")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "clear",
+		ShortUsage: "asc cache clear [flags]",
+		ShortHelp:  "Remove cached responses for the current profile.",
+		LongHelp: `Remove cached responses for the current profile.
+
+The current profile is the one asc would authenticate with (--profile,
+ASC_PROFILE or the default profile). Use --all to clear every profile.
+
+Examples:
+  asc cache clear
+  asc --profile "client-a" cache clear
+  asc cache clear --all`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(_ context.Context, args []string) error {
+			if len(args) > 0 {
+				return shared.UsageErrorf("unexpected argument(s): %s", strings.Join(args, " "))
+			}
+
+			root, err := shared.ResponseCacheRoot()
+			if err != nil {
+				return fmt.Errorf("cache clear: %w", err)
+			}
+
+			resp := &cacheClearResponse{All: *all}
+			scope := ""
+			if !*all {
+				profile := shared.ResolveProfileName()
+				if creds, err := shared.ResolveAuthCredentialsMetadata(profile); err == nil {
+					profile = creds.Profile
+				}
+				resp.Profile = shared.ResponseCacheScope(profile)
+				scope = resp.Profile
+			}
+			resp.Removed, err = asc.ClearResponseCache(root, scope)
+			if err != nil {
+				return fmt.Errorf("cache clear: %w", err)
+			}
+
+			return shared.PrintOutput(resp, *output.Output, *output.Pretty)
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package cmdtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/cmd"
+)
+
+func TestRun_APIPaginatesRawRequest(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	var requests []string
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path+"?"+req.URL.RawQuery)
+		if req.Method != http.MethodGet || req.URL.Path != "/v1/apps/123/appStoreVersions" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		if req.URL.Query().Get("cursor") == "" {
+			return jsonHTTPResponse(http.StatusOK, `{"data":[{"type":"appStoreVersions","id":"v1"}],"links":{"next":"https://api.appstoreconnect.apple.com/v1/apps/123/appStoreVersions?filter%5Bplatform%5D=IOS&cursor=abc"}}`), nil
+		}
+		return jsonHTTPResponse(http.StatusOK, `{"data":[{"type":"appStoreVersions","id":"v2"}],"links":{"next":""},"meta":{"paging":{"total":2}}}`), nil
+	})
+
+	stdout, stderr := captureOutput(t, func() {
+		code := cmd.Run([]string{"api", "GET", "/v1/apps/{id}/appStoreVersions", "--param", "id=123", "--query", "filter[platform]=IOS", "--paginate"}, "1.0.0")
+		if code != cmd.ExitSuccess {
+			t.Fatalf("exit code = %d, want %d", code, cmd.ExitSuccess)
+		}
+	})
+
+	if strings.TrimSpace(stderr) != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %v", requests)
+	}
+	if requests[0] != "GET /v1/apps/123/appStoreVersions?filter%5Bplatform%5D=IOS" {
+		t.Fatalf("unexpected first request %q", requests[0])
+	}
+
+	var payload struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &payload); err != nil {
+		t.Fatalf("decode output: %v\nstdout=%s", err, stdout)
+	}
+	if len(payload.Data) != 2 || payload.Data[0].ID != "v1" || payload.Data[1].ID != "v2" {
+		t.Fatalf("unexpected merged data: %+v", payload.Data)
+	}
+}
+
+func TestRun_APIRejectsInvalidRequestBeforeSending(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		return nil, nil
+	})
+
+	_, stderr := captureOutput(t, func() {
+		code := cmd.Run([]string{"api", "GET", "/v1/apps", "--query", "filter[bogus]=1"}, "1.0.0")
+		if code != cmd.ExitUsage {
+			t.Fatalf("exit code = %d, want %d", code, cmd.ExitUsage)
+		}
+	})
+
+	if !strings.Contains(stderr, `unknown query parameter "filter[bogus]"`) {
+		t.Fatalf("expected unknown query parameter error, got %q", stderr)
+	}
+}
+
+func TestRun_APIRejectsUnknownTrailingFlagWithoutExiting(t *testing.T) {
+	_, stderr := captureOutput(t, func() {
+		code := cmd.Run([]string{"api", "GET", "/v1/apps", "--bogus"}, "1.0.0")
+		if code != cmd.ExitUsage {
+			t.Fatalf("exit code = %d, want %d", code, cmd.ExitUsage)
+		}
+	})
+
+	if !strings.Contains(stderr, "flag provided but not defined: -bogus") {
+		t.Fatalf("expected unknown flag error, got %q", stderr)
+	}
+}
@@ -170,6 +170,7 @@ func TestRunScreenshotsUploadWritesFailureArtifactAndResumeCompletes(t *testing.
 
 	stdout, stderr := captureOutput(t, func() {
 		code := cmd.Run([]string{
+			"--upload-progress", "off",
 			"screenshots", "upload",
 			"--version-localization", "LOC_123",
 			"--path", workDir,
@@ -239,6 +240,7 @@ func TestRunScreenshotsUploadWritesFailureArtifactAndResumeCompletes(t *testing.
 
 	stdout, stderr = captureOutput(t, func() {
 		code := cmd.Run([]string{
+			"--upload-progress", "off",
 			"screenshots", "upload",
 			"--resume", artifactPath,
 			"--output", "json",
@@ -384,6 +386,7 @@ func TestRunScreenshotsUploadFanoutPrintsPartialResultsOnLocaleFailure(t *testin
 
 	stdout, stderr := captureOutput(t, func() {
 		code := cmd.Run([]string{
+			"--upload-progress", "off",
 			"screenshots", "upload",
 			"--app", "123456789",
 			"--version-id", "version-1",
@@ -0,0 +1,109 @@
+package cmdtest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildsUploadResumesInterruptedUpload(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("ASC_MAX_RETRIES", "0")
+
+	ipaPath := filepath.Join(t.TempDir(), "app.ipa")
+	if err := os.WriteFile(ipaPath, []byte("testdata"), 0o600); err != nil {
+		t.Fatalf("write ipa fixture: %v", err)
+	}
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	var requests []string
+	failPart := "/part-2"
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/buildUploads":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"buildUploads","id":"upload-1","attributes":{"cfBundleShortVersionString":"1.0.0","cfBundleVersion":"42","platform":"IOS"}}}`)
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/buildUploadFiles":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"buildUploadFiles","id":"file-1","attributes":{"fileName":"app.ipa","fileSize":8,"uti":"com.apple.itunes.ipa","assetType":"ASSET","uploadOperations":[{"method":"PUT","url":"https://upload.example.com/part-1","length":4,"offset":0},{"method":"PUT","url":"https://upload.example.com/part-2","length":4,"offset":4}]}}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/buildUploads/upload-1":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"buildUploads","id":"upload-1","attributes":{"cfBundleShortVersionString":"1.0.0","cfBundleVersion":"42","platform":"IOS","state":{"state":"AWAITING_UPLOAD"}}}}`)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/buildUploadFiles/file-1":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"buildUploadFiles","id":"file-1","attributes":{"fileName":"app.ipa","fileSize":8,"uti":"com.apple.itunes.ipa","uploaded":false}}}`)
+		case req.Method == http.MethodPut && req.URL.Host == "upload.example.com":
+			status := http.StatusOK
+			if req.URL.Path == failPart {
+				status = http.StatusBadRequest
+			}
+			return &http.Response{
+				StatusCode: status,
+				Status:     http.StatusText(status),
+				Body:       io.NopCloser(strings.NewReader("")),
+				Header:     http.Header{},
+			}, nil
+		case req.Method == http.MethodPatch && req.URL.Path == "/v1/buildUploadFiles/file-1":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"buildUploadFiles","id":"file-1","attributes":{"uploaded":true}}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	runUpload := func() (string, error) {
+		root := RootCommand("1.2.3")
+		root.FlagSet.SetOutput(io.Discard)
+		var runErr error
+		_, stderr := captureOutput(t, func() {
+			if err := root.Parse([]string{
+				"builds", "upload",
+				"--app", "123456789",
+				"--ipa", ipaPath,
+				"--version", "1.0.0",
+				"--build-number", "42",
+			}); err != nil {
+				t.Fatalf("parse error: %v", err)
+			}
+			runErr = root.Run(context.Background())
+		})
+		return stderr, runErr
+	}
+
+	if _, err := runUpload(); err == nil {
+		t.Fatal("expected first upload to fail")
+	}
+	entries, err := os.ReadDir(os.Getenv("ASC_UPLOAD_STATE_DIR"))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one saved upload state, got %v (%v)", entries, err)
+	}
+
+	requests = nil
+	failPart = ""
+	stderr, err := runUpload()
+	if err != nil {
+		t.Fatalf("resumed upload error: %v", err)
+	}
+	if !strings.Contains(stderr, "Resuming upload upload-1 (1 of 2 parts already uploaded)") {
+		t.Fatalf("expected resume notice, got %q", stderr)
+	}
+	want := []string{
+		"GET /v1/buildUploads/upload-1",
+		"GET /v1/buildUploadFiles/file-1",
+		"PUT /part-2",
+		"PATCH /v1/buildUploadFiles/file-1",
+	}
+	if strings.Join(requests, ",") != strings.Join(want, ",") {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+	entries, err = os.ReadDir(os.Getenv("ASC_UPLOAD_STATE_DIR"))
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("expected upload state to be removed after commit, got %v (%v)", entries, err)
+	}
+}
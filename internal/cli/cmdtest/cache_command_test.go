@@ -0,0 +1,76 @@
+package cmdtest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/cmd"
+)
+
+func TestRun_CacheServesRepeatedTerritoryLookups(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	requestCount := 0
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requestCount++
+		if req.Method != http.MethodGet || req.URL.Path != "/v1/territories" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		body := `{"data":[{"type":"territories","id":"USA","attributes":{"currency":"USD"}}],"links":{"next":""}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+
+	stdout, stderr := captureOutput(t, func() {
+		for _, args := range [][]string{
+			{"pricing", "territories", "list"},
+			{"pricing", "territories", "list"},
+			{"cache", "stats"},
+			{"--refresh-cache", "pricing", "territories", "list"},
+			{"cache", "clear", "--all"},
+		} {
+			if code := cmd.Run(args, "1.0.0"); code != cmd.ExitSuccess {
+				t.Fatalf("Run(%v) exit code = %d, want %d", args, code, cmd.ExitSuccess)
+			}
+		}
+	})
+
+	if strings.TrimSpace(stderr) != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+	if requestCount != 2 {
+		t.Fatalf("expected one cached lookup and one refresh, got %d requests", requestCount)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(stdout))
+	var outputs []map[string]any
+	for decoder.More() {
+		var output map[string]any
+		if err := decoder.Decode(&output); err != nil {
+			t.Fatalf("decode output: %v\nstdout=%s", err, stdout)
+		}
+		outputs = append(outputs, output)
+	}
+	if len(outputs) != 5 {
+		t.Fatalf("expected 5 JSON outputs, got %d", len(outputs))
+	}
+	if entries := outputs[2]["entries"]; entries != float64(1) {
+		t.Fatalf("expected one cached entry in stats, got %v", outputs[2])
+	}
+	if removed := outputs[4]["removed"]; removed != float64(1) {
+		t.Fatalf("expected one removed entry, got %v", outputs[4])
+	}
+}
@@ -31,6 +31,7 @@ func setupAuth(t *testing.T) {
 	t.Setenv("ASC_PRIVATE_KEY", "")
 	t.Setenv("ASC_PRIVATE_KEY_B64", "")
 	t.Setenv("ASC_STRICT_AUTH", "")
+	t.Setenv("ASC_UPLOAD_STATE_DIR", filepath.Join(tempDir, "uploads"))
 }
 
 func TestGameCenterEnabledVersionsListValidationErrors(t *testing.T) {
@@ -14,6 +14,7 @@ func resetCmdtestState() {
 	auth.ResetInvalidBypassKeychainWarningsForTest()
 	shared.ResetDefaultOutputFormat()
 	shared.ResetTierCacheForTest()
+	shared.ResetResponseCacheForTest()
 }
 
 func RootCommand(version string) *ffcli.Command {
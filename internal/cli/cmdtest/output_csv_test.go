@@ -0,0 +1,111 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAppsListPaginatedCSVOutputHasSingleHeaderRow(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	const nextURL = "https://api.appstoreconnect.apple.com/v1/apps?cursor=Mg&limit=200"
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/apps" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		body := `{"data":[{"type":"apps","id":"app-1","attributes":{"name":"Budget, Pro","bundleId":"com.example.budget","sku":"BUDGET"}}],"links":{"next":"` + nextURL + `"}}`
+		if req.URL.Query().Get("cursor") == "Mg" {
+			body = `{"data":[{"type":"apps","id":"app-2","attributes":{"name":"Quote \"Q\"","bundleId":"com.example.quote","sku":"QUOTE"}}],"links":{"next":""}}`
+		}
+		return jsonResponse(http.StatusOK, body)
+	})
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"apps", "list", "--paginate", "--output", "csv"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatalf("parse CSV output: %v\n%s", err, stdout)
+	}
+	want := [][]string{
+		{"ID", "Name", "Bundle ID", "SKU"},
+		{"app-1", "Budget, Pro", "com.example.budget", "BUDGET"},
+		{"app-2", `Quote "Q"`, "com.example.quote", "QUOTE"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("expected %d records, got %d:\n%s", len(want), len(records), stdout)
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Fatalf("record %d = %v, want %v", i, records[i], want[i])
+		}
+	}
+}
+
+func TestStatusRejectsCSVOutputWithoutRowRenderer(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/v1/apps":
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"apps","id":"app-1","attributes":{"name":"My App","bundleId":"app-1"}}]}`)
+		case "/v1/apps/app-1":
+			return jsonResponse(http.StatusOK, `{"data":{"type":"apps","id":"app-1","attributes":{"name":"My App","bundleId":"com.example.myapp"}}}`)
+		default:
+			return jsonResponse(http.StatusOK, `{"data":[]}`)
+		}
+	})
+
+	for _, format := range []string{"csv", "tsv"} {
+		t.Run(format, func(t *testing.T) {
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			stdout, stderr := captureOutput(t, func() {
+				if err := root.Parse([]string{"status", "--app", "app-1", "--output", format}); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+					t.Fatalf("expected ErrHelp, got %v", err)
+				}
+			})
+			if stdout != "" {
+				t.Fatalf("expected no stdout, got %q", stdout)
+			}
+			if !strings.Contains(stderr, "--output "+format+" is not supported by this command") {
+				t.Fatalf("expected unsupported format error, got %q", stderr)
+			}
+		})
+	}
+}
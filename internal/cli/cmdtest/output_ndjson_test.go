@@ -0,0 +1,116 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPaginatedNDJSONOutputStreamsPages(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		typ  string
+		args []string
+	}{
+		{
+			name: "testflight testers list",
+			path: "/v1/betaTesters",
+			typ:  "betaTesters",
+			args: []string{"testflight", "testers", "list", "--app", "app-1", "--paginate", "--output", "ndjson"},
+		},
+		{
+			name: "users list",
+			path: "/v1/users",
+			typ:  "users",
+			args: []string{"users", "list", "--paginate", "--output", "ndjson"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setupAuth(t)
+			t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+			// Commands write to os.Stdout; point it at a file so the transport
+			// can check what was written before the next page is fetched.
+			outPath := filepath.Join(t.TempDir(), "stdout.ndjson")
+			outFile, err := os.Create(outPath)
+			if err != nil {
+				t.Fatalf("create stdout file: %v", err)
+			}
+			t.Cleanup(func() { _ = outFile.Close() })
+
+			originalTransport := http.DefaultTransport
+			t.Cleanup(func() {
+				http.DefaultTransport = originalTransport
+			})
+
+			nextURL := "https://api.appstoreconnect.apple.com" + test.path + "?cursor=Mg&limit=200"
+			requests := 0
+			http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				requests++
+				if req.Method != http.MethodGet || req.URL.Path != test.path {
+					t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+				}
+				if req.URL.Query().Get("cursor") == "Mg" {
+					written, err := os.ReadFile(outPath)
+					if err != nil {
+						t.Fatalf("read stdout file: %v", err)
+					}
+					if !strings.Contains(string(written), `"id":"item-1"`) {
+						t.Fatalf("expected first page on stdout before the second page is fetched, got %q", written)
+					}
+					return jsonResponse(http.StatusOK, `{"data":[{"type":"`+test.typ+`","id":"item-2"},{"type":"`+test.typ+`","id":"item-3"}],"links":{"next":""}}`)
+				}
+				return jsonResponse(http.StatusOK, `{"data":[{"type":"`+test.typ+`","id":"item-1"}],"links":{"next":"`+nextURL+`"}}`)
+			})
+
+			root := RootCommand("1.2.3")
+			root.FlagSet.SetOutput(io.Discard)
+
+			_, stderr := captureOutput(t, func() {
+				os.Stdout = outFile
+				if err := root.Parse(test.args); err != nil {
+					t.Fatalf("parse error: %v", err)
+				}
+				if err := root.Run(context.Background()); err != nil {
+					t.Fatalf("run error: %v", err)
+				}
+			})
+			if stderr != "" {
+				t.Fatalf("expected empty stderr, got %q", stderr)
+			}
+			if requests != 2 {
+				t.Fatalf("expected 2 requests, got %d", requests)
+			}
+
+			stdout, err := os.ReadFile(outPath)
+			if err != nil {
+				t.Fatalf("read stdout file: %v", err)
+			}
+			lines := strings.Split(strings.TrimSuffix(string(stdout), "\n"), "\n")
+			wantIDs := []string{"item-1", "item-2", "item-3"}
+			if len(lines) != len(wantIDs) {
+				t.Fatalf("expected %d NDJSON lines, got %q", len(wantIDs), stdout)
+			}
+			for i, line := range lines {
+				var resource struct {
+					Type string `json:"type"`
+					ID   string `json:"id"`
+				}
+				if err := json.Unmarshal([]byte(line), &resource); err != nil {
+					t.Fatalf("line %d is not JSON: %v (%q)", i, err, line)
+				}
+				if resource.Type != test.typ || resource.ID != wantIDs[i] {
+					t.Fatalf("line %d = %+v, want %s/%s", i, resource, test.typ, wantIDs[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package cmdtest
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cmd "github.com/rudrankriyam/App-Store-Connect-CLI/cmd"
+)
+
+const projectionVersionsBody = `{"data":[` +
+	`{"type":"appStoreVersions","id":"ver-1","attributes":{"versionString":"1.0","appStoreState":"READY_FOR_SALE","platform":"IOS"}},` +
+	`{"type":"appStoreVersions","id":"ver-2","attributes":{"versionString":"1.1","appStoreState":"PREPARE_FOR_SUBMISSION","platform":"IOS"}}` +
+	`],"links":{"next":""}}`
+
+func stubProjectionVersions(t *testing.T) {
+	t.Helper()
+
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet || req.URL.Path != "/v1/apps/app-1/appStoreVersions" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL.String())
+		}
+		return jsonResponse(http.StatusOK, projectionVersionsBody)
+	})
+}
+
+func TestRun_FieldsSelectsCSVColumns(t *testing.T) {
+	stubProjectionVersions(t)
+
+	var code int
+	stdout, stderr := captureOutput(t, func() {
+		code = cmd.Run([]string{
+			"--fields", "id,attributes.versionString,attributes.appStoreState",
+			"versions", "list", "--app", "app-1", "--output", "csv",
+		}, "1.0.0")
+	})
+	if code != cmd.ExitSuccess {
+		t.Fatalf("exit code = %d, want %d; stderr=%q", code, cmd.ExitSuccess, stderr)
+	}
+	want := "id,attributes.versionString,attributes.appStoreState\n" +
+		"ver-1,1.0,READY_FOR_SALE\n" +
+		"ver-2,1.1,PREPARE_FOR_SUBMISSION\n"
+	if stdout != want {
+		t.Fatalf("unexpected CSV output:\n%s\nwant:\n%s", stdout, want)
+	}
+}
+
+func TestRun_QueryFiltersJSONOutput(t *testing.T) {
+	stubProjectionVersions(t)
+
+	var code int
+	stdout, stderr := captureOutput(t, func() {
+		code = cmd.Run([]string{
+			"--query", "data[?attributes.appStoreState == 'READY_FOR_SALE'].attributes.versionString",
+			"versions", "list", "--app", "app-1", "--output", "json",
+		}, "1.0.0")
+	})
+	if code != cmd.ExitSuccess {
+		t.Fatalf("exit code = %d, want %d; stderr=%q", code, cmd.ExitSuccess, stderr)
+	}
+	if strings.TrimSpace(stdout) != `["1.0"]` {
+		t.Fatalf("unexpected query output %q", stdout)
+	}
+}
+
+func TestRun_QueryRejectsInvalidExpression(t *testing.T) {
+	var code int
+	_, stderr := captureOutput(t, func() {
+		code = cmd.Run([]string{"--query", "data[", "versions", "list", "--app", "app-1"}, "1.0.0")
+	})
+	if code != cmd.ExitUsage {
+		t.Fatalf("exit code = %d, want %d", code, cmd.ExitUsage)
+	}
+	if !strings.Contains(stderr, "--query") {
+		t.Fatalf("expected --query error, got %q", stderr)
+	}
+}
@@ -0,0 +1,63 @@
+package cmdtest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cmd "github.com/rudrankriyam/App-Store-Connect-CLI/cmd"
+)
+
+func TestRun_TemplateRendersCustomText(t *testing.T) {
+	stubProjectionVersions(t)
+
+	var code int
+	stdout, stderr := captureOutput(t, func() {
+		code = cmd.Run([]string{
+			"versions", "list", "--app", "app-1", "--output", "template",
+			"--template", `{{range .data}}- {{.attributes.versionString}} ({{.attributes.appStoreState | lower}}){{"\n"}}{{end}}`,
+		}, "1.0.0")
+	})
+	if code != cmd.ExitSuccess {
+		t.Fatalf("exit code = %d, want %d; stderr=%q", code, cmd.ExitSuccess, stderr)
+	}
+	want := "- 1.0 (ready_for_sale)\n- 1.1 (prepare_for_submission)\n"
+	if stdout != want {
+		t.Fatalf("unexpected template output %q, want %q", stdout, want)
+	}
+}
+
+func TestRun_TemplateFileRendersCustomText(t *testing.T) {
+	stubProjectionVersions(t)
+	path := filepath.Join(t.TempDir(), "versions.tmpl")
+	if err := os.WriteFile(path, []byte("{{len .data}} versions\n"), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	var code int
+	stdout, stderr := captureOutput(t, func() {
+		code = cmd.Run([]string{"versions", "list", "--app", "app-1", "--output", "template", "--template-file", path}, "1.0.0")
+	})
+	if code != cmd.ExitSuccess {
+		t.Fatalf("exit code = %d, want %d; stderr=%q", code, cmd.ExitSuccess, stderr)
+	}
+	if stdout != "2 versions\n" {
+		t.Fatalf("unexpected template output %q", stdout)
+	}
+}
+
+func TestRun_TemplateOutputRequiresTemplate(t *testing.T) {
+	setupAuth(t)
+
+	var code int
+	_, stderr := captureOutput(t, func() {
+		code = cmd.Run([]string{"versions", "list", "--app", "app-1", "--output", "template"}, "1.0.0")
+	})
+	if code != cmd.ExitUsage {
+		t.Fatalf("exit code = %d, want %d", code, cmd.ExitUsage)
+	}
+	if !strings.Contains(stderr, "--output template requires --template or --template-file") {
+		t.Fatalf("expected template usage error, got %q", stderr)
+	}
+}
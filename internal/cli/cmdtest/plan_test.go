@@ -0,0 +1,55 @@
+package cmdtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cmd "github.com/rudrankriyam/App-Store-Connect-CLI/cmd"
+)
+
+func TestRun_PlanPrintsInterceptedRequests(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request sent in plan mode: %s %s", req.Method, req.URL.String())
+		return nil, nil
+	})
+
+	var code int
+	stdout, stderr := captureOutput(t, func() {
+		code = cmd.Run([]string{"--plan", "api", "PATCH", "/v1/appStoreVersions/v1", "--body", `{"data":{"type":"appStoreVersions","id":"v1","attributes":{"versionString":"2.0"}}}`}, "1.0.0")
+	})
+	if code != cmd.ExitSuccess {
+		t.Fatalf("exit code = %d, want %d; stderr=%q", code, cmd.ExitSuccess, stderr)
+	}
+	if !strings.Contains(stdout, `"versionString":"2.0"`) {
+		t.Fatalf("expected synthetic response on stdout, got %q", stdout)
+	}
+
+	var plan struct {
+		Requests []struct {
+			Step   int             `json:"step"`
+			Method string          `json:"method"`
+			Path   string          `json:"path"`
+			Body   json.RawMessage `json:"body"`
+		} `json:"requests"`
+	}
+	if err := json.Unmarshal([]byte(stderr), &plan); err != nil {
+		t.Fatalf("expected plan JSON on stderr, got %q: %v", stderr, err)
+	}
+	if len(plan.Requests) != 1 {
+		t.Fatalf("expected one planned request, got %+v", plan.Requests)
+	}
+	got := plan.Requests[0]
+	if got.Step != 1 || got.Method != http.MethodPatch || got.Path != "/v1/appStoreVersions/v1" || len(got.Body) == 0 {
+		t.Fatalf("unexpected planned request %+v", got)
+	}
+}
@@ -0,0 +1,108 @@
+package cmdtest
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cmd "github.com/rudrankriyam/App-Store-Connect-CLI/cmd"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/config"
+)
+
+func TestRun_ReadOnlyFlagBlocksMutations(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	t.Setenv("ASC_READ_ONLY", "")
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	requests := 0
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		if req.Method != http.MethodGet {
+			t.Fatalf("unexpected mutating request: %s %s", req.Method, req.URL.String())
+		}
+		return jsonHTTPResponse(http.StatusOK, `{"data":{"type":"apps","id":"123"}}`), nil
+	})
+
+	var readCode, writeCode int
+	_, stderr := captureOutput(t, func() {
+		readCode = cmd.Run([]string{"--read-only", "api", "GET", "/v1/apps/123"}, "1.0.0")
+		writeCode = cmd.Run([]string{"--read-only", "api", "PATCH", "/v1/appStoreVersions/v1", "--body", `{"data":{"type":"appStoreVersions","id":"v1","attributes":{"versionString":"2.0"}}}`}, "1.0.0")
+	})
+
+	if readCode != cmd.ExitSuccess {
+		t.Fatalf("read exit code = %d, want %d; stderr=%q", readCode, cmd.ExitSuccess, stderr)
+	}
+	if writeCode != cmd.ExitReadOnly {
+		t.Fatalf("write exit code = %d, want %d; stderr=%q", writeCode, cmd.ExitReadOnly, stderr)
+	}
+	if requests != 1 {
+		t.Fatalf("expected only the read request to be sent, got %d", requests)
+	}
+	if !strings.Contains(stderr, "PATCH /v1/appStoreVersions/v1 blocked: read-only mode is enabled (--read-only)") {
+		t.Fatalf("unexpected stderr %q", stderr)
+	}
+}
+
+func TestRun_ReadOnlyProfileBlocksMutations(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.json")
+	keyPath := filepath.Join(tempDir, "AuthKey.p8")
+	writeECDSAPEM(t, keyPath)
+
+	cfg := &config.Config{
+		DefaultKeyName: "agent",
+		Keys: []config.Credential{
+			{Name: "agent", KeyID: "KEY123", IssuerID: "ISS456", PrivateKeyPath: keyPath},
+			{Name: "admin", KeyID: "KEY789", IssuerID: "ISS456", PrivateKeyPath: keyPath},
+		},
+		ReadOnlyProfiles: []string{"agent"},
+	}
+	if err := config.SaveAt(configPath, cfg); err != nil {
+		t.Fatalf("SaveAt() error: %v", err)
+	}
+
+	t.Setenv("ASC_BYPASS_KEYCHAIN", "1")
+	t.Setenv("ASC_CONFIG_PATH", configPath)
+	t.Setenv("ASC_PROFILE", "")
+	t.Setenv("ASC_KEY_ID", "")
+	t.Setenv("ASC_ISSUER_ID", "")
+	t.Setenv("ASC_PRIVATE_KEY_PATH", "")
+	t.Setenv("ASC_PRIVATE_KEY", "")
+	t.Setenv("ASC_PRIVATE_KEY_B64", "")
+	t.Setenv("ASC_READ_ONLY", "")
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	requests := 0
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		return jsonHTTPResponse(http.StatusOK, `{"data":{"type":"appStoreVersions","id":"v1"}}`), nil
+	})
+
+	body := `{"data":{"type":"appStoreVersions","id":"v1","attributes":{"versionString":"2.0"}}}`
+	var agentCode, adminCode int
+	_, stderr := captureOutput(t, func() {
+		agentCode = cmd.Run([]string{"api", "PATCH", "/v1/appStoreVersions/v1", "--body", body}, "1.0.0")
+		adminCode = cmd.Run([]string{"--profile", "admin", "api", "PATCH", "/v1/appStoreVersions/v1", "--body", body}, "1.0.0")
+	})
+
+	if agentCode != cmd.ExitReadOnly {
+		t.Fatalf("agent exit code = %d, want %d; stderr=%q", agentCode, cmd.ExitReadOnly, stderr)
+	}
+	if adminCode != cmd.ExitSuccess {
+		t.Fatalf("admin exit code = %d, want %d; stderr=%q", adminCode, cmd.ExitSuccess, stderr)
+	}
+	if requests != 1 {
+		t.Fatalf("expected only the admin request to be sent, got %d", requests)
+	}
+	if !strings.Contains(stderr, "read-only mode is enabled (profile)") {
+		t.Fatalf("unexpected stderr %q", stderr)
+	}
+}
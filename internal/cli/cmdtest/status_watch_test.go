@@ -3,6 +3,8 @@ package cmdtest
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"io"
 	"net/http"
 	"path/filepath"
@@ -385,3 +387,18 @@ func TestStatusWatchRequestTimeoutDuringSnapshotFetchReturnsError(t *testing.T)
 		t.Fatalf("expected empty stderr, got %q", stderr)
 	}
 }
+
+func TestStatusWatchRejectsHTMLOutput(t *testing.T) {
+	root := RootCommand("1.2.3")
+	_, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"status", "--app", "123456789", "--watch", "--output", "html"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected usage error, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--output html is not supported with --watch") {
+		t.Fatalf("expected --watch usage error, got %q", stderr)
+	}
+}
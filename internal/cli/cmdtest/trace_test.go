@@ -0,0 +1,116 @@
+package cmdtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cmd "github.com/rudrankriyam/App-Store-Connect-CLI/cmd"
+)
+
+func TestRun_TraceFileWritesOTLPSpans(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	tracePath := filepath.Join(t.TempDir(), "traces", "run.json")
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonHTTPResponse(http.StatusOK, `{"data":{"type":"apps","id":"123"}}`), nil
+	})
+
+	var code int
+	_, stderr := captureOutput(t, func() {
+		code = cmd.Run([]string{"--trace-file", tracePath, "api", "GET", "/v1/apps/123"}, "1.0.0")
+	})
+	if code != cmd.ExitSuccess {
+		t.Fatalf("exit code = %d, want %d; stderr=%q", code, cmd.ExitSuccess, stderr)
+	}
+
+	data, err := os.ReadFile(tracePath)
+	if err != nil {
+		t.Fatalf("read trace file: %v", err)
+	}
+	var document struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					SpanID       string `json:"spanId"`
+					ParentSpanID string `json:"parentSpanId"`
+					Name         string `json:"name"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("unmarshal trace: %v\n%s", err, data)
+	}
+	spans := document.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected root and request spans, got %+v", spans)
+	}
+	root, request := spans[0], spans[1]
+	if root.Name != "asc api" || root.ParentSpanID != "" {
+		t.Fatalf("unexpected root span %+v", root)
+	}
+	if request.Name != "GET /v1/apps/{id}" || request.ParentSpanID != root.SpanID {
+		t.Fatalf("unexpected request span %+v", request)
+	}
+}
+
+func TestRun_TraceFileChromeFormat(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	tracePath := filepath.Join(t.TempDir(), "run.json")
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonHTTPResponse(http.StatusOK, `{"data":[]}`), nil
+	})
+
+	var code int
+	_, stderr := captureOutput(t, func() {
+		code = cmd.Run([]string{"--trace-file", tracePath, "--trace-format", "chrome", "api", "GET", "/v1/apps"}, "1.0.0")
+	})
+	if code != cmd.ExitSuccess {
+		t.Fatalf("exit code = %d, want %d; stderr=%q", code, cmd.ExitSuccess, stderr)
+	}
+
+	data, err := os.ReadFile(tracePath)
+	if err != nil {
+		t.Fatalf("read trace file: %v", err)
+	}
+	var document struct {
+		TraceEvents []struct {
+			Name  string `json:"name"`
+			Phase string `json:"ph"`
+		} `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("unmarshal trace: %v\n%s", err, data)
+	}
+	if len(document.TraceEvents) != 2 || document.TraceEvents[1].Name != "GET /v1/apps" || document.TraceEvents[1].Phase != "X" {
+		t.Fatalf("unexpected chrome events %+v", document.TraceEvents)
+	}
+}
+
+func TestRun_TraceFormatRejectsUnknownValue(t *testing.T) {
+	var code int
+	_, stderr := captureOutput(t, func() {
+		code = cmd.Run([]string{"--trace-format", "zipkin", "version"}, "1.0.0")
+	})
+	if code != cmd.ExitUsage {
+		t.Fatalf("exit code = %d, want %d", code, cmd.ExitUsage)
+	}
+	if !strings.Contains(stderr, "--trace-format must be one of") {
+		t.Fatalf("expected trace format error, got %q", stderr)
+	}
+}
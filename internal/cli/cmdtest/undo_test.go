@@ -0,0 +1,122 @@
+package cmdtest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cmd "github.com/rudrankriyam/App-Store-Connect-CLI/cmd"
+)
+
+func TestRun_UndoRestoresAuditedPatch(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	t.Setenv("ASC_AUDIT_LOG", logPath)
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() {
+		http.DefaultTransport = originalTransport
+	})
+	name := "Old"
+	var requests []string
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch req.Method {
+		case http.MethodGet:
+			return jsonHTTPResponse(http.StatusOK, `{"data":{"type":"betaGroups","id":"g1","attributes":{"name":"`+name+`"}}}`), nil
+		case http.MethodPatch:
+			body, _ := io.ReadAll(req.Body)
+			var payload struct {
+				Data struct {
+					Attributes struct {
+						Name string `json:"name"`
+					} `json:"attributes"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("unmarshal PATCH body: %v", err)
+			}
+			name = payload.Data.Attributes.Name
+			return jsonHTTPResponse(http.StatusOK, `{"data":{"type":"betaGroups","id":"g1","attributes":{"name":"`+name+`"}}}`), nil
+		default:
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.String())
+			return nil, nil
+		}
+	})
+
+	var patchCode, listCode, undoCode, againCode int
+	var listOut string
+	stdout, stderr := captureOutput(t, func() {
+		patchCode = cmd.Run([]string{"api", "PATCH", "/v1/betaGroups/g1", "--body", `{"data":{"type":"betaGroups","id":"g1","attributes":{"name":"New"}}}`}, "1.0.0")
+	})
+	if patchCode != cmd.ExitSuccess || name != "New" {
+		t.Fatalf("PATCH exit code = %d, name = %q; stdout=%q stderr=%q", patchCode, name, stdout, stderr)
+	}
+
+	listOut, stderr = captureOutput(t, func() {
+		listCode = cmd.Run([]string{"undo", "list", "--output", "json"}, "1.0.0")
+	})
+	if listCode != cmd.ExitSuccess {
+		t.Fatalf("undo list exit code = %d; stderr=%q", listCode, stderr)
+	}
+	var list struct {
+		Entries []struct {
+			ID       string `json:"id"`
+			Command  string `json:"command"`
+			Method   string `json:"method"`
+			Undoable bool   `json:"undoable"`
+		} `json:"entries"`
+	}
+	if err := json.Unmarshal([]byte(listOut), &list); err != nil {
+		t.Fatalf("unmarshal undo list: %v; stdout=%q", err, listOut)
+	}
+	if len(list.Entries) != 1 || !list.Entries[0].Undoable || list.Entries[0].Method != http.MethodPatch {
+		t.Fatalf("unexpected undo list %+v", list.Entries)
+	}
+	if !strings.HasPrefix(list.Entries[0].Command, "asc api PATCH /v1/betaGroups/g1") {
+		t.Fatalf("unexpected recorded command %q", list.Entries[0].Command)
+	}
+	entryID := list.Entries[0].ID
+
+	_, stderr = captureOutput(t, func() {
+		undoCode = cmd.Run([]string{"undo", entryID, "--confirm"}, "1.0.0")
+	})
+	if undoCode != cmd.ExitSuccess {
+		t.Fatalf("undo exit code = %d; stderr=%q", undoCode, stderr)
+	}
+	if name != "Old" {
+		t.Fatalf("expected undo to restore the name, got %q", name)
+	}
+
+	_, stderr = captureOutput(t, func() {
+		againCode = cmd.Run([]string{"undo", entryID, "--confirm"}, "1.0.0")
+	})
+	if againCode == cmd.ExitSuccess || !strings.Contains(stderr, "already undone") {
+		t.Fatalf("expected second undo to fail, code=%d stderr=%q", againCode, stderr)
+	}
+
+	want := []string{
+		"GET /v1/betaGroups/g1", "PATCH /v1/betaGroups/g1",
+		"GET /v1/betaGroups/g1", "PATCH /v1/betaGroups/g1",
+	}
+	if strings.Join(requests, ",") != strings.Join(want, ",") {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestRun_UndoRejectsUnknownTrailingFlagWithoutExiting(t *testing.T) {
+	_, stderr := captureOutput(t, func() {
+		code := cmd.Run([]string{"undo", "3f9a2c71b0de", "--bogus"}, "1.0.0")
+		if code != cmd.ExitUsage {
+			t.Fatalf("exit code = %d, want %d", code, cmd.ExitUsage)
+		}
+	})
+
+	if !strings.Contains(stderr, "flag provided but not defined: -bogus") {
+		t.Fatalf("expected unknown flag error, got %q", stderr)
+	}
+}
@@ -0,0 +1,125 @@
+package cmdtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/validate"
+)
+
+func TestValidateOutputsSARIFAndJUnit(t *testing.T) {
+	fixture := validValidateFixture()
+	client := newValidateTestClient(t, fixture)
+	restore := validate.SetClientFactory(func() (*asc.Client, error) {
+		return client, nil
+	})
+	defer restore()
+
+	junitPath := filepath.Join(t.TempDir(), "validate.xml")
+	root := RootCommand("1.2.3")
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"validate", "--app", "app-1", "--version-id", "ver-1", "--output", "sarif", "--junit", junitPath}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID string `json:"ruleId"`
+				Level  string `json:"level"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &log); err != nil {
+		t.Fatalf("failed to parse SARIF output: %v\n%s", err, stdout)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || log.Runs[0].Tool.Driver.Name != "asc" {
+		t.Fatalf("unexpected SARIF envelope: %+v", log)
+	}
+	foundPrivacy := false
+	for _, result := range log.Runs[0].Results {
+		if result.RuleID == "privacy.publish_state.unverified" {
+			foundPrivacy = result.Level == "note"
+		}
+	}
+	if !foundPrivacy {
+		t.Fatalf("expected privacy advisory as a note, got %+v", log.Runs[0].Results)
+	}
+
+	data, err := os.ReadFile(junitPath)
+	if err != nil {
+		t.Fatalf("read JUnit report: %v", err)
+	}
+	junit := string(data)
+	if !strings.Contains(junit, `<testsuite name="asc validate"`) || !strings.Contains(junit, `name="privacy.publish_state.unverified"`) {
+		t.Fatalf("unexpected JUnit report:\n%s", junit)
+	}
+	if strings.Contains(junit, "<failure") {
+		t.Fatalf("expected no failures for a ready version:\n%s", junit)
+	}
+}
+
+func TestValidateSARIFRejectsPretty(t *testing.T) {
+	root := RootCommand("1.2.3")
+	_, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"validate", "--app", "app-1", "--version-id", "ver-1", "--output", "sarif", "--pretty"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); !errors.Is(err, flag.ErrHelp) {
+			t.Fatalf("expected usage error, got %v", err)
+		}
+	})
+	if !strings.Contains(stderr, "--pretty is only valid with JSON output") {
+		t.Fatalf("expected --pretty usage error, got %q", stderr)
+	}
+}
+
+func TestValidateOutputsHTMLReport(t *testing.T) {
+	fixture := validValidateFixture()
+	client := newValidateTestClient(t, fixture)
+	restore := validate.SetClientFactory(func() (*asc.Client, error) {
+		return client, nil
+	})
+	defer restore()
+
+	root := RootCommand("1.2.3")
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"validate", "--app", "app-1", "--version-id", "ver-1", "--output", "html"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+	if stderr != "" {
+		t.Fatalf("expected empty stderr, got %q", stderr)
+	}
+	for _, want := range []string{"<!doctype html>", "<h1>Submission Readiness</h1>", "No blocking issues.", "privacy.publish_state.unverified"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected HTML output to contain %q:\n%s", want, stdout)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -1642,3 +1643,351 @@ func TestWorkflowValidate_Pretty(t *testing.T) {
 		t.Fatalf("expected valid=true, got %v", result["valid"])
 	}
 }
+
+func TestWorkflowRun_UsesStepRunsInProcess(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	requests := newRequestLog(2)
+	installDefaultTransport(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests.Add(req.Method + " " + req.URL.Path)
+		var body string
+		switch req.URL.Path {
+		case "/v1/builds/build-42":
+			body = `{"data":{"type":"builds","id":"build-42","attributes":{"version":"42","processingState":"VALID"}}}`
+		case "/v1/builds/build-42/preReleaseVersion":
+			body = `{"data":{"type":"preReleaseVersions","id":"prv-1","attributes":{"version":"1.2.3","platform":"IOS"}}}`
+		default:
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader(`{"errors":[{"status":"404","title":"Not Found"}]}`)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	}))
+
+	dir := t.TempDir()
+	path := writeWorkflowJSON(t, dir, `{
+		"workflows": {
+			"test": {
+				"steps": [
+					{"name": "build", "uses": "asc builds info", "with": {"build-id": "build-42"}},
+					"echo state=${steps.build.processingState} version=${steps.build.version}"
+				]
+			}
+		}
+	}`)
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"workflow", "run", "--file", path, "test"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		Status  string                       `json:"status"`
+		Outputs map[string]map[string]string `json:"outputs"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON stdout, got %q: %v", stdout, err)
+	}
+	if result.Status != "ok" {
+		t.Fatalf("expected status=ok, got %q", result.Status)
+	}
+	if got := result.Outputs["build"]["id"]; got != "build-42" {
+		t.Fatalf("expected build output id=build-42, got %v", result.Outputs["build"])
+	}
+	if !strings.Contains(stderr, "state=VALID version=42") {
+		t.Fatalf("expected interpolated outputs on stderr, got %q", stderr)
+	}
+	if entries := requests.Snapshot(); len(entries) == 0 || entries[0] != "GET /v1/builds/build-42" {
+		t.Fatalf("unexpected requests: %v", entries)
+	}
+}
+
+func TestWorkflowRun_UsesStepRunsNextToParallelShellStep(t *testing.T) {
+	setupAuth(t)
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(t.TempDir(), "nonexistent.json"))
+
+	installDefaultTransport(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"data":{"type":"builds","id":"build-42","attributes":{"version":"42"}}}`)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	}))
+
+	dir := t.TempDir()
+	path := writeWorkflowJSON(t, dir, `{
+		"workflows": {
+			"test": {
+				"steps": [
+					{
+						"name": "group",
+						"parallel": [
+							{"name": "build", "uses": "asc builds info", "with": {"build-id": "build-42"}},
+							{"name": "shell", "run": "for i in 1 2 3 4 5; do echo shell-line-$i; done"}
+						]
+					}
+				]
+			}
+		}
+	}`)
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"workflow", "run", "--file", path, "test"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		Status  string                       `json:"status"`
+		Outputs map[string]map[string]string `json:"outputs"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON stdout, got %q: %v", stdout, err)
+	}
+	if result.Status != "ok" {
+		t.Fatalf("expected status=ok, got %q", result.Status)
+	}
+	if got := result.Outputs["build"]["version"]; got != "42" {
+		t.Fatalf("expected build output version=42, got %v", result.Outputs["build"])
+	}
+	if !strings.Contains(stderr, "shell-line-5") {
+		t.Fatalf("expected shell step output on stderr, got %q", stderr)
+	}
+}
+
+func TestWorkflowRun_UsesStepUnknownFlagFailsStep(t *testing.T) {
+	dir := t.TempDir()
+	path := writeWorkflowJSON(t, dir, `{
+		"workflows": {
+			"test": {
+				"steps": [{"name": "build", "uses": "asc builds info", "with": {"no-such-flag": "x"}}]
+			}
+		}
+	}`)
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"workflow", "run", "--file", path, "test"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if runErr == nil {
+		t.Fatal("expected run error")
+	}
+
+	var result struct {
+		Status string `json:"status"`
+		Steps  []struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"steps"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON stdout, got %q: %v", stdout, err)
+	}
+	if result.Status != "error" || len(result.Steps) != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if !strings.Contains(result.Steps[0].Error, "flag provided but not defined: -no-such-flag") {
+		t.Fatalf("expected unknown flag error, got %q", result.Steps[0].Error)
+	}
+}
+
+func TestWorkflowRun_UsesStepBadCustomFlagValueFailsOnlyThatStep(t *testing.T) {
+	dir := t.TempDir()
+	path := writeWorkflowJSON(t, dir, `{
+		"workflows": {
+			"test": {
+				"steps": [
+					{"name": "sandbox", "uses": "asc sandbox update", "with": {"id": "tester-1", "interrupt-purchases": "maybe"}, "continue_on_error": true},
+					{"name": "table", "uses": "asc sandbox update", "with": {"output": "table"}, "continue_on_error": true},
+					"echo after-sandbox"
+				]
+			}
+		}
+	}`)
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"workflow", "run", "--file", path, "test"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result struct {
+		Status string `json:"status"`
+		Steps  []struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"steps"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON stdout, got %q: %v", stdout, err)
+	}
+	if result.Status != "ok" || len(result.Steps) != 3 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Steps[0].Status != "error" || !strings.Contains(result.Steps[0].Error, `invalid value "maybe" for flag -interrupt-purchases: must be true or false`) {
+		t.Fatalf("expected interrupt-purchases error, got %+v", result.Steps[0])
+	}
+	// --output is bound with a custom flag type that must be constructed
+	// by its command; it should still pass the flag check.
+	if strings.Contains(result.Steps[1].Error, "-output") {
+		t.Fatalf("expected --output table to be accepted, got %q", result.Steps[1].Error)
+	}
+	if result.Steps[2].Status != "ok" || !strings.Contains(stderr, "after-sandbox") {
+		t.Fatalf("expected the next step to run, got %+v (stderr %q)", result.Steps[2], stderr)
+	}
+}
+
+func TestWorkflowValidate_ReportsImportCycle(t *testing.T) {
+	dir := t.TempDir()
+	path := writeWorkflowJSON(t, dir, `{
+		"imports": ["shared.json"],
+		"workflows": {"beta": {"steps": ["echo beta"]}}
+	}`)
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "shared.json"), []byte(`{
+		"imports": ["workflow.json"],
+		"workflows": {"shared": {"steps": ["echo shared"]}}
+	}`), 0o600); err != nil {
+		t.Fatalf("write shared.json: %v", err)
+	}
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"workflow", "validate", "--file", path}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err == nil {
+			t.Fatal("expected error for import cycle")
+		}
+	})
+
+	var result struct {
+		Valid  bool `json:"valid"`
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON stdout, got %q: %v", stdout, err)
+	}
+	if result.Valid || len(result.Errors) != 1 {
+		t.Fatalf("expected one validation error, got %+v", result)
+	}
+	if result.Errors[0].Code != "import_cycle" || result.Errors[0].Message != "cyclic workflow import: workflow.json -> shared.json -> workflow.json" {
+		t.Fatalf("unexpected error: %+v", result.Errors[0])
+	}
+}
+
+func TestWorkflowRun_ImportedWorkflowWithInputs(t *testing.T) {
+	dir := t.TempDir()
+	path := writeWorkflowJSON(t, dir, `{
+		"imports": ["release-common.json"],
+		"workflows": {
+			"release": {"steps": [{"workflow": "release-common", "with": {"VERSION": "2.1.0"}}]}
+		}
+	}`)
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "release-common.json"), []byte(`{
+		"workflows": {
+			"release-common": {
+				"inputs": {
+					"VERSION": {"required": true},
+					"SUBMIT": {"type": "boolean", "default": false}
+				},
+				"steps": ["echo version=$VERSION submit=$SUBMIT"]
+			}
+		}
+	}`), 0o600); err != nil {
+		t.Fatalf("write release-common.json: %v", err)
+	}
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	stdout, stderr := captureOutput(t, func() {
+		if err := root.Parse([]string{"workflow", "run", "--file", path, "release"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		if err := root.Run(context.Background()); err != nil {
+			t.Fatalf("run error: %v", err)
+		}
+	})
+
+	var result map[string]any
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON stdout, got %q: %v", stdout, err)
+	}
+	if result["status"] != "ok" {
+		t.Fatalf("expected status=ok, got %v", result["status"])
+	}
+	if !strings.Contains(stderr, "version=2.1.0 submit=false") {
+		t.Fatalf("expected resolved inputs on stderr, got %q", stderr)
+	}
+}
+
+func TestWorkflowRun_MissingRequiredInputParam(t *testing.T) {
+	dir := t.TempDir()
+	path := writeWorkflowJSON(t, dir, `{
+		"workflows": {
+			"release": {
+				"inputs": {"VERSION": {"required": true}},
+				"steps": ["echo $VERSION"]
+			}
+		}
+	}`)
+
+	root := RootCommand("1.2.3")
+	root.FlagSet.SetOutput(io.Discard)
+
+	var runErr error
+	stdout, _ := captureOutput(t, func() {
+		if err := root.Parse([]string{"workflow", "run", "--file", path, "release"}); err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+		runErr = root.Run(context.Background())
+	})
+	if runErr == nil {
+		t.Fatal("expected run error")
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("expected JSON stdout, got %q: %v", stdout, err)
+	}
+	if errText, _ := result["error"].(string); !strings.Contains(errText, `missing required input "VERSION"`) {
+		t.Fatalf("expected missing input error, got %v", result["error"])
+	}
+}
@@ -136,7 +136,7 @@ func runListCommand(ctx context.Context, config shared.ListCommandConfig, flags
 			return fmt.Errorf("%s: failed to fetch: %w", prefix, err)
 		}
 
-		crashes, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		crashes, err := shared.PaginateAllForOutput(requestCtx, *flags.output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 			return client.GetCrashes(ctx, resolvedAppID, asc.WithCrashNextURL(nextURL))
 		})
 		if err != nil {
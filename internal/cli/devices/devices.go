@@ -151,7 +151,7 @@ Examples:
 					return fmt.Errorf("devices list: failed to fetch: %w", err)
 				}
 
-				devices, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				devices, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetDevices(ctx, asc.WithDevicesNextURL(nextURL))
 				})
 				if err != nil {
@@ -134,7 +134,7 @@ func runListCommand(ctx context.Context, config shared.ListCommandConfig, flags
 			return fmt.Errorf("%s: failed to fetch: %w", prefix, err)
 		}
 
-		feedback, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+		feedback, err := shared.PaginateAllForOutput(requestCtx, *flags.output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 			return client.GetFeedback(ctx, resolvedAppID, asc.WithFeedbackNextURL(nextURL))
 		})
 		if err != nil {
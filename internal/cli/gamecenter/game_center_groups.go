@@ -127,7 +127,7 @@ Examples:
 					return fmt.Errorf("game-center groups list: failed to fetch: %w", err)
 				}
 
-				resp, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetGameCenterGroups(ctx, asc.WithGCGroupsNextURL(nextURL))
 				})
 				if err != nil {
@@ -426,7 +426,7 @@ Examples:
 					return fmt.Errorf("game-center groups achievements list: failed to fetch: %w", err)
 				}
 
-				resp, err := asc.PaginateAll(requestCtx, firstPage, fetch)
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, fetch)
 				if err != nil {
 					return fmt.Errorf("game-center groups achievements list: %w", err)
 				}
@@ -600,7 +600,7 @@ Examples:
 					return fmt.Errorf("game-center groups leaderboards list: failed to fetch: %w", err)
 				}
 
-				resp, err := asc.PaginateAll(requestCtx, firstPage, fetch)
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, fetch)
 				if err != nil {
 					return fmt.Errorf("game-center groups leaderboards list: %w", err)
 				}
@@ -772,7 +772,7 @@ Examples:
 					return fmt.Errorf("game-center groups leaderboard-sets list: failed to fetch: %w", err)
 				}
 
-				resp, err := asc.PaginateAll(requestCtx, firstPage, fetch)
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, fetch)
 				if err != nil {
 					return fmt.Errorf("game-center groups leaderboard-sets list: %w", err)
 				}
@@ -874,7 +874,7 @@ Examples:
 					return fmt.Errorf("game-center groups activities list: failed to fetch: %w", err)
 				}
 
-				resp, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetGameCenterGroupActivities(ctx, id, asc.WithGCActivitiesNextURL(nextURL))
 				})
 				if err != nil {
@@ -975,7 +975,7 @@ Examples:
 					return fmt.Errorf("game-center groups challenges list: failed to fetch: %w", err)
 				}
 
-				resp, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetGameCenterGroupChallenges(ctx, id, asc.WithGCChallengesNextURL(nextURL))
 				})
 				if err != nil {
@@ -1126,7 +1126,7 @@ Examples:
 					return fmt.Errorf("game-center groups details list: failed to fetch: %w", err)
 				}
 
-				resp, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetGameCenterGroupGameCenterDetails(ctx, id, asc.WithGCDetailsNextURL(nextURL))
 				})
 				if err != nil {
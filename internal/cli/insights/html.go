@@ -0,0 +1,180 @@
+package insights
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// htmlMetric is the period-agnostic view of a weekly or daily metric.
+type htmlMetric struct {
+	Name         string
+	Unit         string
+	Current      *float64
+	Previous     *float64
+	Delta        *float64
+	DeltaPercent *float64
+	Status       string
+	Reason       string
+}
+
+func buildWeeklyInsightsHTMLReport(resp *weeklyInsightsResponse) shared.HTMLReport {
+	contextRows := [][]string{
+		{"App ID", resp.AppID},
+		{"Source", resp.Source.Name},
+		{"Week", fmt.Sprintf("%s to %s", resp.Week.Start, resp.Week.End)},
+		{"Previous week", fmt.Sprintf("%s to %s", resp.PreviousWeek.Start, resp.PreviousWeek.End)},
+	}
+	if strings.TrimSpace(resp.Source.VendorNumber) != "" {
+		contextRows = append(contextRows, []string{"Vendor number", resp.Source.VendorNumber})
+	}
+	if resp.Source.RequestsScanned > 0 {
+		contextRows = append(contextRows, []string{"Requests scanned", strconv.Itoa(resp.Source.RequestsScanned)})
+	}
+
+	metrics := make([]htmlMetric, 0, len(resp.Metrics))
+	for _, metric := range resp.Metrics {
+		metrics = append(metrics, htmlMetric{
+			Name:         metric.Name,
+			Unit:         metric.Unit,
+			Current:      metric.ThisWeek,
+			Previous:     metric.LastWeek,
+			Delta:        metric.Delta,
+			DeltaPercent: metric.DeltaPercent,
+			Status:       metric.Status,
+			Reason:       metric.Reason,
+		})
+	}
+
+	return buildInsightsHTMLReport(
+		"Weekly Insights",
+		fmt.Sprintf("%s · %s · week of %s", resp.AppID, resp.Source.Name, resp.Week.Start),
+		resp.GeneratedAt,
+		"last week",
+		[]string{"This week", "Last week"},
+		contextRows,
+		metrics,
+	)
+}
+
+func buildDailyInsightsHTMLReport(resp *dailyInsightsResponse) shared.HTMLReport {
+	contextRows := [][]string{
+		{"App ID", resp.AppID},
+		{"Source", resp.Source.Name},
+		{"Date", resp.Date},
+		{"Previous date", resp.PreviousDate},
+	}
+	if strings.TrimSpace(resp.Source.VendorNumber) != "" {
+		contextRows = append(contextRows, []string{"Vendor number", resp.Source.VendorNumber})
+	}
+	if strings.TrimSpace(resp.Source.AppSKU) != "" {
+		contextRows = append(contextRows, []string{"App SKU", resp.Source.AppSKU})
+	}
+
+	metrics := make([]htmlMetric, 0, len(resp.Metrics))
+	for _, metric := range resp.Metrics {
+		metrics = append(metrics, htmlMetric{
+			Name:         metric.Name,
+			Unit:         metric.Unit,
+			Current:      metric.ThisDay,
+			Previous:     metric.PreviousDay,
+			Delta:        metric.Delta,
+			DeltaPercent: metric.DeltaPercent,
+			Status:       metric.Status,
+			Reason:       metric.Reason,
+		})
+	}
+
+	return buildInsightsHTMLReport(
+		"Daily Insights",
+		fmt.Sprintf("%s · %s · %s", resp.AppID, resp.Source.Name, resp.Date),
+		resp.GeneratedAt,
+		"previous day",
+		[]string{"This day", "Previous day"},
+		contextRows,
+		metrics,
+	)
+}
+
+// buildInsightsHTMLReport renders one card per available metric with its
+// change against the previous period, followed by context and metric tables.
+// Unavailable metrics are listed with their reason instead of a card.
+func buildInsightsHTMLReport(title, subtitle, generatedAt, previousLabel string, periodHeaders []string, contextRows [][]string, metrics []htmlMetric) shared.HTMLReport {
+	cards := make([]shared.HTMLReportCard, 0, len(metrics))
+	unavailable := make([]shared.HTMLReportItem, 0)
+	rows := make([][]shared.HTMLReportCell, 0, len(metrics))
+	for _, metric := range metrics {
+		tone := deltaTone(metric.Delta)
+		if metric.Current != nil {
+			cards = append(cards, shared.HTMLReportCard{
+				Label:  metric.Name,
+				Value:  formatOptionalNumber(metric.Current),
+				Detail: formatDeltaDetail(metric.Delta, metric.DeltaPercent, previousLabel),
+				Tone:   tone,
+			})
+		} else {
+			unavailable = append(unavailable, shared.HTMLReportItem{Text: metric.Name, Detail: shared.OrNA(metric.Reason)})
+		}
+		rows = append(rows, []shared.HTMLReportCell{
+			{Text: metric.Name},
+			{Text: shared.OrNA(metric.Unit)},
+			{Text: formatOptionalNumber(metric.Current)},
+			{Text: formatOptionalNumber(metric.Previous)},
+			{Text: formatOptionalNumber(metric.Delta), Tone: tone},
+			{Text: formatOptionalNumber(metric.DeltaPercent), Tone: tone},
+			{Text: metric.Status},
+		})
+	}
+
+	contextCells := make([][]shared.HTMLReportCell, 0, len(contextRows))
+	for _, row := range contextRows {
+		contextCells = append(contextCells, shared.Cells(row...))
+	}
+
+	headers := append([]string{"Metric", "Unit"}, periodHeaders...)
+	headers = append(headers, "Delta", "Delta %", "Status")
+	sections := []shared.HTMLReportSection{
+		{Title: "Metrics", Table: &shared.HTMLReportTable{Headers: headers, Rows: rows}},
+	}
+	if len(unavailable) > 0 {
+		sections = append(sections, shared.HTMLReportSection{Title: "Unavailable Metrics", Items: unavailable})
+	}
+	sections = append(sections, shared.HTMLReportSection{
+		Title: "Context",
+		Table: &shared.HTMLReportTable{Headers: []string{"Field", "Value"}, Rows: contextCells},
+	})
+
+	return shared.HTMLReport{
+		Title:       title,
+		Subtitle:    subtitle,
+		GeneratedAt: generatedAt,
+		Cards:       cards,
+		Sections:    sections,
+	}
+}
+
+func formatDeltaDetail(delta, deltaPercent *float64, previousLabel string) string {
+	if delta == nil {
+		return "no comparison"
+	}
+	detail := fmt.Sprintf("%+.2f", *delta)
+	if deltaPercent != nil {
+		detail += fmt.Sprintf(" (%+.2f%%)", *deltaPercent)
+	}
+	return detail + " vs " + previousLabel
+}
+
+// deltaTone colors growth green and decline red; every insights metric is
+// a volume where more is better.
+func deltaTone(delta *float64) string {
+	switch {
+	case delta == nil || *delta == 0:
+		return shared.HTMLToneNeutral
+	case *delta > 0:
+		return shared.HTMLToneGood
+	default:
+		return shared.HTMLToneBad
+	}
+}
@@ -0,0 +1,65 @@
+package insights
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+func TestBuildWeeklyInsightsHTMLReport_CardsCarryDeltas(t *testing.T) {
+	resp := &weeklyInsightsResponse{
+		AppID:        "123456789",
+		Source:       weeklyInsightsSource{Name: sourceSales, VendorNumber: "12345678"},
+		Week:         weekRange{Start: "2026-02-16", End: "2026-02-22"},
+		PreviousWeek: weekRange{Start: "2026-02-09", End: "2026-02-15"},
+		Metrics: []weeklyMetric{
+			comparableMetric("download_units", "count", 120, 100),
+			comparableMetric("developer_proceeds", "currency", 40, 50),
+			unavailableMetric("active_devices", "count", "not derivable from sales summary exports"),
+		},
+		GeneratedAt: "2026-02-23T00:00:00Z",
+	}
+
+	report := buildWeeklyInsightsHTMLReport(resp)
+	if len(report.Cards) != 2 {
+		t.Fatalf("expected cards for available metrics only, got %+v", report.Cards)
+	}
+	downloads := report.Cards[0]
+	if downloads.Value != "120.00" || downloads.Tone != shared.HTMLToneGood || downloads.Detail != "+20.00 (+20.00%) vs last week" {
+		t.Fatalf("unexpected downloads card: %+v", downloads)
+	}
+	if report.Cards[1].Tone != shared.HTMLToneBad {
+		t.Fatalf("expected declining proceeds to be bad, got %+v", report.Cards[1])
+	}
+
+	html, err := shared.RenderHTMLReport(report)
+	if err != nil {
+		t.Fatalf("RenderHTMLReport error: %v", err)
+	}
+	for _, want := range []string{"Weekly Insights", "Unavailable Metrics", "not derivable from sales summary exports", "2026-02-09 to 2026-02-15"} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("expected HTML to contain %q:\n%s", want, html)
+		}
+	}
+}
+
+func TestBuildDailyInsightsHTMLReport_UsesPreviousDayLabels(t *testing.T) {
+	resp := &dailyInsightsResponse{
+		AppID:        "123456789",
+		Source:       dailyInsightsSource{Name: sourceSales},
+		Date:         "2026-02-20",
+		PreviousDate: "2026-02-19",
+		Metrics: []dailyMetric{
+			comparableDailyMetric("renewal_units", "count", 5, 5),
+		},
+	}
+
+	report := buildDailyInsightsHTMLReport(resp)
+	if len(report.Cards) != 1 || report.Cards[0].Tone != shared.HTMLToneNeutral || !strings.HasSuffix(report.Cards[0].Detail, "vs previous day") {
+		t.Fatalf("unexpected daily cards: %+v", report.Cards)
+	}
+	if headers := report.Sections[0].Table.Headers; headers[2] != "This day" || headers[3] != "Previous day" {
+		t.Fatalf("unexpected metric headers: %v", headers)
+	}
+}
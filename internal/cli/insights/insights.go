@@ -58,7 +58,7 @@ func insightsWeeklyCommand() *ffcli.Command {
 	source := fs.String("source", "", "Insights source: analytics or sales")
 	week := fs.String("week", "", "Week start date (YYYY-MM-DD)")
 	vendor := fs.String("vendor", "", "Vendor number for sales source (or ASC_VENDOR_NUMBER)")
-	output := shared.BindOutputFlags(fs)
+	output := shared.BindOutputFlagsWithFormats(fs, "html")
 
 	return &ffcli.Command{
 		Name:       "weekly",
@@ -66,7 +66,7 @@ func insightsWeeklyCommand() *ffcli.Command {
 		ShortHelp:  "Summarize this week vs last week metrics.",
 		LongHelp: `Summarize this week vs last week metrics.
 
-The output is deterministic JSON by default and can be rendered as table/markdown.
+The output is deterministic JSON by default and can be rendered as table/markdown/html.
 
 For --source sales, totals are scoped to the selected app and include linked in-app purchases
 and subscriptions by matching Parent Identifier against the app SKU.
@@ -74,7 +74,8 @@ and subscriptions by matching Parent Identifier against the app SKU.
 Examples:
   asc insights weekly --app "123456789" --source analytics --week "2026-02-16"
   asc insights weekly --app "123456789" --source sales --week "2026-02-16" --vendor "12345678"
-  asc insights weekly --app "123456789" --source sales --week "2026-02-16" --output table`,
+  asc insights weekly --app "123456789" --source sales --week "2026-02-16" --output table
+  asc insights weekly --app "123456789" --source sales --week "2026-02-16" --output html > weekly.html`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -121,6 +122,9 @@ Examples:
 				return fmt.Errorf("insights weekly: %w", err)
 			}
 
+			if shared.NormalizeOutputFormat(*output.Output) == "html" {
+				return shared.PrintHTMLReport(buildWeeklyInsightsHTMLReport(resp))
+			}
 			return shared.PrintOutputWithRenderers(
 				resp,
 				*output.Output,
@@ -138,7 +142,7 @@ func insightsDailyCommand() *ffcli.Command {
 	appID := fs.String("app", "", "App Store Connect app ID (required, or ASC_APP_ID env)")
 	vendor := fs.String("vendor", "", "Vendor number for sales source (or ASC_VENDOR_NUMBER)")
 	date := fs.String("date", "", "Report date (YYYY-MM-DD)")
-	output := shared.BindOutputFlags(fs)
+	output := shared.BindOutputFlagsWithFormats(fs, "html")
 
 	return &ffcli.Command{
 		Name:       "daily",
@@ -151,7 +155,8 @@ Renewal metrics are derived from rows where Subscription equals "Renewal" for pr
 
 Examples:
   asc insights daily --app "123456789" --vendor "12345678" --date "2026-02-20"
-  asc insights daily --app "123456789" --vendor "12345678" --date "2026-02-20" --output table`,
+  asc insights daily --app "123456789" --vendor "12345678" --date "2026-02-20" --output table
+  asc insights daily --app "123456789" --vendor "12345678" --date "2026-02-20" --output html > daily.html`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -187,6 +192,9 @@ Examples:
 				return fmt.Errorf("insights daily: %w", err)
 			}
 
+			if shared.NormalizeOutputFormat(*output.Output) == "html" {
+				return shared.PrintHTMLReport(buildDailyInsightsHTMLReport(resp))
+			}
 			return shared.PrintOutputWithRenderers(
 				resp,
 				*output.Output,
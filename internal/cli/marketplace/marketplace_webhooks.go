@@ -101,7 +101,7 @@ Examples:
 					return fmt.Errorf("marketplace webhooks list: failed to fetch: %w", err)
 				}
 
-				webhooks, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				webhooks, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetMarketplaceWebhooks(ctx, asc.WithMarketplaceWebhooksNextURL(nextURL))
 				})
 				if err != nil {
@@ -141,7 +141,7 @@ Examples:
 					return fmt.Errorf("merchant-ids list: failed to fetch: %w", err)
 				}
 
-				paginated, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				paginated, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetMerchantIDs(ctx, asc.WithMerchantIDsNextURL(nextURL))
 				})
 				if err != nil {
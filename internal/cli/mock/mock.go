@@ -0,0 +1,164 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/ascmock"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/schema"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+const (
+	defaultAddr           = "127.0.0.1:8686"
+	serverShutdownTimeout = 5 * time.Second
+)
+
+// MockCommand returns the mock command group.
+func MockCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("mock", flag.ExitOnError)
+
+	return &ffcli.Command{
+		Name:       "mock",
+		ShortUsage: "asc mock <subcommand> [flags]",
+		ShortHelp:  "Run a local App Store Connect API emulator.",
+		LongHelp: `Run a local App Store Connect API emulator.
+
+The emulator accepts every endpoint in the bundled schema index and keeps an
+in-memory JSON:API resource graph, so creates, updates and deletes are visible
+to later reads. Point asc at it with ASC_BASE_URL (or base_url in config).
+
+Examples:
+  asc mock serve
+  asc mock serve --addr 127.0.0.1:9000 --seed ./fixtures/apps.json
+  ASC_BASE_URL=http://127.0.0.1:8686 asc apps list`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			mockServeCommand(),
+		},
+		Exec: func(_ context.Context, _ []string) error {
+			return flag.ErrHelp
+		},
+	}
+}
+
+func mockServeCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("mock serve", flag.ExitOnError)
+
+	addr := fs.String("addr", defaultAddr, "Listen address (loopback only)")
+	seed := fs.String("seed", "", "Path to a JSON file of resources to preload (array or JSON:API document)")
+
+	return &ffcli.Command{
+		Name:       "serve",
+		ShortUsage: "asc mock serve [flags]",
+		ShortHelp:  "Serve the emulator until interrupted.",
+		LongHelp: `Serve the emulator until interrupted.
+
+Requests must carry a bearer token, but signatures are not verified, so any
+configured API key works. List endpoints page with limit/cursor and return
+links.next, and filter[...] parameters match attributes or relationship IDs.
+
+Examples:
+  asc mock serve
+  asc mock serve --seed ./fixtures/apps.json
+  asc mock serve --addr 127.0.0.1:9000`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) > 0 {
+				return shared.UsageErrorf("unexpected argument(s): %s", strings.Join(args, " "))
+			}
+			if err := validateLoopbackAddr(*addr); err != nil {
+				return shared.UsageError(err.Error())
+			}
+
+			server, err := newEmulator(*seed)
+			if err != nil {
+				return fmt.Errorf("mock serve: %w", err)
+			}
+
+			listener, err := net.Listen("tcp", *addr)
+			if err != nil {
+				return fmt.Errorf("mock serve: %w", err)
+			}
+			return serve(ctx, listener, server)
+		},
+	}
+}
+
+func newEmulator(seedPath string) (*ascmock.Server, error) {
+	index, err := schema.LoadEndpoints()
+	if err != nil {
+		return nil, err
+	}
+	endpoints := make([]ascmock.Endpoint, 0, len(index))
+	for _, endpoint := range index {
+		endpoints = append(endpoints, ascmock.Endpoint{Method: endpoint.Method, Path: endpoint.Path})
+	}
+
+	server := ascmock.NewServer(endpoints)
+	if seedPath = strings.TrimSpace(seedPath); seedPath != "" {
+		if err := server.SeedFile(seedPath); err != nil {
+			return nil, err
+		}
+	}
+	return server, nil
+}
+
+func serve(ctx context.Context, listener net.Listener, handler http.Handler) error {
+	httpServer := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	baseURL := "http://" + listener.Addr().String()
+	fmt.Fprintf(os.Stderr, "App Store Connect emulator listening on %s\n", baseURL)
+	fmt.Fprintf(os.Stderr, "Use it with: export ASC_BASE_URL=%s\n", baseURL)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("mock serve: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serverShutdownTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("mock serve: shutdown: %w", err)
+		}
+		return nil
+	}
+}
+
+// validateLoopbackAddr keeps the emulator off external interfaces; it
+// accepts unauthenticated state changes and must never be reachable remotely.
+func validateLoopbackAddr(addr string) error {
+	host, _, err := net.SplitHostPort(strings.TrimSpace(addr))
+	if err != nil {
+		return fmt.Errorf("--addr must be host:port: %v", err)
+	}
+	if strings.EqualFold(host, "localhost") {
+		return nil
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("--addr must use a loopback host (127.0.0.1, ::1 or localhost), got %q", host)
+	}
+	return nil
+}
@@ -174,7 +174,7 @@ Examples:
 					return fmt.Errorf("nominations list: failed to fetch: %w", err)
 				}
 
-				nominations, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				nominations, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetNominations(ctx, asc.WithNominationsNextURL(nextURL))
 				})
 				if err != nil {
@@ -149,7 +149,7 @@ Examples:
 					return fmt.Errorf("pass-type-ids list: failed to fetch: %w", err)
 				}
 
-				paginated, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				paginated, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetPassTypeIDs(ctx, asc.WithPassTypeIDsNextURL(nextURL))
 				})
 				if err != nil {
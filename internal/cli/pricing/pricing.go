@@ -126,7 +126,7 @@ Examples:
 					return fmt.Errorf("pricing territories list: failed to fetch: %w", err)
 				}
 
-				territories, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				territories, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetTerritories(ctx, asc.WithTerritoriesNextURL(nextURL))
 				})
 				if err != nil {
@@ -218,7 +218,7 @@ Examples:
 					return fmt.Errorf("pricing price-points: failed to fetch: %w", err)
 				}
 
-				points, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				points, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetAppPricePoints(ctx, resolvedAppID, asc.WithPricePointsNextURL(nextURL))
 				})
 				if err != nil {
@@ -475,7 +475,7 @@ Examples:
 					return fmt.Errorf("pricing schedule manual-prices: %w", err)
 				}
 
-				resp, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetAppPriceScheduleManualPrices(ctx, trimmedScheduleID, asc.WithAppPriceSchedulePricesNextURL(nextURL))
 				})
 				if err != nil {
@@ -565,7 +565,7 @@ Examples:
 					return fmt.Errorf("pricing schedule automatic-prices: %w", err)
 				}
 
-				resp, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetAppPriceScheduleAutomaticPrices(ctx, trimmedScheduleID, asc.WithAppPriceSchedulePricesNextURL(nextURL))
 				})
 				if err != nil {
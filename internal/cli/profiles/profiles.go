@@ -102,7 +102,7 @@ Examples:
 
 			if *paginate {
 				paginateOpts := append(opts, asc.WithProfilesLimit(200))
-				paginated, err := shared.PaginateWithSpinner(requestCtx,
+				paginated, err := shared.PaginateForOutput(requestCtx, *output.Output,
 					func(ctx context.Context) (asc.PaginatedResponse, error) {
 						return client.GetProfiles(ctx, paginateOpts...)
 					},
@@ -775,7 +775,7 @@ type publishUploadResult struct {
 }
 
 func uploadBuildAndWaitForID(ctx context.Context, client *asc.Client, appID, ipaPath string, fileInfo os.FileInfo, version, buildNumber string, platform asc.Platform, pollInterval time.Duration, uploadTimeout time.Duration, overrideUploadTimeout bool) (*publishUploadResult, error) {
-	uploadResp, fileResp, err := shared.PrepareBuildUpload(ctx, client, appID, fileInfo, version, buildNumber, platform, asc.UTIIPA)
+	uploadResp, fileResp, uploadState, err := shared.ReserveResumableBuildUpload(ctx, client, appID, ipaPath, fileInfo, version, buildNumber, platform, asc.UTIIPA)
 	if err != nil {
 		return nil, err
 	}
@@ -786,7 +786,7 @@ func uploadBuildAndWaitForID(ctx context.Context, client *asc.Client, appID, ipa
 
 	fmt.Fprintf(os.Stderr, "Uploading %s (%d bytes) to App Store Connect...\n", fileInfo.Name(), fileInfo.Size())
 	uploadCtx, uploadCancel := contextWithPublishUploadTimeout(ctx, uploadTimeout, overrideUploadTimeout)
-	err = asc.ExecuteUploadOperations(uploadCtx, ipaPath, fileResp.Data.Attributes.UploadOperations)
+	err = asc.ExecuteUploadOperations(uploadCtx, ipaPath, fileResp.Data.Attributes.UploadOperations, asc.WithUploadState(uploadState))
 	uploadCancel()
 	if err != nil {
 		return nil, err
@@ -798,6 +798,7 @@ func uploadBuildAndWaitForID(ctx context.Context, client *asc.Client, appID, ipa
 	if err != nil {
 		return nil, err
 	}
+	_ = uploadState.Remove()
 
 	fmt.Fprintln(os.Stderr, "Upload committed in App Store Connect.")
 	fmt.Fprintf(os.Stderr, "Waiting for build %s (%s) to appear in App Store Connect...\n", buildNumber, version)
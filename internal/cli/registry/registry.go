@@ -14,6 +14,7 @@ import (
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/alternativedistribution"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/analytics"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/androidiosmapping"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/api"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/app_events"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/appclips"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/apps"
@@ -24,6 +25,7 @@ import (
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/buildlocalizations"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/builds"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/bundleids"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/cache"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/categories"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/certificates"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/completion"
@@ -43,6 +45,7 @@ import (
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/merchantids"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/metadata"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/migrate"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/mock"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/nominations"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/notarization"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/notify"
@@ -67,6 +70,7 @@ import (
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/submit"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/subscriptions"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/testflight"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/undo"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/users"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/validate"
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/versions"
@@ -139,7 +143,7 @@ func Subcommands(version string) []*ffcli.Command {
 		buildbundles.BuildBundlesCommand(),
 		publish.PublishCommand(),
 		release.ReleaseCommand(),
-		workflow.WorkflowCommand(),
+		workflow.WorkflowCommand(func() []*ffcli.Command { return Subcommands(version) }),
 		xcode.XcodeCommand(),
 		versions.VersionsCommand(),
 		productpages.ProductPagesCommand(),
@@ -172,6 +176,10 @@ func Subcommands(version string) []*ffcli.Command {
 		notify.NotifyCommand(),
 		gamecenter.GameCenterCommand(),
 		schema.SchemaCommand(),
+		mock.MockCommand(),
+		cache.CacheCommand(),
+		api.APICommand(),
+		undo.UndoCommand(),
 		snitch.SnitchCommand(version),
 		VersionCommand(version),
 	}
@@ -84,7 +84,7 @@ Examples:
 
 			if *paginate {
 				paginateOpts := append(opts, asc.WithAppStoreReviewAttachmentsLimit(200))
-				pages, err := shared.PaginateWithSpinner(requestCtx,
+				pages, err := shared.PaginateForOutput(requestCtx, *output.Output,
 					func(ctx context.Context) (asc.PaginatedResponse, error) {
 						return client.GetAppStoreReviewAttachmentsForReviewDetail(ctx, reviewDetailValue, paginateOpts...)
 					},
@@ -83,7 +83,7 @@ Examples:
 				}
 
 				// Fetch all remaining pages
-				resp, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetSandboxTesters(ctx, asc.WithSandboxTestersNextURL(nextURL))
 				})
 				if err != nil {
@@ -36,6 +36,11 @@ type Parameter struct {
 	Required bool     `json:"required,omitempty"`
 }
 
+// LoadEndpoints returns the embedded App Store Connect endpoint index.
+func LoadEndpoints() ([]Endpoint, error) {
+	return loadIndex()
+}
+
 func loadIndex() ([]Endpoint, error) {
 	var endpoints []Endpoint
 	if err := json.Unmarshal(schemaIndexData, &endpoints); err != nil {
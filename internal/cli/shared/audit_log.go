@@ -0,0 +1,76 @@
+package shared
+
+import (
+	"os"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+const auditLogEnvVar = "ASC_AUDIT_LOG"
+
+// auditCommandLine is the invocation recorded with every audit entry.
+var auditCommandLine string
+
+// auditSensitiveFlags lists flag name fragments whose values are redacted
+// from the recorded command line.
+var auditSensitiveFlags = []string{"password", "secret", "token", "private-key"}
+
+// SetAuditCommandLine records the CLI arguments (without argv[0]) for the
+// audit log, redacting values of sensitive flags.
+func SetAuditCommandLine(args []string) {
+	redacted := make([]string, 0, len(args)+1)
+	redacted = append(redacted, "asc")
+	redactNext := false
+	for _, arg := range args {
+		// Boolean flags such as --password-stdin take no value.
+		if redactNext && !strings.HasPrefix(arg, "-") {
+			redacted = append(redacted, "[REDACTED]")
+			redactNext = false
+			continue
+		}
+		redactNext = false
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if strings.HasPrefix(arg, "-") && isAuditSensitiveFlag(name) {
+			if hasValue {
+				arg = arg[:strings.Index(arg, "=")+1] + "[REDACTED]"
+			} else {
+				redactNext = true
+			}
+		}
+		redacted = append(redacted, arg)
+	}
+	auditCommandLine = strings.Join(redacted, " ")
+}
+
+func isAuditSensitiveFlag(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range auditSensitiveFlags {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditLogPath returns the configured audit log file (ASC_AUDIT_LOG, then
+// audit_log in config.json), or "" when auditing is disabled.
+func AuditLogPath() string {
+	if value := strings.TrimSpace(os.Getenv(auditLogEnvVar)); value != "" {
+		return value
+	}
+	cfg, _, err := loadConfigForCredentialMetadata()
+	if err != nil || cfg == nil {
+		return ""
+	}
+	return strings.TrimSpace(cfg.AuditLog)
+}
+
+func attachAuditLog(client *asc.Client, profile string) {
+	if client == nil {
+		return
+	}
+	if path := AuditLogPath(); path != "" {
+		client.SetAuditLog(asc.NewAuditLog(path, auditCommandLine, profile))
+	}
+}
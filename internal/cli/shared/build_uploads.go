@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
 )
@@ -57,6 +58,69 @@ func PrepareBuildUpload(ctx context.Context, client *asc.Client, appID string, f
 	return uploadResp, fileResp, nil
 }
 
+// ReserveResumableBuildUpload resumes the reservation saved by an earlier,
+// interrupted upload of the same file to the same app/version/build, or
+// creates a new one and saves it. The returned state (nil when resuming is
+// unavailable) should be passed to asc.ExecuteUploadOperations and removed
+// once the file is committed.
+func ReserveResumableBuildUpload(ctx context.Context, client *asc.Client, appID, filePath string, fileInfo os.FileInfo, version, buildNumber string, platform asc.Platform, uti asc.UTI) (*asc.BuildUploadResponse, *asc.BuildUploadFileResponse, *asc.UploadState, error) {
+	store := UploadStateStore()
+	scope := strings.Join([]string{appID, string(platform), version, buildNumber}, "/")
+	var checksum string
+	if store != nil {
+		sum, err := asc.ComputeFileChecksum(filePath, asc.ChecksumAlgorithmMD5)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		checksum = sum.Hash
+	}
+
+	state, err := store.Load(asc.UploadStateKindBuild, scope, checksum, fileInfo.Size())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring upload state: %v\n", err)
+	}
+	if state != nil {
+		uploadResp, fileResp := resumeBuildUpload(ctx, client, state)
+		if uploadResp != nil {
+			fmt.Fprintf(os.Stderr, "Resuming upload %s (%d of %d parts already uploaded)\n", state.UploadID, state.CompletedCount(), len(state.Operations))
+			return uploadResp, fileResp, state, nil
+		}
+		_ = state.Remove()
+	}
+
+	uploadResp, fileResp, err := PrepareBuildUpload(ctx, client, appID, fileInfo, version, buildNumber, platform, uti)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	state, err = store.Begin(asc.UploadStateKindBuild, scope, checksum, fileInfo.Size(), uploadResp.Data.ID, fileResp.Data.ID, fileResp.Data.Attributes.UploadOperations)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: upload will not be resumable: %v\n", err)
+	}
+	return uploadResp, fileResp, state, nil
+}
+
+// resumeBuildUpload fetches the saved reservation and returns nil responses
+// when it can no longer receive parts (deleted, failed, or already committed).
+func resumeBuildUpload(ctx context.Context, client *asc.Client, state *asc.UploadState) (*asc.BuildUploadResponse, *asc.BuildUploadFileResponse) {
+	uploadResp, err := client.GetBuildUpload(ctx, state.UploadID)
+	if err != nil || uploadResp.Data.ID != state.UploadID {
+		return nil, nil
+	}
+	if uploadState := uploadResp.Data.Attributes.State; uploadState != nil && uploadState.State != nil &&
+		strings.EqualFold(*uploadState.State, "FAILED") {
+		return nil, nil
+	}
+	fileResp, err := client.GetBuildUploadFile(ctx, state.FileID)
+	if err != nil || fileResp.Data.ID != state.FileID {
+		return nil, nil
+	}
+	if uploaded := fileResp.Data.Attributes.Uploaded; uploaded != nil && *uploaded {
+		return nil, nil
+	}
+	fileResp.Data.Attributes.UploadOperations = state.Operations
+	return uploadResp, fileResp
+}
+
 // CommitBuildUploadFile marks a reserved upload file as uploaded and optionally
 // persists source-file checksums.
 func CommitBuildUploadFile(ctx context.Context, client *asc.Client, fileID string, checksums *asc.Checksums) (*asc.BuildUploadFileResponse, error) {
@@ -0,0 +1,59 @@
+package shared
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+var (
+	recordCassettePath string
+	replayCassettePath string
+)
+
+// BindCassetteFlags registers HTTP record/replay flags.
+func BindCassetteFlags(fs *flag.FlagSet) {
+	fs.StringVar(&recordCassettePath, "record", "", "Record every App Store Connect HTTP interaction to a cassette file")
+	fs.StringVar(&replayCassettePath, "replay", "", "Replay App Store Connect HTTP interactions from a cassette file (no network)")
+}
+
+// StartCassette installs the cassette selected by --record/--replay.
+// The returned finish function saves recordings and must be called once the
+// command has finished; it is a no-op when no cassette flag was given.
+func StartCassette() (func() error, error) {
+	record := strings.TrimSpace(recordCassettePath)
+	replay := strings.TrimSpace(replayCassettePath)
+	if record != "" && replay != "" {
+		return nil, fmt.Errorf("--record and --replay are mutually exclusive")
+	}
+
+	var (
+		cassette *asc.Cassette
+		err      error
+	)
+	switch {
+	case record != "":
+		cassette, err = asc.OpenCassette(asc.CassetteModeRecord, record)
+	case replay != "":
+		cassette, err = asc.OpenCassette(asc.CassetteModeReplay, replay)
+	default:
+		return func() error { return nil }, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	asc.SetActiveCassette(cassette)
+	return func() error {
+		asc.SetActiveCassette(nil)
+		return cassette.Save()
+	}, nil
+}
+
+// SetCassettePaths sets the --record/--replay values (tests only).
+func SetCassettePaths(record, replay string) {
+	recordCassettePath = record
+	replayCassettePath = replay
+}
@@ -172,7 +172,7 @@ func BuildPaginatedListCommand(config PaginatedListCommandConfig) *ffcli.Command
 				firstPageLimit = limitMax
 			}
 
-			resp, err := PaginateWithSpinner(requestCtx,
+			resp, err := PaginateForOutput(requestCtx, *output.Output,
 				func(ctx context.Context) (asc.PaginatedResponse, error) {
 					return config.FetchPage(ctx, client, resolvedParentID, firstPageLimit, *next)
 				},
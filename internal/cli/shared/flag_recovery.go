@@ -43,3 +43,10 @@ func RecoverBoolFlagTailArgs(original *flag.FlagSet, args []string, currentValue
 	}
 	return nil
 }
+
+// ParseTrailingFlags parses flags that follow a command's positional
+// arguments into original's flag values. It uses a ContinueOnError copy, so a
+// bad flag is returned as a usage error instead of exiting the process.
+func ParseTrailingFlags(original *flag.FlagSet, args []string) error {
+	return RecoverBoolFlagTailArgs(original, args, nil)
+}
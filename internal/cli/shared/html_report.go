@@ -0,0 +1,172 @@
+package shared
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+)
+
+// HTML report tones map to the card, list and cell colors of the report.
+const (
+	HTMLToneNeutral = ""
+	HTMLToneGood    = "good"
+	HTMLToneWarning = "warning"
+	HTMLToneBad     = "bad"
+)
+
+// HTMLReport is a self-contained, offline HTML summary rendered by commands
+// that support --output html (status, validate, insights).
+type HTMLReport struct {
+	Title       string
+	Subtitle    string
+	GeneratedAt string
+	Cards       []HTMLReportCard
+	Sections    []HTMLReportSection
+}
+
+// HTMLReportCard is a headline number or state shown at the top of a report.
+type HTMLReportCard struct {
+	Label  string
+	Value  string
+	Detail string
+	Tone   string
+}
+
+// HTMLReportSection groups a list, ordered steps and/or a table under a heading.
+type HTMLReportSection struct {
+	Title string
+	Intro string
+	Items []HTMLReportItem
+	Steps []HTMLReportItem
+	Table *HTMLReportTable
+	Empty string
+}
+
+// HTMLReportItem is one bullet or step, with optional secondary text.
+type HTMLReportItem struct {
+	Text   string
+	Detail string
+	Tone   string
+}
+
+// HTMLReportTable is a simple table of text cells.
+type HTMLReportTable struct {
+	Headers []string
+	Rows    [][]HTMLReportCell
+}
+
+// HTMLReportCell is a table cell.
+type HTMLReportCell struct {
+	Text string
+	Tone string
+}
+
+// Cells converts plain strings to neutral table cells.
+func Cells(values ...string) []HTMLReportCell {
+	cells := make([]HTMLReportCell, 0, len(values))
+	for _, value := range values {
+		cells = append(cells, HTMLReportCell{Text: value})
+	}
+	return cells
+}
+
+// RenderHTMLReport renders report as a standalone HTML document.
+func RenderHTMLReport(report HTMLReport) (string, error) {
+	tmpl, err := template.New("report").Parse(htmlReportTemplate)
+	if err != nil {
+		return "", fmt.Errorf("parse HTML report template: %w", err)
+	}
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, report); err != nil {
+		return "", fmt.Errorf("render HTML report: %w", err)
+	}
+	return builder.String(), nil
+}
+
+// PrintHTMLReport writes report to stdout as a standalone HTML document.
+func PrintHTMLReport(report HTMLReport) error {
+	html, err := RenderHTMLReport(report)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(os.Stdout, html)
+	return err
+}
+
+const htmlReportTemplate = `<!doctype html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <meta name="viewport" content="width=device-width, initial-scale=1" />
+  <title>{{.Title}}</title>
+  <style>
+    body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 24px auto; max-width: 960px; padding: 0 16px; color: #1f2937; background: #ffffff; }
+    h1 { margin: 0 0 6px 0; font-size: 26px; }
+    h2 { margin: 28px 0 10px 0; font-size: 18px; border-bottom: 1px solid #e5e7eb; padding-bottom: 6px; }
+    .meta { color: #4b5563; font-size: 14px; margin-bottom: 18px; }
+    .cards { display: grid; grid-template-columns: repeat(auto-fit, minmax(160px, 1fr)); gap: 10px; }
+    .card { border: 1px solid #e5e7eb; border-left-width: 4px; border-radius: 8px; padding: 12px; }
+    .label { font-size: 12px; color: #6b7280; text-transform: uppercase; letter-spacing: 0.04em; }
+    .value { font-size: 22px; font-weight: 700; margin-top: 4px; word-break: break-word; }
+    .detail { font-size: 13px; color: #6b7280; margin-top: 4px; }
+    .intro { color: #4b5563; font-size: 14px; }
+    ul, ol { padding-left: 22px; }
+    li { margin: 6px 0; }
+    table { width: 100%; border-collapse: collapse; }
+    th, td { border: 1px solid #e5e7eb; padding: 7px 9px; vertical-align: top; text-align: left; font-size: 13px; }
+    th { background: #f9fafb; }
+    .empty { color: #9ca3af; font-style: italic; }
+    .tone-good { border-left-color: #16a34a; color: #166534; }
+    .tone-warning { border-left-color: #d97706; color: #92400e; }
+    .tone-bad { border-left-color: #dc2626; color: #991b1b; }
+    .card .label, .card .detail { color: #6b7280; }
+    code { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; }
+  </style>
+</head>
+<body>
+  <h1>{{.Title}}</h1>
+  <div class="meta">{{if .Subtitle}}{{.Subtitle}}<br />{{end}}{{if .GeneratedAt}}Generated at {{.GeneratedAt}}{{end}}</div>
+{{- if .Cards}}
+  <div class="cards">
+{{- range .Cards}}
+    <div class="card{{if .Tone}} tone-{{.Tone}}{{end}}"><div class="label">{{.Label}}</div><div class="value">{{.Value}}</div>{{if .Detail}}<div class="detail">{{.Detail}}</div>{{end}}</div>
+{{- end}}
+  </div>
+{{- end}}
+{{- range .Sections}}
+  <h2>{{.Title}}</h2>
+{{- if .Intro}}
+  <p class="intro">{{.Intro}}</p>
+{{- end}}
+{{- if .Items}}
+  <ul>
+{{- range .Items}}
+    <li{{if .Tone}} class="tone-{{.Tone}}"{{end}}>{{.Text}}{{if .Detail}}<div class="detail">{{.Detail}}</div>{{end}}</li>
+{{- end}}
+  </ul>
+{{- end}}
+{{- if .Steps}}
+  <ol>
+{{- range .Steps}}
+    <li{{if .Tone}} class="tone-{{.Tone}}"{{end}}>{{.Text}}{{if .Detail}}<div class="detail">{{.Detail}}</div>{{end}}</li>
+{{- end}}
+  </ol>
+{{- end}}
+{{- with .Table}}
+  <table>
+    <thead><tr>{{range .Headers}}<th>{{.}}</th>{{end}}</tr></thead>
+    <tbody>
+{{- range .Rows}}
+      <tr>{{range .}}<td{{if .Tone}} class="tone-{{.Tone}}"{{end}}>{{.Text}}</td>{{end}}</tr>
+{{- end}}
+    </tbody>
+  </table>
+{{- end}}
+{{- if and .Empty (not .Items) (not .Steps) (not .Table)}}
+  <p class="empty">{{.Empty}}</p>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`
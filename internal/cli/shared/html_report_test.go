@@ -0,0 +1,44 @@
+package shared
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHTMLReport_RendersCardsSectionsAndEscapes(t *testing.T) {
+	html, err := RenderHTMLReport(HTMLReport{
+		Title:       "Release <Status>",
+		GeneratedAt: "2026-02-20T00:00:00Z",
+		Cards: []HTMLReportCard{
+			{Label: "Health", Value: "RED", Tone: HTMLToneBad},
+		},
+		Sections: []HTMLReportSection{
+			{Title: "Blockers", Items: []HTMLReportItem{{Text: "Build is <invalid>", Tone: HTMLToneBad}}},
+			{Title: "Next Step", Steps: []HTMLReportItem{{Text: "Upload a build", Detail: "asc builds upload"}}},
+			{Title: "Builds", Table: &HTMLReportTable{Headers: []string{"Field", "Value"}, Rows: [][]HTMLReportCell{Cells("Version", "1.2.0")}}},
+			{Title: "Review", Empty: "No submissions."},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RenderHTMLReport error: %v", err)
+	}
+
+	for _, want := range []string{
+		"<!doctype html>",
+		"<title>Release &lt;Status&gt;</title>",
+		`<div class="card tone-bad"><div class="label">Health</div><div class="value">RED</div>`,
+		`<li class="tone-bad">Build is &lt;invalid&gt;</li>`,
+		`<ol>`,
+		`<div class="detail">asc builds upload</div>`,
+		`<tr><td>Version</td><td>1.2.0</td></tr>`,
+		`<p class="empty">No submissions.</p>`,
+		"Generated at 2026-02-20T00:00:00Z",
+	} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("expected HTML to contain %q:\n%s", want, html)
+		}
+	}
+	if strings.Contains(html, "<script") || strings.Contains(html, "http://") || strings.Contains(html, "https://") {
+		t.Fatalf("expected a self-contained report without scripts or remote assets:\n%s", html)
+	}
+}
@@ -0,0 +1,229 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// activeOutputTemplate is the template compiled from the executing command's
+// --template/--template-file flags; it is reset before each command runs.
+var activeOutputTemplate *template.Template
+
+type outputTemplateFlags struct {
+	text *string
+	file *string
+}
+
+func bindOutputTemplateFlags(fs *flag.FlagSet) outputTemplateFlags {
+	text := ""
+	file := ""
+	fs.StringVar(&text, "template", "", "Go template used with --output template (e.g. '{{range .data}}{{.id}}{{\"\\n\"}}{{end}}')")
+	fs.StringVar(&file, "template-file", "", "Path to a Go template file used with --output template")
+	return outputTemplateFlags{text: &text, file: &file}
+}
+
+// resolve compiles the template flags for format. It returns nil when format
+// is not "template".
+func (f outputTemplateFlags) resolve(format string) (*template.Template, error) {
+	text := strings.TrimSpace(derefString(f.text))
+	file := strings.TrimSpace(derefString(f.file))
+	if format != "template" {
+		if text != "" || file != "" {
+			return nil, fmt.Errorf("--template and --template-file require --output template")
+		}
+		return nil, nil
+	}
+
+	switch {
+	case text != "" && file != "":
+		return nil, fmt.Errorf("--template and --template-file are mutually exclusive")
+	case text == "" && file == "":
+		return nil, fmt.Errorf("--output template requires --template or --template-file")
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("--template-file: %w", err)
+		}
+		return parseOutputTemplate("--template-file", string(data))
+	}
+	return parseOutputTemplate("--template", *f.text)
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func parseOutputTemplate(flagName, text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Funcs(outputTemplateFuncs()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", flagName, err)
+	}
+	return tmpl, nil
+}
+
+// renderOutputTemplate executes the active template against the JSON form of
+// data, so templates address fields by their JSON names (.data, .attributes).
+func renderOutputTemplate(data any) error {
+	if activeOutputTemplate == nil {
+		return fmt.Errorf("--output template requires --template or --template-file")
+	}
+	value, err := templateValue(data)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := activeOutputTemplate.Execute(&buf, value); err != nil {
+		return fmt.Errorf("render template: %w", err)
+	}
+	_, err = os.Stdout.Write(buf.Bytes())
+	return err
+}
+
+func templateValue(data any) (any, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("render template: %w", err)
+	}
+	var value any
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return nil, fmt.Errorf("render template: %w", err)
+	}
+	return value, nil
+}
+
+// outputTemplateFuncs returns the helpers available to --template. Helpers
+// take the piped value last so they compose: {{.attributes.name | truncate 20}}.
+func outputTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"date":       templateDate,
+		"ago":        templateAgo,
+		"now":        func() time.Time { return time.Now().UTC() },
+		"truncate":   templateTruncate,
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"trim":       strings.TrimSpace,
+		"replace":    func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+		"join":       templateJoin,
+		"default":    templateDefault,
+		"json":       templateJSON,
+		"prettyJSON": templatePrettyJSON,
+	}
+}
+
+func templateTime(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.000-0700", "2006-01-02"} {
+			if parsed, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return parsed, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// templateDate reformats an API timestamp with a Go layout; values that are
+// not timestamps are returned unchanged.
+func templateDate(layout string, value any) string {
+	parsed, ok := templateTime(value)
+	if !ok {
+		return templateText(value)
+	}
+	return parsed.Format(layout)
+}
+
+func templateAgo(value any) string {
+	parsed, ok := templateTime(value)
+	if !ok {
+		return templateText(value)
+	}
+	elapsed := time.Since(parsed)
+	suffix := "ago"
+	if elapsed < 0 {
+		elapsed = -elapsed
+		suffix = "from now"
+	}
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%dm %s", int(elapsed.Minutes()), suffix)
+	case elapsed < 24*time.Hour:
+		return fmt.Sprintf("%dh %s", int(elapsed.Hours()), suffix)
+	default:
+		return fmt.Sprintf("%dd %s", int(elapsed.Hours()/24), suffix)
+	}
+}
+
+func templateTruncate(length int, value any) string {
+	runes := []rune(templateText(value))
+	if length < 0 || len(runes) <= length {
+		return string(runes)
+	}
+	if length <= 3 {
+		return string(runes[:length])
+	}
+	return string(runes[:length-3]) + "..."
+}
+
+func templateJoin(sep string, value any) string {
+	items, ok := value.([]any)
+	if !ok {
+		return templateText(value)
+	}
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, templateText(item))
+	}
+	return strings.Join(parts, sep)
+}
+
+func templateDefault(fallback, value any) any {
+	if value == nil {
+		return fallback
+	}
+	if s, ok := value.(string); ok && s == "" {
+		return fallback
+	}
+	return value
+}
+
+func templateJSON(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func templatePrettyJSON(value any) (string, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func templateText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
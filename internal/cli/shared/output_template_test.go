@@ -0,0 +1,127 @@
+package shared
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func bindTemplateOutputForTest(t *testing.T, args ...string) error {
+	t.Helper()
+	t.Cleanup(func() { activeOutputTemplate = nil })
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	BindOutputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	return ValidateBoundOutputFlags(fs)
+}
+
+func TestBindOutputFlags_TemplateValidation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "apps.tmpl")
+	if err := os.WriteFile(path, []byte("{{len .data}}"), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{args: []string{"--output", "template", "--template", "{{.data}}"}},
+		{args: []string{"--output", "template", "--template-file", path}},
+		{args: []string{"--output", "template"}, wantErr: "requires --template or --template-file"},
+		{args: []string{"--output", "json", "--template", "{{.data}}"}, wantErr: "require --output template"},
+		{args: []string{"--output", "template", "--template", "x", "--template-file", path}, wantErr: "mutually exclusive"},
+		{args: []string{"--output", "template", "--template", "{{.data"}, wantErr: "--template:"},
+		{args: []string{"--output", "template", "--template-file", filepath.Join(dir, "missing")}, wantErr: "--template-file:"},
+		{args: []string{"--output", "template", "--template", "x", "--pretty"}, wantErr: "--pretty is only valid with JSON output"},
+	}
+	for _, tc := range tests {
+		err := bindTemplateOutputForTest(t, tc.args...)
+		if tc.wantErr == "" {
+			if err != nil {
+				t.Fatalf("args %v: unexpected error %v", tc.args, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Fatalf("args %v: expected %q, got %v", tc.args, tc.wantErr, err)
+		}
+	}
+}
+
+func TestPrintOutput_TemplateRendersJSONFields(t *testing.T) {
+	if err := bindTemplateOutputForTest(t, "--output", "template", "--template",
+		`{{range .data}}{{.id}}:{{.attributes.name | upper}} {{.attributes.sku | truncate 2}}{{"\n"}}{{end}}{{.links | json}}`); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(projectionTestApps(), "template", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	want := "1:ONE ON\n2:TWO, INC TW\n" + `{"self":"https://api.example.com/v1/apps"}`
+	if stdout != want {
+		t.Fatalf("stdout = %q, want %q", stdout, want)
+	}
+}
+
+func TestPrintOutput_TemplateAppliesProjection(t *testing.T) {
+	setProjectionForTest(t, "", "data[?attributes.sku == 'TWO'] | [0]")
+	if err := bindTemplateOutputForTest(t, "--output", "template", "--template", "{{.attributes.bundleId}}"); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(projectionTestApps(), "template", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	if stdout != "com.two" {
+		t.Fatalf("stdout = %q, want %q", stdout, "com.two")
+	}
+}
+
+func TestPrintOutput_TemplateRequiresActiveTemplate(t *testing.T) {
+	activeOutputTemplate = nil
+	err := PrintOutput(&asc.AppsResponse{}, "template", false)
+	if err == nil || !strings.Contains(err.Error(), "requires --template") {
+		t.Fatalf("expected missing template error, got %v", err)
+	}
+}
+
+func TestOutputTemplateFuncs(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"date", templateDate("Jan 2, 2006", "2026-03-04T10:20:30Z"), "Mar 4, 2026"},
+		{"date passthrough", templateDate("2006", "not a date"), "not a date"},
+		{"ago", templateAgo(time.Now().Add(-49 * time.Hour).UTC().Format(time.RFC3339)), "2d ago"},
+		{"truncate", templateTruncate(6, "changelog"), "cha..."},
+		{"truncate short", templateTruncate(20, "notes"), "notes"},
+		{"truncate number", templateTruncate(10, 42.0), "42"},
+		{"join", templateJoin(", ", []any{"a", 2.5, nil}), "a, 2.5, "},
+		{"default", templateText(templateDefault("n/a", nil)), "n/a"},
+		{"default keeps value", templateText(templateDefault("n/a", "set")), "set"},
+	}
+	for _, tc := range tests {
+		if tc.got != tc.want {
+			t.Fatalf("%s = %q, want %q", tc.name, tc.got, tc.want)
+		}
+	}
+
+	pretty, err := templatePrettyJSON(map[string]any{"a": 1})
+	if err != nil || pretty != "{\n  \"a\": 1\n}" {
+		t.Fatalf("prettyJSON = %q, %v", pretty, err)
+	}
+}
@@ -0,0 +1,51 @@
+package shared
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+var planMode bool
+
+// BindPlanFlag registers the --plan root flag.
+func BindPlanFlag(fs *flag.FlagSet) {
+	fs.BoolVar(&planMode, "plan", false, "Preview mutating requests without sending them; prints the plan to stderr")
+}
+
+// planOutput is the document printed when a --plan run finishes.
+type planOutput struct {
+	Requests []asc.PlannedRequest `json:"requests"`
+}
+
+// StartPlan installs a plan when --plan is set. The returned finish function
+// prints the intercepted requests to stderr and must be called once the
+// command has finished; it is a no-op without --plan.
+func StartPlan() func() error {
+	if !planMode {
+		return func() error { return nil }
+	}
+	plan := asc.NewPlan()
+	asc.SetActivePlan(plan)
+	return func() error {
+		asc.SetActivePlan(nil)
+		return writePlan(os.Stderr, plan)
+	}
+}
+
+func writePlan(w io.Writer, plan *asc.Plan) error {
+	requests := plan.Requests()
+	if requests == nil {
+		requests = []asc.PlannedRequest{}
+	}
+	data, err := json.MarshalIndent(planOutput{Requests: requests}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
@@ -0,0 +1,89 @@
+package shared
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/auth"
+)
+
+var (
+	allProfiles    bool
+	profilesFanout string
+)
+
+// BindProfileFanoutFlags registers the --all-profiles/--profiles root flags.
+func BindProfileFanoutFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&allProfiles, "all-profiles", false, "Run a read-only command once per stored profile and merge the results")
+	fs.StringVar(&profilesFanout, "profiles", "", "Run a read-only command once per listed profile (comma-separated) and merge the results")
+}
+
+// ValidateProfileFanoutFlags rejects conflicting profile selections.
+func ValidateProfileFanoutFlags() error {
+	listed := strings.TrimSpace(profilesFanout) != ""
+	if !allProfiles && !listed {
+		return nil
+	}
+	if allProfiles && listed {
+		return fmt.Errorf("--all-profiles and --profiles are mutually exclusive")
+	}
+	if strings.TrimSpace(selectedProfile) != "" {
+		return fmt.Errorf("--profile cannot be combined with --all-profiles or --profiles")
+	}
+	if listed && len(splitProfileList(profilesFanout)) == 0 {
+		return fmt.Errorf("--profiles requires at least one profile name")
+	}
+	return nil
+}
+
+// SetProfileFanoutFlags sets the --all-profiles/--profiles values (tests only).
+func SetProfileFanoutFlags(all bool, profiles string) {
+	allProfiles = all
+	profilesFanout = profiles
+}
+
+// FanoutProfiles returns the profiles selected by --all-profiles or
+// --profiles, or nil when neither flag is set. --all-profiles expands to every
+// stored profile name in sorted order.
+func FanoutProfiles() ([]string, error) {
+	if strings.TrimSpace(profilesFanout) != "" {
+		return splitProfileList(profilesFanout), nil
+	}
+	if !allProfiles {
+		return nil, nil
+	}
+
+	credentials, err := listCredentialSummariesFn()
+	if err != nil {
+		var warning *auth.CredentialsWarning
+		if !errors.As(err, &warning) {
+			return nil, fmt.Errorf("--all-profiles: %w", err)
+		}
+	}
+	names := make([]string, 0, len(credentials))
+	for _, cred := range credentials {
+		if name := strings.TrimSpace(cred.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("--all-profiles: no stored profiles found (see 'asc auth login')")
+	}
+	return names, nil
+}
+
+func splitProfileList(value string) []string {
+	var names []string
+	for _, part := range strings.Split(value, ",") {
+		name := strings.TrimSpace(part)
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,72 @@
+package shared
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/auth"
+)
+
+func TestFanoutProfiles(t *testing.T) {
+	previousList := listCredentialSummariesFn
+	listCredentialSummariesFn = func() ([]auth.Credential, error) {
+		return []auth.Credential{{Name: "team-b"}, {Name: "team-a"}, {Name: " "}, {Name: "team-b"}}, nil
+	}
+	t.Cleanup(func() {
+		listCredentialSummariesFn = previousList
+		SetProfileFanoutFlags(false, "")
+	})
+
+	SetProfileFanoutFlags(false, "")
+	if profiles, err := FanoutProfiles(); err != nil || profiles != nil {
+		t.Fatalf("FanoutProfiles() = %v, %v; want nil without fan-out flags", profiles, err)
+	}
+
+	SetProfileFanoutFlags(true, "")
+	profiles, err := FanoutProfiles()
+	if err != nil || !slices.Equal(profiles, []string{"team-a", "team-b"}) {
+		t.Fatalf("FanoutProfiles() = %v, %v; want sorted unique stored profiles", profiles, err)
+	}
+
+	SetProfileFanoutFlags(false, "x, y,,x")
+	profiles, err = FanoutProfiles()
+	if err != nil || !slices.Equal(profiles, []string{"x", "y"}) {
+		t.Fatalf("FanoutProfiles() = %v, %v; want listed profiles in order", profiles, err)
+	}
+
+	listCredentialSummariesFn = func() ([]auth.Credential, error) { return nil, nil }
+	SetProfileFanoutFlags(true, "")
+	if _, err := FanoutProfiles(); err == nil || !strings.Contains(err.Error(), "no stored profiles") {
+		t.Fatalf("expected no stored profiles error, got %v", err)
+	}
+
+	listCredentialSummariesFn = func() ([]auth.Credential, error) { return nil, errors.New("keychain locked") }
+	if _, err := FanoutProfiles(); err == nil || !strings.Contains(err.Error(), "keychain locked") {
+		t.Fatalf("expected listing error, got %v", err)
+	}
+}
+
+func TestValidateProfileFanoutFlags(t *testing.T) {
+	t.Cleanup(func() {
+		SetProfileFanoutFlags(false, "")
+		SetSelectedProfile("")
+	})
+
+	SetProfileFanoutFlags(false, ",")
+	if err := ValidateProfileFanoutFlags(); err == nil {
+		t.Fatal("expected an error for an empty --profiles list")
+	}
+
+	SetProfileFanoutFlags(false, "a")
+	SetSelectedProfile("a")
+	if err := ValidateProfileFanoutFlags(); err == nil || !strings.Contains(err.Error(), "--profile cannot be combined") {
+		t.Fatalf("expected --profile conflict, got %v", err)
+	}
+
+	SetSelectedProfile("")
+	if err := ValidateProfileFanoutFlags(); err != nil {
+		t.Fatalf("ValidateProfileFanoutFlags() error: %v", err)
+	}
+}
@@ -0,0 +1,314 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared/query"
+)
+
+var (
+	outputFields string
+	outputQuery  string
+)
+
+// BindProjectionFlags registers the --fields/--query root flags.
+func BindProjectionFlags(fs *flag.FlagSet) {
+	fs.StringVar(&outputFields, "fields", "", "Comma-separated fields to keep per resource (e.g. id,attributes.name)")
+	fs.StringVar(&outputQuery, "query", "", "JMESPath-style expression applied to command output before rendering")
+}
+
+// ValidateProjectionFlags rejects malformed --fields and --query values.
+func ValidateProjectionFlags() error {
+	_, err := resolveOutputProjection()
+	return err
+}
+
+// SetProjectionFlags sets the --fields/--query values (tests only).
+func SetProjectionFlags(fields, expression string) {
+	outputFields = fields
+	outputQuery = expression
+}
+
+type outputProjection struct {
+	fields [][]string
+	names  []string
+	query  *query.Expression
+}
+
+// resolveOutputProjection returns nil when neither --fields nor --query is set.
+func resolveOutputProjection() (*outputProjection, error) {
+	fieldsValue := strings.TrimSpace(outputFields)
+	queryValue := strings.TrimSpace(outputQuery)
+	if fieldsValue == "" && queryValue == "" {
+		return nil, nil
+	}
+
+	projection := &outputProjection{}
+	if queryValue != "" {
+		expr, err := query.Compile(queryValue)
+		if err != nil {
+			return nil, fmt.Errorf("--query: %w", err)
+		}
+		projection.query = expr
+	}
+	for _, field := range SplitCSV(fieldsValue) {
+		segments := strings.Split(field, ".")
+		for _, segment := range segments {
+			if strings.TrimSpace(segment) == "" {
+				return nil, fmt.Errorf("--fields: invalid field path %q", field)
+			}
+		}
+		projection.fields = append(projection.fields, segments)
+		projection.names = append(projection.names, field)
+	}
+	if fieldsValue != "" && len(projection.fields) == 0 {
+		return nil, fmt.Errorf("--fields requires at least one field")
+	}
+	return projection, nil
+}
+
+// printProjectedOutput applies --query/--fields to data and renders the
+// result. It reports false when no projection is configured.
+func printProjectedOutput(data any, format string, pretty bool) (bool, error) {
+	projection, err := resolveOutputProjection()
+	if err != nil || projection == nil {
+		return false, err
+	}
+	value, err := projection.apply(data)
+	if err != nil {
+		return true, err
+	}
+	return true, projection.print(value, format, pretty)
+}
+
+// apply runs --query and then --fields against the JSON form of data.
+func (p *outputProjection) apply(data any) (any, error) {
+	value, err := query.FromValue(data)
+	if err != nil {
+		return nil, fmt.Errorf("project output: %w", err)
+	}
+	if p.query != nil {
+		value, err = p.query.Search(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(p.fields) == 0 {
+		return value, nil
+	}
+
+	switch v := value.(type) {
+	case []any:
+		return p.projectItems(v), nil
+	case *query.Object:
+		inner, ok := v.Get("data")
+		if !ok {
+			return p.projectItem(v), nil
+		}
+		projected := query.NewObject()
+		for _, key := range v.Keys {
+			if key != "data" {
+				projected.Set(key, v.Values[key])
+				continue
+			}
+			switch items := inner.(type) {
+			case []any:
+				projected.Set(key, p.projectItems(items))
+			default:
+				projected.Set(key, p.projectItem(items))
+			}
+		}
+		return projected, nil
+	}
+	return value, nil
+}
+
+func (p *outputProjection) projectItems(items []any) []any {
+	projected := make([]any, 0, len(items))
+	for _, item := range items {
+		projected = append(projected, p.projectItem(item))
+	}
+	return projected
+}
+
+// projectItem keeps only the selected paths of an object, preserving nesting
+// (attributes.name stays under "attributes"). Non-objects pass through.
+func (p *outputProjection) projectItem(item any) any {
+	obj, ok := item.(*query.Object)
+	if !ok {
+		return item
+	}
+	projected := query.NewObject()
+	for _, path := range p.fields {
+		value, found := lookupPath(obj, path)
+		if !found {
+			continue
+		}
+		target := projected
+		for _, segment := range path[:len(path)-1] {
+			next, ok := target.Get(segment)
+			nested, isObject := next.(*query.Object)
+			if !ok || !isObject {
+				nested = query.NewObject()
+				target.Set(segment, nested)
+			}
+			target = nested
+		}
+		target.Set(path[len(path)-1], value)
+	}
+	return projected
+}
+
+func lookupPath(value any, path []string) (any, bool) {
+	current := value
+	for _, segment := range path {
+		obj, ok := current.(*query.Object)
+		if !ok {
+			return nil, false
+		}
+		current, ok = obj.Get(segment)
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// print renders a projected value in format. Tabular formats use one row per
+// resource: --fields defines the columns, otherwise columns are the flattened
+// scalar paths in first-seen order.
+func (p *outputProjection) print(value any, format string, pretty bool) error {
+	switch format {
+	case "json":
+		return printJSONOutput(value, pretty)
+	case "ndjson":
+		enc := json.NewEncoder(os.Stdout)
+		for _, item := range projectedItems(value) {
+			if err := enc.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "template":
+		return renderOutputTemplate(value)
+	}
+
+	headers, rows := p.rows(projectedItems(value))
+	switch format {
+	case "table":
+		asc.RenderTable(headers, rows)
+	case "markdown":
+		asc.RenderMarkdown(headers, rows)
+	case "csv":
+		asc.RenderCSV(headers, rows)
+	case "tsv":
+		asc.RenderTSV(headers, rows)
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+	return nil
+}
+
+// projectedItems returns the resources in a projected value: the elements of
+// a top-level array or of a "data" array, or the value itself.
+func projectedItems(value any) []any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		return v
+	case *query.Object:
+		if inner, ok := v.Get("data"); ok {
+			if items, ok := inner.([]any); ok {
+				return items
+			}
+			return []any{inner}
+		}
+	}
+	return []any{value}
+}
+
+func (p *outputProjection) rows(items []any) ([]string, [][]string) {
+	if len(p.names) > 0 {
+		rows := make([][]string, 0, len(items))
+		for _, item := range items {
+			row := make([]string, len(p.fields))
+			for i, path := range p.fields {
+				if value, ok := lookupPath(item, path); ok {
+					row[i] = projectionCellText(value)
+				}
+			}
+			rows = append(rows, row)
+		}
+		return p.names, rows
+	}
+
+	var headers []string
+	seen := map[string]bool{}
+	flattened := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		cells := map[string]string{}
+		obj, ok := item.(*query.Object)
+		if !ok {
+			cells["value"] = projectionCellText(item)
+			if !seen["value"] {
+				seen["value"] = true
+				headers = append(headers, "value")
+			}
+			flattened = append(flattened, cells)
+			continue
+		}
+		flattenProjectedObject(obj, "", func(path, text string) {
+			cells[path] = text
+			if !seen[path] {
+				seen[path] = true
+				headers = append(headers, path)
+			}
+		})
+		flattened = append(flattened, cells)
+	}
+
+	rows := make([][]string, 0, len(flattened))
+	for _, cells := range flattened {
+		row := make([]string, len(headers))
+		for i, header := range headers {
+			row[i] = cells[header]
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
+
+func flattenProjectedObject(obj *query.Object, prefix string, emit func(path, text string)) {
+	for _, key := range obj.Keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := obj.Values[key].(*query.Object); ok {
+			flattenProjectedObject(nested, path, emit)
+			continue
+		}
+		emit(path, projectionCellText(obj.Values[key]))
+	}
+}
+
+func projectionCellText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(bytes.TrimSpace(data))
+}
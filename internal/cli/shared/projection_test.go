@@ -0,0 +1,144 @@
+package shared
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func setProjectionForTest(t *testing.T, fields, expression string) {
+	t.Helper()
+	SetProjectionFlags(fields, expression)
+	t.Cleanup(func() { SetProjectionFlags("", "") })
+}
+
+func projectionTestApps() *asc.AppsResponse {
+	return &asc.AppsResponse{
+		Data: []asc.Resource[asc.AppAttributes]{
+			{Type: asc.ResourceTypeApps, ID: "1", Attributes: asc.AppAttributes{Name: "One", BundleID: "com.one", SKU: "ONE"}},
+			{Type: asc.ResourceTypeApps, ID: "2", Attributes: asc.AppAttributes{Name: "Two, Inc", BundleID: "com.two", SKU: "TWO"}},
+		},
+		Links: asc.Links{Self: "https://api.example.com/v1/apps"},
+	}
+}
+
+func TestValidateProjectionFlags(t *testing.T) {
+	tests := []struct {
+		fields, query, wantErr string
+	}{
+		{},
+		{fields: "id,attributes.name"},
+		{query: "data[?attributes.sku == 'ONE'].id"},
+		{fields: "id,attributes.", wantErr: "--fields: invalid field path"},
+		{fields: " , ", wantErr: "--fields requires at least one field"},
+		{query: "data[", wantErr: "--query: invalid query"},
+	}
+	for _, tc := range tests {
+		setProjectionForTest(t, tc.fields, tc.query)
+		err := ValidateProjectionFlags()
+		if tc.wantErr == "" {
+			if err != nil {
+				t.Fatalf("fields=%q query=%q: unexpected error %v", tc.fields, tc.query, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Fatalf("fields=%q query=%q: expected %q, got %v", tc.fields, tc.query, tc.wantErr, err)
+		}
+	}
+}
+
+func TestPrintOutput_FieldsProjectJSONResources(t *testing.T) {
+	setProjectionForTest(t, "id,attributes.name", "")
+
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(projectionTestApps(), "json", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	want := `{"data":[{"id":"1","attributes":{"name":"One"}},{"id":"2","attributes":{"name":"Two, Inc"}}],"links":{"self":"https://api.example.com/v1/apps"}}`
+	if strings.TrimSpace(stdout) != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", stdout, want)
+	}
+}
+
+func TestPrintOutput_FieldsDefineTableAndCSVColumns(t *testing.T) {
+	setProjectionForTest(t, "id,attributes.bundleId", "")
+
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(projectionTestApps(), "csv", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	if stdout != "id,attributes.bundleId\n1,com.one\n2,com.two\n" {
+		t.Fatalf("unexpected CSV output %q", stdout)
+	}
+
+	stdout, _ = captureOutput(t, func() {
+		if err := PrintOutput(projectionTestApps(), "table", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	for _, want := range []string{"attributes.bundleId", "com.two"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected table to contain %q, got:\n%s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "Two, Inc") {
+		t.Fatalf("expected unselected columns to be dropped, got:\n%s", stdout)
+	}
+}
+
+func TestPrintOutput_QueryThenRender(t *testing.T) {
+	setProjectionForTest(t, "", "data[?attributes.sku == 'TWO'].{id: id, name: attributes.name}")
+
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(projectionTestApps(), "json", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	if strings.TrimSpace(stdout) != `[{"id":"2","name":"Two, Inc"}]` {
+		t.Fatalf("unexpected JSON output %q", stdout)
+	}
+
+	stdout, _ = captureOutput(t, func() {
+		if err := PrintOutput(projectionTestApps(), "markdown", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "| id") || !strings.Contains(stdout, "| name") || !strings.Contains(stdout, "Two, Inc") {
+		t.Fatalf("unexpected markdown output:\n%s", stdout)
+	}
+}
+
+func TestPrintOutput_QueryScalarResultsUseValueColumn(t *testing.T) {
+	setProjectionForTest(t, "", "data[*].attributes.sku")
+
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(projectionTestApps(), "tsv", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	if stdout != "value\nONE\nTWO\n" {
+		t.Fatalf("unexpected TSV output %q", stdout)
+	}
+}
+
+func TestPrintOutput_QueryAndFieldsCombine(t *testing.T) {
+	setProjectionForTest(t, "id", "data[?attributes.sku == 'ONE']")
+
+	stdout, _ := captureOutput(t, func() {
+		if err := PrintOutput(projectionTestApps(), "ndjson", false); err != nil {
+			t.Fatalf("PrintOutput() error: %v", err)
+		}
+	})
+	var item map[string]any
+	if err := json.Unmarshal([]byte(stdout), &item); err != nil {
+		t.Fatalf("expected one NDJSON line, got %q (%v)", stdout, err)
+	}
+	if len(item) != 1 || item["id"] != "1" {
+		t.Fatalf("unexpected projected item %v", item)
+	}
+}
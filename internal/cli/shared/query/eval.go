@@ -0,0 +1,340 @@
+package query
+
+import (
+	"fmt"
+)
+
+// Expression is a compiled JMESPath-style query.
+type Expression struct {
+	source string
+	root   *node
+}
+
+// Compile parses a JMESPath-style expression.
+func Compile(expression string) (*Expression, error) {
+	root, err := parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query %q: %w", expression, err)
+	}
+	return &Expression{source: expression, root: root}, nil
+}
+
+// String returns the source expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Search evaluates the expression against data, which must be a query value
+// as returned by Decode or FromValue.
+func (e *Expression) Search(data any) (any, error) {
+	value, err := eval(e.root, data)
+	if err != nil {
+		return nil, fmt.Errorf("query %q: %w", e.source, err)
+	}
+	return value, nil
+}
+
+func eval(n *node, current any) (any, error) {
+	switch n.kind {
+	case nodeCurrent:
+		return current, nil
+	case nodeLiteral:
+		return n.value, nil
+	case nodeField:
+		obj, ok := current.(*Object)
+		if !ok {
+			return nil, nil
+		}
+		value, _ := obj.Get(n.name)
+		return value, nil
+	case nodeSubexpr, nodePipe:
+		left, err := eval(n.children[0], current)
+		if err != nil {
+			return nil, err
+		}
+		if left == nil && n.kind == nodeSubexpr {
+			return nil, nil
+		}
+		return eval(n.children[1], left)
+	case nodeIndex:
+		items, ok := current.([]any)
+		if !ok {
+			return nil, nil
+		}
+		idx := n.value.(int)
+		if idx < 0 {
+			idx += len(items)
+		}
+		if idx < 0 || idx >= len(items) {
+			return nil, nil
+		}
+		return items[idx], nil
+	case nodeSlice:
+		items, ok := current.([]any)
+		if !ok {
+			return nil, nil
+		}
+		return sliceItems(items, n.slice)
+	case nodeFlatten:
+		left, err := eval(n.children[0], current)
+		if err != nil {
+			return nil, err
+		}
+		items, ok := left.([]any)
+		if !ok {
+			return nil, nil
+		}
+		flattened := []any{}
+		for _, item := range items {
+			if nested, ok := item.([]any); ok {
+				flattened = append(flattened, nested...)
+			} else {
+				flattened = append(flattened, item)
+			}
+		}
+		return flattened, nil
+	case nodeProjection, nodeValueProjection, nodeFilterProjection:
+		return evalProjection(n, current)
+	case nodeOr:
+		left, err := eval(n.children[0], current)
+		if err != nil {
+			return nil, err
+		}
+		if truthy(left) {
+			return left, nil
+		}
+		return eval(n.children[1], current)
+	case nodeAnd:
+		left, err := eval(n.children[0], current)
+		if err != nil {
+			return nil, err
+		}
+		if !truthy(left) {
+			return left, nil
+		}
+		return eval(n.children[1], current)
+	case nodeNot:
+		operand, err := eval(n.children[0], current)
+		if err != nil {
+			return nil, err
+		}
+		return !truthy(operand), nil
+	case nodeCompare:
+		left, err := eval(n.children[0], current)
+		if err != nil {
+			return nil, err
+		}
+		right, err := eval(n.children[1], current)
+		if err != nil {
+			return nil, err
+		}
+		return compare(n.op, left, right), nil
+	case nodeMultiList:
+		if current == nil {
+			return nil, nil
+		}
+		result := make([]any, 0, len(n.children))
+		for _, child := range n.children {
+			value, err := eval(child, current)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+		}
+		return result, nil
+	case nodeMultiHash:
+		if current == nil {
+			return nil, nil
+		}
+		result := NewObject()
+		for i, child := range n.children {
+			value, err := eval(child, current)
+			if err != nil {
+				return nil, err
+			}
+			result.Set(n.keys[i], value)
+		}
+		return result, nil
+	case nodeFunction:
+		return callFunction(n, current)
+	case nodeExpref:
+		return nil, fmt.Errorf("expression references (&) are only valid as function arguments")
+	}
+	return nil, fmt.Errorf("unsupported expression")
+}
+
+func evalProjection(n *node, current any) (any, error) {
+	left, err := eval(n.children[0], current)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []any
+	switch n.kind {
+	case nodeValueProjection:
+		obj, ok := left.(*Object)
+		if !ok {
+			return nil, nil
+		}
+		for _, key := range obj.Keys {
+			items = append(items, obj.Values[key])
+		}
+	default:
+		list, ok := left.([]any)
+		if !ok {
+			return nil, nil
+		}
+		items = list
+	}
+
+	result := []any{}
+	for _, item := range items {
+		if n.kind == nodeFilterProjection {
+			matched, err := eval(n.children[2], item)
+			if err != nil {
+				return nil, err
+			}
+			if !truthy(matched) {
+				continue
+			}
+		}
+		value, err := eval(n.children[1], item)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			result = append(result, value)
+		}
+	}
+	return result, nil
+}
+
+func sliceItems(items []any, parts [3]*int) (any, error) {
+	step := 1
+	if parts[2] != nil {
+		step = *parts[2]
+	}
+	if step == 0 {
+		return nil, fmt.Errorf("slice step cannot be 0")
+	}
+	length := len(items)
+	bound := func(value *int, fallback int) int {
+		if value == nil {
+			return fallback
+		}
+		v := *value
+		if v < 0 {
+			v += length
+			if v < 0 {
+				if step < 0 {
+					return -1
+				}
+				return 0
+			}
+		}
+		if v >= length {
+			if step < 0 {
+				return length - 1
+			}
+			return length
+		}
+		return v
+	}
+
+	result := []any{}
+	if step > 0 {
+		for i := bound(parts[0], 0); i < bound(parts[1], length); i += step {
+			result = append(result, items[i])
+		}
+	} else {
+		for i := bound(parts[0], length-1); i > bound(parts[1], -1); i += step {
+			result = append(result, items[i])
+		}
+	}
+	return result, nil
+}
+
+func truthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []any:
+		return len(v) > 0
+	case *Object:
+		return v != nil && len(v.Keys) > 0
+	}
+	return true
+}
+
+func compare(op tokenKind, left, right any) any {
+	switch op {
+	case tokEQ:
+		return equal(left, right)
+	case tokNE:
+		return !equal(left, right)
+	}
+
+	// Ordering is defined for numbers and, as an extension for ISO 8601
+	// timestamps and version strings, for strings.
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return nil
+		}
+		return ordered(op, l < r, l == r)
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return nil
+		}
+		return ordered(op, l < r, l == r)
+	}
+	return nil
+}
+
+func ordered(op tokenKind, less, same bool) bool {
+	switch op {
+	case tokLT:
+		return less
+	case tokLTE:
+		return less || same
+	case tokGT:
+		return !less && !same
+	default:
+		return !less
+	}
+}
+
+func equal(left, right any) bool {
+	switch l := left.(type) {
+	case *Object:
+		r, ok := right.(*Object)
+		if !ok || len(l.Keys) != len(r.Keys) {
+			return false
+		}
+		for _, key := range l.Keys {
+			rv, ok := r.Get(key)
+			if !ok || !equal(l.Values[key], rv) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		r, ok := right.([]any)
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for i := range l {
+			if !equal(l[i], r[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return left == right
+}
@@ -0,0 +1,336 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type function struct {
+	arity    int
+	variadic bool
+	call     func(args []any, refs []*node) (any, error)
+}
+
+var functions map[string]function
+
+func init() {
+	functions = map[string]function{
+		"length":      {arity: 1, call: fnLength},
+		"contains":    {arity: 2, call: fnContains},
+		"starts_with": {arity: 2, call: fnStartsWith},
+		"ends_with":   {arity: 2, call: fnEndsWith},
+		"keys":        {arity: 1, call: fnKeys},
+		"values":      {arity: 1, call: fnValues},
+		"join":        {arity: 2, call: fnJoin},
+		"sort":        {arity: 1, call: fnSort},
+		"sort_by":     {arity: 2, call: fnSortBy},
+		"reverse":     {arity: 1, call: fnReverse},
+		"min":         {arity: 1, call: fnMin},
+		"max":         {arity: 1, call: fnMax},
+		"sum":         {arity: 1, call: fnSum},
+		"to_string":   {arity: 1, call: fnToString},
+		"to_number":   {arity: 1, call: fnToNumber},
+		"type":        {arity: 1, call: fnType},
+		"not_null":    {arity: 1, variadic: true, call: fnNotNull},
+	}
+}
+
+func callFunction(n *node, current any) (any, error) {
+	fn, ok := functions[n.name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s()", n.name)
+	}
+	if len(n.children) < fn.arity || (!fn.variadic && len(n.children) != fn.arity) {
+		return nil, fmt.Errorf("%s() expects %d argument(s), got %d", n.name, fn.arity, len(n.children))
+	}
+
+	args := make([]any, len(n.children))
+	refs := make([]*node, len(n.children))
+	for i, child := range n.children {
+		if child.kind == nodeExpref {
+			refs[i] = child.children[0]
+			continue
+		}
+		value, err := eval(child, current)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+	value, err := fn.call(args, refs)
+	if err != nil {
+		return nil, fmt.Errorf("%s(): %w", n.name, err)
+	}
+	return value, nil
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case *Object:
+		return "object"
+	}
+	return "unknown"
+}
+
+func fnLength(args []any, _ []*node) (any, error) {
+	switch v := args[0].(type) {
+	case string:
+		return float64(len([]rune(v))), nil
+	case []any:
+		return float64(len(v)), nil
+	case *Object:
+		return float64(len(v.Keys)), nil
+	}
+	return nil, fmt.Errorf("expected string, array or object, got %s", typeName(args[0]))
+}
+
+func fnContains(args []any, _ []*node) (any, error) {
+	switch v := args[0].(type) {
+	case string:
+		needle, ok := args[1].(string)
+		return ok && strings.Contains(v, needle), nil
+	case []any:
+		for _, item := range v {
+			if equal(item, args[1]) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return nil, fmt.Errorf("expected string or array, got %s", typeName(args[0]))
+}
+
+func fnStartsWith(args []any, _ []*node) (any, error) {
+	s, ok1 := args[0].(string)
+	prefix, ok2 := args[1].(string)
+	if !ok1 || !ok2 {
+		return nil, fmt.Errorf("expected string arguments")
+	}
+	return strings.HasPrefix(s, prefix), nil
+}
+
+func fnEndsWith(args []any, _ []*node) (any, error) {
+	s, ok1 := args[0].(string)
+	suffix, ok2 := args[1].(string)
+	if !ok1 || !ok2 {
+		return nil, fmt.Errorf("expected string arguments")
+	}
+	return strings.HasSuffix(s, suffix), nil
+}
+
+func fnKeys(args []any, _ []*node) (any, error) {
+	obj, ok := args[0].(*Object)
+	if !ok {
+		return nil, fmt.Errorf("expected object, got %s", typeName(args[0]))
+	}
+	keys := make([]any, 0, len(obj.Keys))
+	for _, key := range obj.Keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func fnValues(args []any, _ []*node) (any, error) {
+	obj, ok := args[0].(*Object)
+	if !ok {
+		return nil, fmt.Errorf("expected object, got %s", typeName(args[0]))
+	}
+	values := make([]any, 0, len(obj.Keys))
+	for _, key := range obj.Keys {
+		values = append(values, obj.Values[key])
+	}
+	return values, nil
+}
+
+func fnJoin(args []any, _ []*node) (any, error) {
+	sep, ok := args[0].(string)
+	items, ok2 := args[1].([]any)
+	if !ok || !ok2 {
+		return nil, fmt.Errorf("expected separator string and array of strings")
+	}
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected array of strings, found %s", typeName(item))
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, sep), nil
+}
+
+func sortKey(value any) (string, float64, error) {
+	switch v := value.(type) {
+	case string:
+		return v, 0, nil
+	case float64:
+		return "", v, nil
+	}
+	return "", 0, fmt.Errorf("can only sort strings or numbers, found %s", typeName(value))
+}
+
+func sortValues(items []any, keys []any) ([]any, error) {
+	if len(items) == 0 {
+		return []any{}, nil
+	}
+	kind := typeName(keys[0])
+	for _, key := range keys {
+		if _, _, err := sortKey(key); err != nil {
+			return nil, err
+		}
+		if typeName(key) != kind {
+			return nil, fmt.Errorf("cannot sort mixed %s and %s values", kind, typeName(key))
+		}
+	}
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		as, an, _ := sortKey(keys[a])
+		bs, bn, _ := sortKey(keys[b])
+		if kind == "string" {
+			return strings.Compare(as, bs)
+		}
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		}
+		return 0
+	})
+	sorted := make([]any, len(items))
+	for i, idx := range order {
+		sorted[i] = items[idx]
+	}
+	return sorted, nil
+}
+
+func fnSort(args []any, _ []*node) (any, error) {
+	items, ok := args[0].([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected array, got %s", typeName(args[0]))
+	}
+	return sortValues(items, items)
+}
+
+func fnSortBy(args []any, refs []*node) (any, error) {
+	items, ok := args[0].([]any)
+	if !ok || refs[1] == nil {
+		return nil, fmt.Errorf("expected array and &expression")
+	}
+	keys := make([]any, len(items))
+	for i, item := range items {
+		key, err := eval(refs[1], item)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	return sortValues(items, keys)
+}
+
+func fnReverse(args []any, _ []*node) (any, error) {
+	switch v := args[0].(type) {
+	case string:
+		runes := []rune(v)
+		slices.Reverse(runes)
+		return string(runes), nil
+	case []any:
+		reversed := slices.Clone(v)
+		slices.Reverse(reversed)
+		return reversed, nil
+	}
+	return nil, fmt.Errorf("expected string or array, got %s", typeName(args[0]))
+}
+
+func extreme(args []any, wantMax bool) (any, error) {
+	items, ok := args[0].([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected array, got %s", typeName(args[0]))
+	}
+	sorted, err := sortValues(items, items)
+	if err != nil || len(sorted) == 0 {
+		return nil, err
+	}
+	if wantMax {
+		return sorted[len(sorted)-1], nil
+	}
+	return sorted[0], nil
+}
+
+func fnMin(args []any, _ []*node) (any, error) {
+	return extreme(args, false)
+}
+
+func fnMax(args []any, _ []*node) (any, error) {
+	return extreme(args, true)
+}
+
+func fnSum(args []any, _ []*node) (any, error) {
+	items, ok := args[0].([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected array, got %s", typeName(args[0]))
+	}
+	total := 0.0
+	for _, item := range items {
+		n, ok := item.(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected array of numbers, found %s", typeName(item))
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func fnToString(args []any, _ []*node) (any, error) {
+	if s, ok := args[0].(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func fnToNumber(args []any, _ []*node) (any, error) {
+	switch v := args[0].(type) {
+	case float64:
+		return v, nil
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil, nil
+		}
+		return n, nil
+	}
+	return nil, nil
+}
+
+func fnType(args []any, _ []*node) (any, error) {
+	return typeName(args[0]), nil
+}
+
+func fnNotNull(args []any, _ []*node) (any, error) {
+	for _, arg := range args {
+		if arg != nil {
+			return arg, nil
+		}
+	}
+	return nil, nil
+}
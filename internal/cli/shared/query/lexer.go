@@ -0,0 +1,189 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdentifier
+	tokQuotedIdentifier
+	tokRawString
+	tokLiteral
+	tokNumber
+	tokDot
+	tokStar
+	tokLbracket
+	tokRbracket
+	tokFilter
+	tokFlatten
+	tokLbrace
+	tokRbrace
+	tokLparen
+	tokRparen
+	tokColon
+	tokComma
+	tokPipe
+	tokOr
+	tokAnd
+	tokNot
+	tokEQ
+	tokNE
+	tokLT
+	tokLTE
+	tokGT
+	tokGTE
+	tokCurrent
+	tokExpref
+)
+
+// bindingPowers follows the JMESPath reference grammar.
+var bindingPowers = map[tokenKind]int{
+	tokPipe:     1,
+	tokOr:       2,
+	tokAnd:      3,
+	tokEQ:       5,
+	tokNE:       5,
+	tokLT:       5,
+	tokLTE:      5,
+	tokGT:       5,
+	tokGTE:      5,
+	tokFlatten:  9,
+	tokStar:     20,
+	tokFilter:   21,
+	tokDot:      40,
+	tokNot:      45,
+	tokLbrace:   50,
+	tokLbracket: 55,
+	tokLparen:   60,
+}
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(input) {
+		ch := input[i]
+		start := i
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+			continue
+		case ch == '_' || unicode.IsLetter(rune(ch)):
+			for i < len(input) && (input[i] == '_' || unicode.IsLetter(rune(input[i])) || unicode.IsDigit(rune(input[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdentifier, text: input[start:i], pos: start})
+			continue
+		case ch == '-' || (ch >= '0' && ch <= '9'):
+			i++
+			for i < len(input) && input[i] >= '0' && input[i] <= '9' {
+				i++
+			}
+			n, err := strconv.Atoi(input[start:i])
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", input[start:i], start)
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: input[start:i], value: n, pos: start})
+			continue
+		case ch == '"':
+			end, err := scanDelimited(input, i, '"')
+			if err != nil {
+				return nil, err
+			}
+			var name string
+			if err := json.Unmarshal([]byte(input[i:end]), &name); err != nil {
+				return nil, fmt.Errorf("invalid quoted identifier at position %d: %w", start, err)
+			}
+			tokens = append(tokens, token{kind: tokQuotedIdentifier, text: input[i:end], value: name, pos: start})
+			i = end
+			continue
+		case ch == '\'':
+			end, err := scanDelimited(input, i, '\'')
+			if err != nil {
+				return nil, err
+			}
+			raw := strings.ReplaceAll(input[i+1:end-1], `\'`, `'`)
+			tokens = append(tokens, token{kind: tokRawString, text: input[i:end], value: raw, pos: start})
+			i = end
+			continue
+		case ch == '`':
+			end, err := scanDelimited(input, i, '`')
+			if err != nil {
+				return nil, err
+			}
+			body := strings.ReplaceAll(input[i+1:end-1], "\\`", "`")
+			value, err := Decode([]byte(body))
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSON literal at position %d: %w", start, err)
+			}
+			tokens = append(tokens, token{kind: tokLiteral, text: input[i:end], value: value, pos: start})
+			i = end
+			continue
+		}
+
+		kind, width := punctuation(input[i:])
+		if width == 0 {
+			return nil, fmt.Errorf("unexpected character %q at position %d", ch, i)
+		}
+		tokens = append(tokens, token{kind: kind, text: input[i : i+width], pos: start})
+		i += width
+	}
+	tokens = append(tokens, token{kind: tokEOF, pos: len(input)})
+	return tokens, nil
+}
+
+func punctuation(s string) (tokenKind, int) {
+	two := map[string]tokenKind{
+		"[?": tokFilter, "[]": tokFlatten, "||": tokOr, "&&": tokAnd,
+		"==": tokEQ, "!=": tokNE, "<=": tokLTE, ">=": tokGTE,
+	}
+	if len(s) >= 2 {
+		if kind, ok := two[s[:2]]; ok {
+			return kind, 2
+		}
+	}
+	one := map[byte]tokenKind{
+		'.': tokDot, '*': tokStar, '[': tokLbracket, ']': tokRbracket,
+		'{': tokLbrace, '}': tokRbrace, '(': tokLparen, ')': tokRparen,
+		':': tokColon, ',': tokComma, '|': tokPipe, '!': tokNot,
+		'<': tokLT, '>': tokGT, '@': tokCurrent, '&': tokExpref,
+	}
+	if kind, ok := one[s[0]]; ok {
+		return kind, 1
+	}
+	return tokEOF, 0
+}
+
+// scanDelimited returns the index just past the closing delimiter of the
+// token that starts at input[start].
+func scanDelimited(input string, start int, delim byte) (int, error) {
+	for i := start + 1; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			i++
+		case delim:
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated %c at position %d", delim, start)
+}
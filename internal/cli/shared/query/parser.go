@@ -0,0 +1,373 @@
+package query
+
+import (
+	"fmt"
+)
+
+type nodeKind int
+
+const (
+	nodeCurrent nodeKind = iota
+	nodeField
+	nodeLiteral
+	nodeSubexpr
+	nodeIndex
+	nodeSlice
+	nodeProjection
+	nodeValueProjection
+	nodeFlatten
+	nodeFilterProjection
+	nodePipe
+	nodeOr
+	nodeAnd
+	nodeNot
+	nodeCompare
+	nodeMultiList
+	nodeMultiHash
+	nodeFunction
+	nodeExpref
+)
+
+type node struct {
+	kind     nodeKind
+	name     string
+	value    any
+	op       tokenKind
+	children []*node
+	keys     []string
+	slice    [3]*int
+}
+
+type parser struct {
+	tokens []token
+	index  int
+}
+
+func parse(expression string) (*node, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.expression(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.current().kind != tokEOF {
+		return nil, p.unexpected()
+	}
+	return root, nil
+}
+
+func (p *parser) current() token {
+	return p.tokens[p.index]
+}
+
+func (p *parser) peek(offset int) token {
+	if p.index+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.index+offset]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.index]
+	if tok.kind != tokEOF {
+		p.index++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind, what string) error {
+	if p.current().kind != kind {
+		return fmt.Errorf("expected %s at position %d, got %s", what, p.current().pos, p.current())
+	}
+	p.advance()
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	return unexpectedToken(p.current())
+}
+
+func unexpectedToken(tok token) error {
+	return fmt.Errorf("unexpected %s at position %d", tok, tok.pos)
+}
+
+func (p *parser) expression(bindingPower int) (*node, error) {
+	left, err := p.nud(p.advance())
+	if err != nil {
+		return nil, err
+	}
+	for bindingPower < bindingPowers[p.current().kind] {
+		left, err = p.led(p.advance(), left)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) nud(tok token) (*node, error) {
+	switch tok.kind {
+	case tokIdentifier:
+		return &node{kind: nodeField, name: tok.text}, nil
+	case tokQuotedIdentifier:
+		return &node{kind: nodeField, name: tok.value.(string)}, nil
+	case tokRawString, tokLiteral:
+		return &node{kind: nodeLiteral, value: tok.value}, nil
+	case tokNumber:
+		return &node{kind: nodeLiteral, value: float64(tok.value.(int))}, nil
+	case tokCurrent:
+		return &node{kind: nodeCurrent}, nil
+	case tokStar:
+		right, err := p.projectionRHS(bindingPowers[tokStar])
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: nodeValueProjection, children: []*node{{kind: nodeCurrent}, right}}, nil
+	case tokFilter:
+		return p.filter(&node{kind: nodeCurrent})
+	case tokFlatten:
+		right, err := p.projectionRHS(bindingPowers[tokFlatten])
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: nodeProjection, children: []*node{{kind: nodeFlatten, children: []*node{{kind: nodeCurrent}}}, right}}, nil
+	case tokLbracket:
+		switch {
+		case p.current().kind == tokNumber || p.current().kind == tokColon:
+			return p.indexOrSlice(&node{kind: nodeCurrent})
+		case p.current().kind == tokStar && p.peek(1).kind == tokRbracket:
+			p.advance()
+			p.advance()
+			return p.listProjection(&node{kind: nodeCurrent})
+		default:
+			return p.multiList()
+		}
+	case tokLbrace:
+		return p.multiHash()
+	case tokNot:
+		operand, err := p.expression(bindingPowers[tokNot])
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: nodeNot, children: []*node{operand}}, nil
+	case tokLparen:
+		inner, err := p.expression(0)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokRparen, "')'"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case tokExpref:
+		inner, err := p.expression(bindingPowers[tokExpref])
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: nodeExpref, children: []*node{inner}}, nil
+	}
+	return nil, unexpectedToken(tok)
+}
+
+func (p *parser) led(tok token, left *node) (*node, error) {
+	switch tok.kind {
+	case tokDot:
+		if p.current().kind == tokStar {
+			p.advance()
+			right, err := p.projectionRHS(bindingPowers[tokStar])
+			if err != nil {
+				return nil, err
+			}
+			return &node{kind: nodeValueProjection, children: []*node{left, right}}, nil
+		}
+		right, err := p.dotRHS(bindingPowers[tokDot])
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: nodeSubexpr, children: []*node{left, right}}, nil
+	case tokPipe, tokOr, tokAnd:
+		right, err := p.expression(bindingPowers[tok.kind])
+		if err != nil {
+			return nil, err
+		}
+		kind := map[tokenKind]nodeKind{tokPipe: nodePipe, tokOr: nodeOr, tokAnd: nodeAnd}[tok.kind]
+		return &node{kind: kind, children: []*node{left, right}}, nil
+	case tokEQ, tokNE, tokLT, tokLTE, tokGT, tokGTE:
+		right, err := p.expression(bindingPowers[tok.kind])
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: nodeCompare, op: tok.kind, children: []*node{left, right}}, nil
+	case tokLparen:
+		if left.kind != nodeField {
+			return nil, fmt.Errorf("invalid function call at position %d", tok.pos)
+		}
+		var args []*node
+		for p.current().kind != tokRparen {
+			arg, err := p.expression(0)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.current().kind == tokComma {
+				p.advance()
+			} else if p.current().kind != tokRparen {
+				return nil, p.unexpected()
+			}
+		}
+		p.advance()
+		return &node{kind: nodeFunction, name: left.name, children: args}, nil
+	case tokFilter:
+		return p.filter(left)
+	case tokFlatten:
+		right, err := p.projectionRHS(bindingPowers[tokFlatten])
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: nodeProjection, children: []*node{{kind: nodeFlatten, children: []*node{left}}, right}}, nil
+	case tokLbracket:
+		if p.current().kind == tokStar && p.peek(1).kind == tokRbracket {
+			p.advance()
+			p.advance()
+			return p.listProjection(left)
+		}
+		return p.indexOrSlice(left)
+	}
+	return nil, unexpectedToken(tok)
+}
+
+func (p *parser) listProjection(left *node) (*node, error) {
+	right, err := p.projectionRHS(bindingPowers[tokStar])
+	if err != nil {
+		return nil, err
+	}
+	return &node{kind: nodeProjection, children: []*node{left, right}}, nil
+}
+
+func (p *parser) filter(left *node) (*node, error) {
+	condition, err := p.expression(0)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(tokRbracket, "']'"); err != nil {
+		return nil, err
+	}
+	right, err := p.projectionRHS(bindingPowers[tokFilter])
+	if err != nil {
+		return nil, err
+	}
+	return &node{kind: nodeFilterProjection, children: []*node{left, right, condition}}, nil
+}
+
+func (p *parser) indexOrSlice(left *node) (*node, error) {
+	var parts [3]*int
+	part := 0
+	for p.current().kind != tokRbracket {
+		switch p.current().kind {
+		case tokNumber:
+			n := p.advance().value.(int)
+			parts[part] = &n
+		case tokColon:
+			p.advance()
+			part++
+			if part > 2 {
+				return nil, p.unexpected()
+			}
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	p.advance()
+	if part == 0 {
+		if parts[0] == nil {
+			return nil, fmt.Errorf("empty index")
+		}
+		return &node{kind: nodeSubexpr, children: []*node{left, {kind: nodeIndex, value: *parts[0]}}}, nil
+	}
+	sliced := &node{kind: nodeSubexpr, children: []*node{left, {kind: nodeSlice, slice: parts}}}
+	right, err := p.projectionRHS(bindingPowers[tokStar])
+	if err != nil {
+		return nil, err
+	}
+	return &node{kind: nodeProjection, children: []*node{sliced, right}}, nil
+}
+
+func (p *parser) multiList() (*node, error) {
+	var items []*node
+	for {
+		item, err := p.expression(0)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if p.current().kind == tokRbracket {
+			p.advance()
+			return &node{kind: nodeMultiList, children: items}, nil
+		}
+		if err := p.expect(tokComma, "',' or ']'"); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) multiHash() (*node, error) {
+	result := &node{kind: nodeMultiHash}
+	for {
+		keyTok := p.advance()
+		var key string
+		switch keyTok.kind {
+		case tokIdentifier:
+			key = keyTok.text
+		case tokQuotedIdentifier:
+			key = keyTok.value.(string)
+		default:
+			return nil, fmt.Errorf("expected key name at position %d, got %s", keyTok.pos, keyTok)
+		}
+		if err := p.expect(tokColon, "':'"); err != nil {
+			return nil, err
+		}
+		value, err := p.expression(0)
+		if err != nil {
+			return nil, err
+		}
+		result.keys = append(result.keys, key)
+		result.children = append(result.children, value)
+		if p.current().kind == tokRbrace {
+			p.advance()
+			return result, nil
+		}
+		if err := p.expect(tokComma, "',' or '}'"); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) dotRHS(bindingPower int) (*node, error) {
+	switch p.current().kind {
+	case tokIdentifier, tokQuotedIdentifier, tokStar:
+		return p.expression(bindingPower)
+	case tokLbracket:
+		p.advance()
+		return p.multiList()
+	case tokLbrace:
+		p.advance()
+		return p.multiHash()
+	}
+	return nil, p.unexpected()
+}
+
+func (p *parser) projectionRHS(bindingPower int) (*node, error) {
+	switch kind := p.current().kind; {
+	case bindingPowers[kind] < 10:
+		return &node{kind: nodeCurrent}, nil
+	case kind == tokLbracket, kind == tokFilter:
+		return p.expression(bindingPower)
+	case kind == tokDot:
+		p.advance()
+		return p.dotRHS(bindingPower)
+	}
+	return nil, p.unexpected()
+}
@@ -0,0 +1,126 @@
+package query
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const sampleDocument = `{
+  "data": [
+    {"type": "appStoreVersions", "id": "1", "attributes": {"versionString": "1.0", "appStoreState": "READY_FOR_SALE", "platform": "IOS", "downloads": 10}},
+    {"type": "appStoreVersions", "id": "2", "attributes": {"versionString": "1.1", "appStoreState": "PREPARE_FOR_SUBMISSION", "platform": "IOS", "downloads": 3}},
+    {"type": "appStoreVersions", "id": "3", "attributes": {"versionString": "2.0", "appStoreState": "READY_FOR_SALE", "platform": "MAC_OS", "downloads": 7}}
+  ],
+  "links": {"self": "https://example.com"}
+}`
+
+func search(t *testing.T, expression string) string {
+	t.Helper()
+
+	doc, err := Decode([]byte(sampleDocument))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	expr, err := Compile(expression)
+	if err != nil {
+		t.Fatalf("Compile(%q) error: %v", expression, err)
+	}
+	result, err := expr.Search(doc)
+	if err != nil {
+		t.Fatalf("Search(%q) error: %v", expression, err)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("marshal result: %v", err)
+	}
+	return string(data)
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+	}{
+		{`links.self`, `"https://example.com"`},
+		{`data[0].id`, `"1"`},
+		{`data[-1].attributes.versionString`, `"2.0"`},
+		{`data[*].id`, `["1","2","3"]`},
+		{`data[].attributes.platform`, `["IOS","IOS","MAC_OS"]`},
+		{`data[1:].id`, `["2","3"]`},
+		{`data[::-1].id`, `["3","2","1"]`},
+		{`data[?attributes.appStoreState == 'READY_FOR_SALE'].id`, `["1","3"]`},
+		{`data[?attributes.platform == 'IOS' && attributes.downloads > ` + "`5`" + `].id`, `["1"]`},
+		{`data[?!(attributes.platform == 'IOS')].id`, `["3"]`},
+		{`data[?attributes.versionString >= '1.1'].attributes.versionString`, `["1.1","2.0"]`},
+		{`data[*].{id: id, version: attributes.versionString}`, `[{"id":"1","version":"1.0"},{"id":"2","version":"1.1"},{"id":"3","version":"2.0"}]`},
+		{`data[*].[id, attributes.platform]`, `[["1","IOS"],["2","IOS"],["3","MAC_OS"]]`},
+		{`data[*].id | [0]`, `"1"`},
+		{`length(data)`, `3`},
+		{`sum(data[*].attributes.downloads)`, `20`},
+		{`max(data[*].attributes.downloads)`, `10`},
+		{`sort_by(data, &attributes.downloads)[*].id`, `["2","3","1"]`},
+		{`join(', ', data[*].attributes.versionString)`, `"1.0, 1.1, 2.0"`},
+		{`data[?contains(attributes.appStoreState, 'PREPARE')].id`, `["2"]`},
+		{`data[?starts_with(attributes.versionString, '1.')].id`, `["1","2"]`},
+		{`keys(data[0].attributes)`, `["versionString","appStoreState","platform","downloads"]`},
+		{`data[0].attributes.*`, `["1.0","READY_FOR_SALE","IOS",10]`},
+		{`missing.field`, `null`},
+		{`data[5]`, `null`},
+		{`not_null(missing, data[0].id)`, `"1"`},
+		{`data[0].attributes.{"state": appStoreState}`, `{"state":"READY_FOR_SALE"}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expression, func(t *testing.T) {
+			if got := search(t, tc.expression); got != tc.want {
+				t.Fatalf("Search(%q) = %s, want %s", tc.expression, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, expression := range []string{
+		`data[`,
+		`data[?id == '1'`,
+		`{id: }`,
+		`data..id`,
+		`'unterminated`,
+		`data[0] extra`,
+	} {
+		if _, err := Compile(expression); err == nil {
+			t.Fatalf("Compile(%q) expected error", expression)
+		}
+	}
+}
+
+func TestSearchFunctionErrors(t *testing.T) {
+	doc, _ := Decode([]byte(sampleDocument))
+	for _, expression := range []string{`unknown(data)`, `length(data, data)`, `sum(data[*].id)`} {
+		expr, err := Compile(expression)
+		if err != nil {
+			t.Fatalf("Compile(%q) error: %v", expression, err)
+		}
+		if _, err := expr.Search(doc); err == nil {
+			t.Fatalf("Search(%q) expected error", expression)
+		}
+	}
+}
+
+func TestDecodePreservesKeyOrder(t *testing.T) {
+	value, err := Decode([]byte(`{"z":1,"a":{"y":true,"b":null},"m":[1,"x"]}`))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if got := string(data); got != `{"z":1,"a":{"y":true,"b":null},"m":[1,"x"]}` {
+		t.Fatalf("round trip = %s", got)
+	}
+	if _, err := Decode([]byte(`{} trailing`)); err == nil || !strings.Contains(err.Error(), "unexpected") {
+		t.Fatalf("expected trailing data error, got %v", err)
+	}
+}
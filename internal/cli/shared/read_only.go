@@ -0,0 +1,53 @@
+package shared
+
+import (
+	"flag"
+	"slices"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+var readOnly bool
+
+// BindReadOnlyFlag registers the --read-only root flag.
+func BindReadOnlyFlag(fs *flag.FlagSet) {
+	fs.BoolVar(&readOnly, "read-only", false, "Refuse every mutating API request (or ASC_READ_ONLY)")
+}
+
+// ApplyReadOnlyFlag forwards --read-only to the API clients. The flag can only
+// enable read-only mode; it never disables ASC_READ_ONLY or a read-only profile.
+func ApplyReadOnlyFlag() {
+	if readOnly {
+		enabled := true
+		asc.SetReadOnlyOverride(&enabled)
+		return
+	}
+	asc.SetReadOnlyOverride(nil)
+}
+
+// SetReadOnlyFlag sets the --read-only value (tests only).
+func SetReadOnlyFlag(value bool) {
+	readOnly = value
+}
+
+// ProfileReadOnly reports whether profile is listed in read_only_profiles.
+func ProfileReadOnly(profile string) bool {
+	profile = strings.TrimSpace(profile)
+	if profile == "" {
+		return false
+	}
+	cfg, _, err := loadConfigForCredentialMetadata()
+	if err != nil || cfg == nil {
+		return false
+	}
+	return slices.ContainsFunc(cfg.ReadOnlyProfiles, func(name string) bool {
+		return strings.TrimSpace(name) == profile
+	})
+}
+
+func applyProfileReadOnly(client *asc.Client, profile string) {
+	if client != nil && ProfileReadOnly(profile) {
+		client.SetReadOnly(true)
+	}
+}
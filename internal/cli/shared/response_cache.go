@@ -0,0 +1,111 @@
+package shared
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+const (
+	noCacheEnvVar       = "ASC_NO_CACHE"
+	cacheMaxSizeEnvVar  = "ASC_CACHE_MAX_MB"
+	defaultCacheScope   = "default"
+	responseCacheSubdir = "http"
+)
+
+var (
+	noCache      bool
+	refreshCache bool
+
+	responseCacheDirOverride   string
+	responseCacheDirOverrideMu sync.RWMutex
+)
+
+// BindCacheFlags registers response cache flags.
+func BindCacheFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&noCache, "no-cache", false, "Bypass the on-disk response cache (or ASC_NO_CACHE)")
+	fs.BoolVar(&refreshCache, "refresh-cache", false, "Ignore cached responses and refresh them from the API")
+}
+
+// ValidateCacheFlags rejects conflicting cache flags.
+func ValidateCacheFlags() error {
+	if noCache && refreshCache {
+		return fmt.Errorf("--no-cache and --refresh-cache are mutually exclusive")
+	}
+	return nil
+}
+
+// SetCacheFlags sets the --no-cache/--refresh-cache values (tests only).
+func SetCacheFlags(disabled, refresh bool) {
+	noCache = disabled
+	refreshCache = refresh
+}
+
+// ResponseCacheRoot returns the directory holding cached API responses.
+func ResponseCacheRoot() (string, error) {
+	responseCacheDirOverrideMu.RLock()
+	override := responseCacheDirOverride
+	responseCacheDirOverrideMu.RUnlock()
+	if override != "" {
+		return override, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home dir: %w", err)
+	}
+	return filepath.Join(home, ".asc", "cache", responseCacheSubdir), nil
+}
+
+// ResponseCacheScope returns the cache scope for a profile name.
+func ResponseCacheScope(profile string) string {
+	profile = strings.TrimSpace(profile)
+	if profile == "" {
+		return defaultCacheScope
+	}
+	return sanitizeTierCacheToken(profile)
+}
+
+func responseCacheDisabled() bool {
+	if noCache {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(noCacheEnvVar))) {
+	case "1", "t", "true", "yes", "y", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// ResponseCacheMaxBytes returns the configured cache size cap (ASC_CACHE_MAX_MB).
+func ResponseCacheMaxBytes() int64 {
+	value := strings.TrimSpace(os.Getenv(cacheMaxSizeEnvVar))
+	if value == "" {
+		return asc.DefaultResponseCacheMaxBytes
+	}
+	megabytes, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || megabytes <= 0 {
+		return asc.DefaultResponseCacheMaxBytes
+	}
+	return megabytes << 20
+}
+
+// attachResponseCache enables the profile-scoped response cache on client
+// unless caching was disabled for this invocation.
+func attachResponseCache(client *asc.Client, profile string) {
+	if client == nil || responseCacheDisabled() {
+		return
+	}
+	root, err := ResponseCacheRoot()
+	if err != nil {
+		return
+	}
+	client.SetResponseCache(asc.NewResponseCache(root, ResponseCacheScope(profile), ResponseCacheMaxBytes(), refreshCache))
+}
@@ -94,6 +94,14 @@ func BindRootFlags(fs *flag.FlagSet) {
 	fs.Var(&debug, "debug", "Enable debug logging to stderr")
 	fs.Var(&apiDebug, "api-debug", "Enable HTTP debug logging to stderr (redacts sensitive values)")
 	BindCIFlags(fs)
+	BindCassetteFlags(fs)
+	BindCacheFlags(fs)
+	BindReadOnlyFlag(fs)
+	BindPlanFlag(fs)
+	BindTraceFlags(fs)
+	BindUploadFlags(fs)
+	BindProfileFanoutFlags(fs)
+	BindProjectionFlags(fs)
 }
 
 // SelectedProfile returns the current profile override.
@@ -311,9 +319,10 @@ type OutputFlags struct {
 }
 
 type validatedOutputValue struct {
-	value   *string
-	pretty  *bool
-	allowed []string
+	value    *string
+	pretty   *bool
+	allowed  []string
+	template *outputTemplateFlags
 }
 
 func (v *validatedOutputValue) String() string {
@@ -341,8 +350,18 @@ func (v *validatedOutputValue) Validate() error {
 		pretty = *v.pretty
 	}
 
-	_, err := validateOutputFormatAllowed(*v.value, pretty, v.allowed...)
-	return err
+	format, err := validateOutputFormatAllowed(*v.value, pretty, v.allowed...)
+	if err != nil || v.template == nil {
+		return err
+	}
+	tmpl, err := v.template.resolve(format)
+	if err != nil {
+		return err
+	}
+	if tmpl != nil {
+		activeOutputTemplate = tmpl
+	}
+	return nil
 }
 
 // MetadataOutputFlags stores pointers to metadata output-related flag values.
@@ -358,6 +377,7 @@ type ResolvedAuthCredentials struct {
 	KeyPath  string
 	KeyPEM   string
 	Profile  string
+	KeyType  string
 }
 
 type resolvedCredentials struct {
@@ -366,6 +386,7 @@ type resolvedCredentials struct {
 	keyPath  string
 	keyPEM   string
 	profile  string
+	keyType  string
 }
 
 type credentialSource struct {
@@ -404,7 +425,7 @@ func resolveCredentials() (resolvedCredentials, error) {
 }
 
 func resolveCredentialsForProfile(profileOverride string) (resolvedCredentials, error) {
-	var actualKeyID, actualIssuerID, actualKeyPath, actualKeyPEM string
+	var actualKeyID, actualIssuerID, actualKeyPath, actualKeyPEM, actualKeyType string
 	actualProfile := ""
 	profile := strings.TrimSpace(profileOverride)
 	if profile == "" {
@@ -433,6 +454,7 @@ func resolveCredentialsForProfile(profileOverride string) (resolvedCredentials,
 		actualKeyPath = cfg.PrivateKeyPath
 		actualKeyPEM = strings.TrimSpace(cfg.PrivateKeyPEM)
 		actualProfile = strings.TrimSpace(cfg.DefaultKeyName)
+		actualKeyType = strings.TrimSpace(cfg.KeyType)
 		sources.keyID = storedSource
 		sources.issuerID = storedSource
 		if actualKeyPath != "" || actualKeyPEM != "" {
@@ -440,8 +462,12 @@ func resolveCredentialsForProfile(profileOverride string) (resolvedCredentials,
 		}
 	}
 
+	// Individual API keys have no issuer ID, so never borrow one from env.
+	individualKey := actualKeyType == config.KeyTypeIndividual
+	missingIssuer := actualIssuerID == "" && !individualKey
+
 	// Priority 2: Environment variables (fallback for CI/CD or when keychain unavailable)
-	if actualKeyID == "" || actualIssuerID == "" || (actualKeyPath == "" && actualKeyPEM == "") {
+	if actualKeyID == "" || missingIssuer || (actualKeyPath == "" && actualKeyPEM == "") {
 		resolved, err := resolveEnvCredentials()
 		if err != nil {
 			return resolvedCredentials{}, fmt.Errorf("invalid private key environment: %w", err)
@@ -451,9 +477,10 @@ func resolveCredentialsForProfile(profileOverride string) (resolvedCredentials,
 			actualKeyID = envCreds.keyID
 			sources.keyID = "env"
 		}
-		if actualIssuerID == "" && envCreds.issuerID != "" {
+		if missingIssuer && envCreds.issuerID != "" {
 			actualIssuerID = envCreds.issuerID
 			sources.issuerID = "env"
+			missingIssuer = false
 		}
 		if actualKeyPath == "" && actualKeyPEM == "" && envCreds.keyPath != "" {
 			actualKeyPath = envCreds.keyPath
@@ -461,7 +488,7 @@ func resolveCredentialsForProfile(profileOverride string) (resolvedCredentials,
 		}
 	}
 
-	if actualKeyID == "" || actualIssuerID == "" || (actualKeyPath == "" && actualKeyPEM == "") {
+	if actualKeyID == "" || missingIssuer || (actualKeyPath == "" && actualKeyPEM == "") {
 		if path, err := config.Path(); err == nil {
 			return resolvedCredentials{}, missingAuthError{msg: fmt.Sprintf("missing authentication. Run 'asc auth login' or create %s (see 'asc auth init')", path)}
 		}
@@ -477,6 +504,7 @@ func resolveCredentialsForProfile(profileOverride string) (resolvedCredentials,
 		keyPath:  actualKeyPath,
 		keyPEM:   actualKeyPEM,
 		profile:  actualProfile,
+		keyType:  config.KeyTypeForIssuer(actualIssuerID),
 	}, nil
 }
 
@@ -750,23 +778,92 @@ func allowsEnvFallbackForStoredError(err error) bool {
 }
 
 func getASCClient() (*asc.Client, error) {
-	resolved, err := resolveCredentials()
-	if err != nil {
-		return nil, err
+	return getASCClientWithTimeout(0)
+}
+
+// sharedClient holds the clients reused while ShareASCClient is in effect,
+// keyed by request timeout (0 for the default).
+var sharedClient struct {
+	mu      sync.Mutex
+	enabled bool
+	clients map[time.Duration]*asc.Client
+}
+
+// ShareASCClient makes GetASCClient and GetASCClientWithTimeout resolve
+// credentials once per timeout and return the same client until the returned
+// function is called. Workflow runs use it so in-process steps share one
+// authenticated client and its connections.
+func ShareASCClient() func() {
+	sharedClient.mu.Lock()
+	defer sharedClient.mu.Unlock()
+	sharedClient.enabled = true
+	sharedClient.clients = nil
+	return func() {
+		sharedClient.mu.Lock()
+		defer sharedClient.mu.Unlock()
+		sharedClient.enabled = false
+		sharedClient.clients = nil
 	}
-	return newASCClientFromResolvedCredentials(resolved, 0)
 }
 
 func getASCClientWithTimeout(timeout time.Duration) (*asc.Client, error) {
+	sharedClient.mu.Lock()
+	enabled := sharedClient.enabled
+	cached := sharedClient.clients[timeout]
+	sharedClient.mu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	// Credentials are resolved without the lock held, since that can wait on
+	// a keychain prompt.
 	resolved, err := resolveCredentials()
 	if err != nil {
+		return replayClientForMissingAuth(err)
+	}
+	client, err := newASCClientFromResolvedCredentials(resolved, timeout)
+	if err != nil || !enabled {
+		return client, err
+	}
+
+	sharedClient.mu.Lock()
+	defer sharedClient.mu.Unlock()
+	if !sharedClient.enabled {
+		return client, nil
+	}
+	if existing := sharedClient.clients[timeout]; existing != nil {
+		return existing, nil
+	}
+	if sharedClient.clients == nil {
+		sharedClient.clients = make(map[time.Duration]*asc.Client)
+	}
+	sharedClient.clients[timeout] = client
+	return client, nil
+}
+
+// replayClientForMissingAuth lets --replay runs work without credentials,
+// since replayed responses never reach App Store Connect.
+func replayClientForMissingAuth(err error) (*asc.Client, error) {
+	if !errors.Is(err, ErrMissingAuth) || !asc.CassetteReplayActive() {
 		return nil, err
 	}
-	return newASCClientFromResolvedCredentials(resolved, timeout)
+	ApplyRootLoggingOverrides()
+	return asc.NewReplayClient()
 }
 
 func newASCClientFromResolvedCredentials(resolved resolvedCredentials, timeout time.Duration) (*asc.Client, error) {
 	ApplyRootLoggingOverrides()
+	client, err := newASCClientForCredentials(resolved, timeout)
+	if err != nil {
+		return nil, err
+	}
+	attachResponseCache(client, resolved.profile)
+	attachAuditLog(client, resolved.profile)
+	applyProfileReadOnly(client, resolved.profile)
+	return client, nil
+}
+
+func newASCClientForCredentials(resolved resolvedCredentials, timeout time.Duration) (*asc.Client, error) {
 	if strings.TrimSpace(resolved.keyPEM) != "" {
 		if timeout > 0 {
 			return asc.NewClientFromPEMWithTimeout(resolved.keyID, resolved.issuerID, resolved.keyPEM, timeout)
@@ -992,6 +1089,12 @@ func printOutput(data any, format string, pretty bool) error {
 	if err != nil {
 		return err
 	}
+	if _, ok := data.(streamedPages); ok {
+		return nil
+	}
+	if projected, err := printProjectedOutput(data, format, pretty); projected || err != nil {
+		return err
+	}
 	switch format {
 	case "json":
 		return printJSONOutput(data, pretty)
@@ -999,6 +1102,12 @@ func printOutput(data any, format string, pretty bool) error {
 		return asc.PrintMarkdown(data)
 	case "table":
 		return asc.PrintTable(data)
+	case "csv", "tsv":
+		return printDelimitedOutput(data, format)
+	case "ndjson":
+		return asc.PrintNDJSON(data)
+	case "template":
+		return renderOutputTemplate(data)
 	default:
 		return fmt.Errorf("unsupported format: %s", format)
 	}
@@ -1009,6 +1118,9 @@ func printOutputWithRenderers(data any, format string, pretty bool, tableRendere
 	if err != nil {
 		return err
 	}
+	if projected, err := printProjectedOutput(data, format, pretty); projected || err != nil {
+		return err
+	}
 	switch format {
 	case "json":
 		return printJSONOutput(data, pretty)
@@ -1022,11 +1134,30 @@ func printOutputWithRenderers(data any, format string, pretty bool, tableRendere
 			return fmt.Errorf("markdown renderer is required")
 		}
 		return markdownRenderer()
+	case "csv", "tsv":
+		return printDelimitedOutput(data, format)
+	case "ndjson":
+		return asc.PrintNDJSON(data)
+	case "template":
+		return renderOutputTemplate(data)
 	default:
 		return fmt.Errorf("unsupported format: %s", format)
 	}
 }
 
+// printDelimitedOutput writes data as CSV or TSV. Only types with a
+// registered row renderer have stable columns; anything else is rejected
+// rather than silently printed as JSON.
+func printDelimitedOutput(data any, format string) error {
+	if !asc.HasRowRenderer(data) {
+		return UsageErrorf("--output %s is not supported by this command; use json, ndjson, table or markdown (or --fields to pick columns)", format)
+	}
+	if format == "tsv" {
+		return asc.PrintTSV(data)
+	}
+	return asc.PrintCSV(data)
+}
+
 func printJSONOutput(data any, pretty bool) error {
 	if pretty {
 		return asc.PrintPrettyJSON(data)
@@ -1045,12 +1176,12 @@ func NormalizeOutputFormat(format string) string {
 }
 
 func validateOutputFormat(format string, pretty bool) (string, error) {
-	return validateOutputFormatAllowed(format, pretty, "json", "table", "markdown")
+	return validateOutputFormatAllowed(format, pretty, "json", "table", "markdown", "csv", "tsv", "ndjson", "template")
 }
 
 func validateOutputFormatAllowed(format string, pretty bool, allowed ...string) (string, error) {
 	if len(allowed) == 0 {
-		allowed = []string{"json", "table", "markdown"}
+		allowed = []string{"json", "table", "markdown", "csv", "tsv", "ndjson"}
 	}
 	normalized := NormalizeOutputFormat(format)
 	if normalized == "" {
@@ -1127,7 +1258,8 @@ var (
 // DefaultOutputFormat returns the default output format for CLI commands.
 // It checks ASC_DEFAULT_OUTPUT first. When unset, interactive terminals default
 // to table output and non-interactive contexts default to JSON.
-// Valid ASC_DEFAULT_OUTPUT values are "json", "table", "markdown", and "md".
+// Valid ASC_DEFAULT_OUTPUT values are "json", "table", "markdown", "md", "csv",
+// "tsv", and "ndjson".
 func DefaultOutputFormat() string {
 	defaultOutputOnce.Do(func() {
 		defaultOutputValue = resolveDefaultOutput()
@@ -1145,37 +1277,42 @@ func resolveDefaultOutput() string {
 	}
 	normalized := strings.ToLower(env)
 	switch normalized {
-	case "json", "table", "markdown", "md":
+	case "json", "table", "markdown", "md", "csv", "tsv", "ndjson":
 		return normalized
 	default:
-		fmt.Fprintf(os.Stderr, "Warning: invalid %s value %q (expected json, table, markdown, or md); using json\n", defaultOutputEnvVar, env)
+		fmt.Fprintf(os.Stderr, "Warning: invalid %s value %q (expected json, table, markdown, md, csv, tsv, or ndjson); using json\n", defaultOutputEnvVar, env)
 		return "json"
 	}
 }
 
 // BindOutputFlagsWith registers a custom output-format flag and --pretty.
 func BindOutputFlagsWith(fs *flag.FlagSet, flagName, defaultValue, usage string) OutputFlags {
-	return BindOutputFlagsWithAllowed(fs, flagName, defaultValue, usage, "json", "table", "markdown")
+	return BindOutputFlagsWithAllowed(fs, flagName, defaultValue, usage, "json", "table", "markdown", "csv", "tsv", "ndjson")
 }
 
 // BindOutputFlagsWithAllowed registers a custom output-format flag and --pretty
 // with an explicit allowed format set.
 func BindOutputFlagsWithAllowed(fs *flag.FlagSet, flagName, defaultValue, usage string, allowed ...string) OutputFlags {
+	return bindOutputFlags(fs, flagName, defaultValue, usage, allowed, nil)
+}
+
+func bindOutputFlags(fs *flag.FlagSet, flagName, defaultValue, usage string, allowed []string, templateFlags *outputTemplateFlags) OutputFlags {
 	name := strings.TrimSpace(flagName)
 	if name == "" {
 		name = "output"
 	}
 
 	if len(allowed) == 0 {
-		allowed = []string{"json", "table", "markdown"}
+		allowed = []string{"json", "table", "markdown", "csv", "tsv", "ndjson"}
 	}
 
 	outputValue := defaultValue
 	prettyValue := false
 	fs.Var(&validatedOutputValue{
-		value:   &outputValue,
-		pretty:  &prettyValue,
-		allowed: slices.Clone(allowed),
+		value:    &outputValue,
+		pretty:   &prettyValue,
+		allowed:  slices.Clone(allowed),
+		template: templateFlags,
 	}, name, usage)
 
 	return OutputFlags{
@@ -1195,9 +1332,22 @@ func bindPrettyJSONFlagWithValue(fs *flag.FlagSet, value *bool) *bool {
 	return value
 }
 
-// BindOutputFlags registers --output and --pretty flags on the provided flagset.
+// BindOutputFlags registers --output, --pretty, --template and --template-file
+// flags on the provided flagset.
 func BindOutputFlags(fs *flag.FlagSet) OutputFlags {
-	return BindOutputFlagsWith(fs, "output", DefaultOutputFormat(), "Output format: json, table, markdown")
+	templateFlags := bindOutputTemplateFlags(fs)
+	return bindOutputFlags(fs, "output", DefaultOutputFormat(), "Output format: json, table, markdown, csv, tsv, ndjson, template",
+		[]string{"json", "table", "markdown", "csv", "tsv", "ndjson", "template"}, &templateFlags)
+}
+
+// BindOutputFlagsWithFormats registers the same flags as BindOutputFlags and
+// also accepts command-specific formats (e.g. sarif) that the caller renders
+// itself before falling back to PrintOutput.
+func BindOutputFlagsWithFormats(fs *flag.FlagSet, extra ...string) OutputFlags {
+	allowed := []string{"json", "table", "markdown", "csv", "tsv", "ndjson", "template"}
+	allowed = append(allowed, extra...)
+	templateFlags := bindOutputTemplateFlags(fs)
+	return bindOutputFlags(fs, "output", DefaultOutputFormat(), "Output format: "+strings.Join(allowed, ", "), allowed, &templateFlags)
 }
 
 // BindMetadataOutputFlags registers --output-format and --pretty flags on the provided flagset.
@@ -1233,6 +1383,7 @@ func ValidateBoundOutputFlags(fs *flag.FlagSet) error {
 }
 
 func validateCommandOutputPath(commands []*ffcli.Command) error {
+	activeOutputTemplate = nil
 	for _, cmd := range commands {
 		if cmd == nil {
 			continue
@@ -1468,7 +1619,15 @@ func validateNextURL(next string) error {
 	if err != nil {
 		return fmt.Errorf("--next must be a valid URL: %w", err)
 	}
-	if parsed.Scheme != "https" || parsed.Host != "api.appstoreconnect.apple.com" {
+	baseURL, err := asc.ResolveBaseURL()
+	if err != nil {
+		return err
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	if parsed.Scheme != base.Scheme || parsed.Host != base.Host {
 		return fmt.Errorf("--next must be an App Store Connect URL")
 	}
 	return nil
@@ -1529,6 +1688,7 @@ func ResolveAuthCredentials(profile string) (ResolvedAuthCredentials, error) {
 		KeyPath:  resolved.keyPath,
 		KeyPEM:   resolved.keyPEM,
 		Profile:  resolved.profile,
+		KeyType:  resolved.keyType,
 	}, nil
 }
 
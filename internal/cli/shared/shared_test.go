@@ -241,6 +241,14 @@ func TestDefaultOutputFormat_MD(t *testing.T) {
 	}
 }
 
+func TestDefaultOutputFormat_CSV(t *testing.T) {
+	resetDefaultOutput(t)
+	t.Setenv("ASC_DEFAULT_OUTPUT", "csv")
+	if got := DefaultOutputFormat(); got != "csv" {
+		t.Fatalf("expected csv, got %q", got)
+	}
+}
+
 func TestDefaultOutputFormat_JSON(t *testing.T) {
 	resetDefaultOutput(t)
 	setTerminalDetection(t, func(int) bool { return true })
@@ -419,6 +427,11 @@ func TestValidateOutputFormat(t *testing.T) {
 		{name: "empty defaults json", input: "", pretty: false, wantFormat: "json"},
 		{name: "json allows pretty", input: "json", pretty: true, wantFormat: "json"},
 		{name: "md alias", input: "md", pretty: false, wantFormat: "markdown"},
+		{name: "csv", input: "CSV", pretty: false, wantFormat: "csv"},
+		{name: "tsv", input: "tsv", pretty: false, wantFormat: "tsv"},
+		{name: "csv pretty rejected", input: "csv", pretty: true, wantErr: "--pretty is only valid with JSON output"},
+		{name: "ndjson", input: "NDJSON", pretty: false, wantFormat: "ndjson"},
+		{name: "ndjson pretty rejected", input: "ndjson", pretty: true, wantErr: "--pretty is only valid with JSON output"},
 		{name: "table pretty rejected", input: "table", pretty: true, wantErr: "--pretty is only valid with JSON output"},
 		{name: "unsupported rejected", input: "yaml", pretty: false, wantErr: "unsupported format: yaml"},
 	}
@@ -1061,6 +1074,61 @@ func TestGetASCClient_ProfileMissingSkipsEnvFallback(t *testing.T) {
 	}
 }
 
+func TestShareASCClient_ReusesClientUntilReleased(t *testing.T) {
+	tempDir := t.TempDir()
+	keyPath := filepath.Join(tempDir, "AuthKey.p8")
+	writeECDSAPEM(t, keyPath)
+
+	t.Setenv("ASC_CONFIG_PATH", filepath.Join(tempDir, "config.json"))
+	t.Setenv("ASC_BYPASS_KEYCHAIN", "1")
+	t.Setenv("ASC_PROFILE", "")
+	t.Setenv("ASC_KEY_ID", "ENVKEY")
+	t.Setenv("ASC_ISSUER_ID", "ENVISS")
+	t.Setenv("ASC_PRIVATE_KEY_PATH", keyPath)
+
+	previousProfile := selectedProfile
+	selectedProfile = ""
+	t.Cleanup(func() {
+		selectedProfile = previousProfile
+	})
+
+	release := ShareASCClient()
+	first, err := getASCClient()
+	if err != nil {
+		t.Fatalf("getASCClient() error: %v", err)
+	}
+	second, err := getASCClient()
+	if err != nil {
+		t.Fatalf("getASCClient() error: %v", err)
+	}
+	if first != second {
+		t.Fatal("expected shared client to be reused")
+	}
+	timed, err := getASCClientWithTimeout(30 * time.Second)
+	if err != nil {
+		t.Fatalf("getASCClientWithTimeout() error: %v", err)
+	}
+	if timed == first {
+		t.Fatal("expected a separate client for a custom timeout")
+	}
+	timedAgain, err := getASCClientWithTimeout(30 * time.Second)
+	if err != nil {
+		t.Fatalf("getASCClientWithTimeout() error: %v", err)
+	}
+	if timedAgain != timed {
+		t.Fatal("expected shared timeout client to be reused")
+	}
+	release()
+
+	third, err := getASCClient()
+	if err != nil {
+		t.Fatalf("getASCClient() error: %v", err)
+	}
+	if third == first {
+		t.Fatal("expected a new client after release")
+	}
+}
+
 func TestResolveCredentials_BypassKeychainPrefersConfigOverEnv(t *testing.T) {
 	resetPrivateKeyTemp(t)
 
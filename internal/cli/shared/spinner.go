@@ -150,6 +150,49 @@ func PaginateWithSpinner(ctx context.Context, fetch FetchFunc, next asc.Paginate
 	return result, err
 }
 
+// streamedPages is returned by PaginateForOutput after pages were already
+// written to stdout; PrintOutput treats it as a no-op.
+type streamedPages struct{}
+
+func (streamedPages) GetLinks() *asc.Links { return nil }
+
+func (streamedPages) GetData() any { return nil }
+
+// PaginateForOutput fetches all pages for a --paginate command. For ndjson
+// output it streams each page's resources to stdout via asc.PaginateEach as
+// pages arrive, instead of aggregating every page in memory, and returns a
+// placeholder that PrintOutput skips. Other formats use PaginateWithSpinner.
+func PaginateForOutput(ctx context.Context, format string, fetch FetchFunc, next asc.PaginateFunc) (asc.PaginatedResponse, error) {
+	if NormalizeOutputFormat(format) != "ndjson" {
+		return PaginateWithSpinner(ctx, fetch, next)
+	}
+	firstPage, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return PaginateAllForOutput(ctx, format, firstPage, next)
+}
+
+// PaginateAllForOutput is asc.PaginateAll for commands that print the
+// result with PrintOutput. For ndjson output, firstPage and every following
+// page are written to stdout as they arrive and the placeholder that
+// PrintOutput skips is returned.
+func PaginateAllForOutput(ctx context.Context, format string, firstPage asc.PaginatedResponse, next asc.PaginateFunc) (asc.PaginatedResponse, error) {
+	if NormalizeOutputFormat(format) != "ndjson" {
+		return asc.PaginateAll(ctx, firstPage, next)
+	}
+	err := asc.PaginateEach(ctx, firstPage, next, func(page asc.PaginatedResponse) error {
+		if projected, err := printProjectedOutput(page, "ndjson", false); projected || err != nil {
+			return err
+		}
+		return asc.PrintNDJSON(page)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return streamedPages{}, nil
+}
+
 func debugOrRetryLogsEnabled() bool {
 	// Root-level flags should take effect immediately, even before shared.GetASCClient() applies
 	// overrides into the asc package, so we need to resolve “effective” values here.
@@ -187,6 +230,7 @@ type spinner struct {
 
 	mu     sync.Mutex
 	maxLen int // rune count of the longest line written (for clearing)
+	label  string
 }
 
 func newSpinner(w io.Writer) *spinner {
@@ -201,10 +245,10 @@ func newSpinner(w io.Writer) *spinner {
 }
 
 func (s *spinner) Start(label string) {
-	label = strings.TrimSpace(label)
+	s.SetLabel(label)
 
 	// Render immediately (helps with short-running operations).
-	s.renderLine(spinnerLine(spinnerFrames[0], label))
+	s.renderLine(spinnerLine(spinnerFrames[0], s.currentLabel()))
 
 	go func() {
 		ticker := time.NewTicker(spinnerTickRate)
@@ -220,12 +264,26 @@ func (s *spinner) Start(label string) {
 			case <-ticker.C:
 				frame := spinnerFrames[i%len(spinnerFrames)]
 				i++
-				s.renderLine(spinnerLine(frame, label))
+				s.renderLine(spinnerLine(frame, s.currentLabel()))
 			}
 		}
 	}()
 }
 
+// SetLabel replaces the text rendered next to the spinner frame; it takes
+// effect on the next tick.
+func (s *spinner) SetLabel(label string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.label = strings.TrimSpace(label)
+}
+
+func (s *spinner) currentLabel() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.label
+}
+
 func (s *spinner) Stop() {
 	s.stopOnce.Do(func() {
 		close(s.stopCh)
@@ -1,6 +1,7 @@
 package shared
 
 import (
+	"context"
 	"errors"
 	"os"
 	"strings"
@@ -349,3 +350,82 @@ func TestWithSpinnerDelayed_StartsWhenSlow(t *testing.T) {
 		t.Fatalf("expected delayed spinner to render initial frame + label, got %q", stderr)
 	}
 }
+
+func TestPaginateForOutput_NDJSONStreamsPagesAsTheyArrive(t *testing.T) {
+	resetSpinnerTestState(t)
+
+	stdoutFile, err := os.CreateTemp(t.TempDir(), "stdout")
+	if err != nil {
+		t.Fatalf("create temp stdout: %v", err)
+	}
+	prevStdout := os.Stdout
+	os.Stdout = stdoutFile
+	t.Cleanup(func() { os.Stdout = prevStdout })
+
+	firstPage := &asc.AppsResponse{
+		Data:  []asc.Resource[asc.AppAttributes]{{Type: asc.ResourceTypeApps, ID: "app-1"}},
+		Links: asc.Links{Next: "https://api.example.com/v1/apps?cursor=2"},
+	}
+	secondPage := &asc.AppsResponse{
+		Data: []asc.Resource[asc.AppAttributes]{{Type: asc.ResourceTypeApps, ID: "app-2"}, {Type: asc.ResourceTypeApps, ID: "app-3"}},
+	}
+
+	resp, err := PaginateForOutput(context.Background(), "ndjson",
+		func(context.Context) (asc.PaginatedResponse, error) {
+			return firstPage, nil
+		},
+		func(context.Context, string) (asc.PaginatedResponse, error) {
+			written, readErr := os.ReadFile(stdoutFile.Name())
+			if readErr != nil {
+				t.Fatalf("read stdout: %v", readErr)
+			}
+			if !strings.Contains(string(written), `"id":"app-1"`) {
+				t.Fatalf("expected first page to be written before fetching the next page, got %q", written)
+			}
+			return secondPage, nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("PaginateForOutput() error: %v", err)
+	}
+	if err := PrintOutput(resp, "ndjson", false); err != nil {
+		t.Fatalf("PrintOutput() error: %v", err)
+	}
+
+	written, err := os.ReadFile(stdoutFile.Name())
+	if err != nil {
+		t.Fatalf("read stdout: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(written), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 NDJSON lines, got %q", written)
+	}
+	for i, id := range []string{"app-1", "app-2", "app-3"} {
+		if !strings.Contains(lines[i], `"id":"`+id+`"`) {
+			t.Fatalf("line %d = %q, want id %s", i, lines[i], id)
+		}
+	}
+}
+
+func TestPaginateForOutput_OtherFormatsAggregate(t *testing.T) {
+	resetSpinnerTestState(t)
+
+	resp, err := PaginateForOutput(context.Background(), "json",
+		func(context.Context) (asc.PaginatedResponse, error) {
+			return &asc.AppsResponse{
+				Data:  []asc.Resource[asc.AppAttributes]{{ID: "app-1"}},
+				Links: asc.Links{Next: "https://api.example.com/v1/apps?cursor=2"},
+			}, nil
+		},
+		func(context.Context, string) (asc.PaginatedResponse, error) {
+			return &asc.AppsResponse{Data: []asc.Resource[asc.AppAttributes]{{ID: "app-2"}}}, nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("PaginateForOutput() error: %v", err)
+	}
+	apps, ok := resp.(*asc.AppsResponse)
+	if !ok || len(apps.Data) != 2 {
+		t.Fatalf("expected aggregated apps response, got %#v", resp)
+	}
+}
@@ -41,3 +41,25 @@ func resetTierCacheDirOverrideForTest() {
 	}
 	_ = os.RemoveAll(override)
 }
+
+// ResetResponseCacheForTest routes response-cache reads and writes to an isolated temp dir for tests.
+func ResetResponseCacheForTest() {
+	responseCacheDirOverrideMu.Lock()
+	override := responseCacheDirOverride
+	if override == "" {
+		tempDir, err := mkdirTempForTest("", "asc-response-cache-*")
+		if err != nil {
+			responseCacheDirOverrideMu.Unlock()
+			panic(fmt.Errorf("create isolated response cache dir: %w", err))
+		}
+		override = tempDir
+		responseCacheDirOverride = override
+	}
+	responseCacheDirOverrideMu.Unlock()
+
+	if err := os.RemoveAll(override); err != nil {
+		panic(fmt.Errorf("reset isolated response cache dir: %w", err))
+	}
+	noCache = false
+	refreshCache = false
+}
@@ -0,0 +1,71 @@
+package shared
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+var (
+	traceFile   string
+	traceFormat string
+)
+
+// BindTraceFlags registers the --trace-file/--trace-format root flags.
+func BindTraceFlags(fs *flag.FlagSet) {
+	fs.StringVar(&traceFile, "trace-file", "", "Write a trace of the run (command, HTTP requests, uploads, polls) to this file")
+	fs.StringVar(&traceFormat, "trace-format", string(asc.TraceFormatOTLP), "Trace file format: otlp (Jaeger) or chrome (Perfetto)")
+}
+
+// ValidateTraceFlags rejects unknown trace formats.
+func ValidateTraceFlags() error {
+	switch asc.TraceFormat(strings.ToLower(strings.TrimSpace(traceFormat))) {
+	case asc.TraceFormatOTLP, asc.TraceFormatChrome:
+		return nil
+	default:
+		return fmt.Errorf("--trace-format must be one of: otlp, chrome (got %q)", traceFormat)
+	}
+}
+
+// StartTrace installs a tracer when --trace-file is set and opens the root
+// span for command. The returned finish function ends the root span with the
+// command result and writes the trace file; it is a no-op without --trace-file.
+func StartTrace(ctx context.Context, command string) (context.Context, func(error) error) {
+	path := strings.TrimSpace(traceFile)
+	if path == "" {
+		return ctx, func(error) error { return nil }
+	}
+	format := asc.TraceFormat(strings.ToLower(strings.TrimSpace(traceFormat)))
+
+	tracer := asc.NewTracer()
+	asc.SetActiveTracer(tracer)
+	ctx, span := asc.StartSpan(ctx, command, map[string]any{"asc.command": command})
+
+	return ctx, func(runErr error) error {
+		span.End(runErr)
+		asc.SetActiveTracer(nil)
+		return writeTrace(path, tracer, format)
+	}
+}
+
+func writeTrace(path string, tracer *asc.Tracer, format asc.TraceFormat) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create trace dir: %w", err)
+		}
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := tracer.Export(file, format); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
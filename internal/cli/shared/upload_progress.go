@@ -0,0 +1,247 @@
+package shared
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+const maxUploadRateEnvVar = "ASC_MAX_UPLOAD_RATE"
+
+// Upload progress modes accepted by --upload-progress.
+const (
+	UploadProgressAuto = "auto"
+	UploadProgressBar  = "bar"
+	UploadProgressJSON = "json"
+	UploadProgressOff  = "off"
+)
+
+const uploadProgressBarWidth = 20
+
+var (
+	maxUploadRate  string
+	uploadProgress string
+)
+
+// BindUploadFlags registers the --max-upload-rate/--upload-progress root flags.
+func BindUploadFlags(fs *flag.FlagSet) {
+	fs.StringVar(&maxUploadRate, "max-upload-rate", "", "Cap upload bandwidth in bytes per second, e.g. 500K or 5MB (or ASC_MAX_UPLOAD_RATE)")
+	fs.StringVar(&uploadProgress, "upload-progress", UploadProgressAuto, "Upload progress on stderr: auto (bar on a terminal, NDJSON when piped), bar, json, or off")
+}
+
+// ValidateUploadFlags rejects malformed upload rates and progress modes.
+func ValidateUploadFlags() error {
+	if _, err := resolveMaxUploadRate(); err != nil {
+		return err
+	}
+	switch normalizeUploadProgressMode(uploadProgress) {
+	case UploadProgressAuto, UploadProgressBar, UploadProgressJSON, UploadProgressOff:
+		return nil
+	default:
+		return fmt.Errorf("--upload-progress must be one of: auto, bar, json, off (got %q)", uploadProgress)
+	}
+}
+
+// SetUploadFlags sets the --max-upload-rate/--upload-progress values (tests only).
+func SetUploadFlags(rate, progress string) {
+	maxUploadRate = rate
+	uploadProgress = progress
+}
+
+// StartUploadProgress installs the upload rate cap and progress reporter for
+// the run. The returned function clears any progress bar still on screen and
+// must be called before the command's result is printed.
+func StartUploadProgress() func() {
+	rate, err := resolveMaxUploadRate()
+	if err != nil {
+		rate = 0
+	}
+	asc.SetMaxUploadRate(rate)
+
+	switch resolveUploadProgressMode() {
+	case UploadProgressBar:
+		bar := &uploadProgressBar{w: os.Stderr}
+		asc.SetUploadProgressReporter(bar.report)
+		return func() {
+			asc.SetUploadProgressReporter(nil)
+			bar.stop()
+		}
+	case UploadProgressJSON:
+		asc.SetUploadProgressReporter(newUploadProgressJSONReporter(os.Stderr))
+		return func() { asc.SetUploadProgressReporter(nil) }
+	default:
+		asc.SetUploadProgressReporter(nil)
+		return func() {}
+	}
+}
+
+func normalizeUploadProgressMode(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return UploadProgressAuto
+	}
+	return value
+}
+
+// resolveUploadProgressMode picks a bar for interactive terminals and NDJSON
+// when stderr is piped, so CI logs stay machine-readable.
+func resolveUploadProgressMode() string {
+	mode := normalizeUploadProgressMode(uploadProgress)
+	if mode != UploadProgressAuto {
+		return mode
+	}
+	if noProgress {
+		return UploadProgressOff
+	}
+	if !isTerminal(int(os.Stderr.Fd())) {
+		return UploadProgressJSON
+	}
+	if SpinnerEnabled() {
+		return UploadProgressBar
+	}
+	return UploadProgressOff
+}
+
+func resolveMaxUploadRate() (int64, error) {
+	value := strings.TrimSpace(maxUploadRate)
+	source := "--max-upload-rate"
+	if value == "" {
+		value = strings.TrimSpace(os.Getenv(maxUploadRateEnvVar))
+		source = maxUploadRateEnvVar
+	}
+	if value == "" {
+		return 0, nil
+	}
+	rate, err := ParseUploadRate(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", source, err)
+	}
+	return rate, nil
+}
+
+// ParseUploadRate parses a bandwidth such as "500K", "5MB", "1.5M/s" or
+// "250000" into bytes per second. Units are binary (K = 1024 bytes).
+func ParseUploadRate(value string) (int64, error) {
+	trimmed := strings.TrimSpace(value)
+	normalized := strings.ToUpper(strings.TrimSuffix(strings.TrimSuffix(trimmed, "/s"), "/S"))
+	normalized = strings.TrimSuffix(strings.TrimSuffix(normalized, "IB"), "B")
+
+	multiplier := float64(1)
+	if n := len(normalized); n > 0 {
+		switch normalized[n-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		}
+		if multiplier > 1 {
+			normalized = normalized[:n-1]
+		}
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(normalized), 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("invalid upload rate %q (use bytes per second, e.g. 500K or 5MB)", value)
+	}
+	rate := number * multiplier
+	if rate < 1 {
+		return 0, fmt.Errorf("upload rate must be at least 1 byte per second (got %q)", value)
+	}
+	return int64(rate), nil
+}
+
+func newUploadProgressJSONReporter(w io.Writer) asc.UploadProgressReporter {
+	var mu sync.Mutex
+	return func(progress asc.UploadProgress) {
+		data, err := json.Marshal(progress)
+		if err != nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = w.Write(append(data, '\n'))
+	}
+}
+
+// uploadProgressBar renders progress on the shared spinner line, starting a
+// spinner with the first event of each upload and clearing it on completion.
+type uploadProgressBar struct {
+	w io.Writer
+
+	mu      sync.Mutex
+	spinner *spinner
+}
+
+func (b *uploadProgressBar) report(progress asc.UploadProgress) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if progress.Event == asc.UploadProgressEventComplete {
+		if b.spinner != nil {
+			b.spinner.Stop()
+			b.spinner = nil
+		}
+		return
+	}
+	label := formatUploadProgressBar(progress)
+	if b.spinner == nil {
+		b.spinner = newSpinner(b.w)
+		b.spinner.Start(label)
+		return
+	}
+	b.spinner.SetLabel(label)
+}
+
+func (b *uploadProgressBar) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.spinner != nil {
+		b.spinner.Stop()
+		b.spinner = nil
+	}
+}
+
+func formatUploadProgressBar(progress asc.UploadProgress) string {
+	fraction := 0.0
+	if progress.TotalBytes > 0 {
+		fraction = math.Min(float64(progress.BytesSent)/float64(progress.TotalBytes), 1)
+	}
+	filled := int(fraction * uploadProgressBarWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", uploadProgressBarWidth-filled)
+
+	line := fmt.Sprintf("%s %s %3.0f%% %s/%s · %d/%d parts",
+		progress.File, bar, fraction*100,
+		formatUploadBytes(float64(progress.BytesSent)), formatUploadBytes(float64(progress.TotalBytes)),
+		progress.PartsDone, progress.TotalParts)
+	if progress.BytesPerSecond > 0 {
+		line += " · " + formatUploadBytes(progress.BytesPerSecond) + "/s"
+	}
+	if progress.ETASeconds > 0 {
+		line += " · ETA " + (time.Duration(progress.ETASeconds) * time.Second).String()
+	}
+	return line
+}
+
+func formatUploadBytes(value float64) string {
+	units := []string{"B", "KB", "MB", "GB"}
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%.0f %s", value, units[unit])
+	}
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
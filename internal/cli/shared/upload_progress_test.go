@@ -0,0 +1,114 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+func TestParseUploadRate(t *testing.T) {
+	tests := map[string]int64{
+		"250000":  250000,
+		"500K":    500 * 1024,
+		"500kb":   500 * 1024,
+		"5MB":     5 * 1024 * 1024,
+		"5MiB/s":  5 * 1024 * 1024,
+		"1.5M":    3 * 512 * 1024,
+		" 1G ":    1 << 30,
+		"2048B/s": 2048,
+	}
+	for input, want := range tests {
+		got, err := ParseUploadRate(input)
+		if err != nil {
+			t.Fatalf("ParseUploadRate(%q) error: %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("ParseUploadRate(%q) = %d, want %d", input, got, want)
+		}
+	}
+
+	for _, input := range []string{"fast", "5XB", "0", "-1M", "0.1"} {
+		if _, err := ParseUploadRate(input); err == nil {
+			t.Fatalf("ParseUploadRate(%q) expected error", input)
+		}
+	}
+}
+
+func TestValidateUploadFlags(t *testing.T) {
+	t.Cleanup(func() { SetUploadFlags("", UploadProgressAuto) })
+
+	SetUploadFlags("5MB", "JSON")
+	if err := ValidateUploadFlags(); err != nil {
+		t.Fatalf("ValidateUploadFlags() error: %v", err)
+	}
+
+	SetUploadFlags("", "loud")
+	if err := ValidateUploadFlags(); err == nil || !strings.Contains(err.Error(), "--upload-progress") {
+		t.Fatalf("expected --upload-progress error, got %v", err)
+	}
+
+	SetUploadFlags("", UploadProgressAuto)
+	t.Setenv(maxUploadRateEnvVar, "lots")
+	if err := ValidateUploadFlags(); err == nil || !strings.Contains(err.Error(), maxUploadRateEnvVar) {
+		t.Fatalf("expected %s error, got %v", maxUploadRateEnvVar, err)
+	}
+}
+
+func TestUploadProgressJSONReporterWritesNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	report := newUploadProgressJSONReporter(&buf)
+	report(asc.UploadProgress{Event: asc.UploadProgressEventProgress, File: "app.ipa", BytesSent: 10, TotalBytes: 20, PartsDone: 1, TotalParts: 2, BytesPerSecond: 5, ETASeconds: 2})
+	report(asc.UploadProgress{Event: asc.UploadProgressEventComplete, File: "app.ipa", BytesSent: 20, TotalBytes: 20, PartsDone: 2, TotalParts: 2})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", buf.String())
+	}
+	var first map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("unmarshal line: %v", err)
+	}
+	for _, key := range []string{"event", "file", "bytesSent", "totalBytes", "partsDone", "totalParts", "bytesPerSecond", "etaSeconds"} {
+		if _, ok := first[key]; !ok {
+			t.Fatalf("expected %q in %s", key, lines[0])
+		}
+	}
+	if !strings.Contains(lines[1], `"event":"upload.complete"`) {
+		t.Fatalf("expected completion event, got %s", lines[1])
+	}
+}
+
+func TestFormatUploadProgressBar(t *testing.T) {
+	line := formatUploadProgressBar(asc.UploadProgress{
+		File:           "app.ipa",
+		BytesSent:      5 << 20,
+		TotalBytes:     10 << 20,
+		PartsDone:      3,
+		TotalParts:     6,
+		BytesPerSecond: 1 << 20,
+		ETASeconds:     5,
+	})
+	want := "app.ipa ██████████░░░░░░░░░░  50% 5.0 MB/10.0 MB · 3/6 parts · 1.0 MB/s · ETA 5s"
+	if line != want {
+		t.Fatalf("formatUploadProgressBar() = %q, want %q", line, want)
+	}
+}
+
+func TestUploadProgressBarClearsLineOnCompletion(t *testing.T) {
+	var buf bytes.Buffer
+	bar := &uploadProgressBar{w: &buf}
+	bar.report(asc.UploadProgress{Event: asc.UploadProgressEventProgress, File: "shot.png", BytesSent: 1, TotalBytes: 2, TotalParts: 1})
+	bar.report(asc.UploadProgress{Event: asc.UploadProgressEventComplete, File: "shot.png", BytesSent: 2, TotalBytes: 2, PartsDone: 1, TotalParts: 1})
+	bar.stop()
+
+	out := buf.String()
+	if !strings.Contains(out, "shot.png") {
+		t.Fatalf("expected the bar to render the file name, got %q", out)
+	}
+	if !strings.HasSuffix(out, "\r") {
+		t.Fatalf("expected the bar line to be cleared, got %q", out)
+	}
+}
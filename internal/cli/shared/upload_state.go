@@ -0,0 +1,25 @@
+package shared
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+)
+
+const uploadStateDirEnvVar = "ASC_UPLOAD_STATE_DIR"
+
+// UploadStateStore returns the store for resumable upload progress
+// (ASC_UPLOAD_STATE_DIR, default ~/.asc/uploads). It returns nil, which
+// disables resuming, when no home directory can be resolved.
+func UploadStateStore() *asc.UploadStateStore {
+	if dir := strings.TrimSpace(os.Getenv(uploadStateDirEnvVar)); dir != "" {
+		return asc.NewUploadStateStore(dir)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || strings.TrimSpace(home) == "" {
+		return nil
+	}
+	return asc.NewUploadStateStore(filepath.Join(home, ".asc", "uploads"))
+}
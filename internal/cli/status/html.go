@@ -0,0 +1,185 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// buildStatusHTMLReport turns the dashboard into a release-health summary for
+// readers who do not use the terminal: headline cards, blockers, the next
+// step, and one table per included section.
+func buildStatusHTMLReport(resp *dashboardResponse) shared.HTMLReport {
+	summary := resp.Summary
+	if summary.Health == "" {
+		summary = buildStatusSummary(resp)
+	}
+
+	title := "Release Status"
+	subtitle := ""
+	if resp.App != nil {
+		title = "Release Status: " + shared.OrNA(resp.App.Name)
+		subtitle = versionDetail(resp.App.BundleID, resp.App.ID)
+	}
+
+	cards := []shared.HTMLReportCard{
+		{Label: "Health", Value: strings.ToUpper(shared.OrNA(summary.Health)), Tone: healthTone(summary.Health)},
+		{Label: "Blockers", Value: fmt.Sprintf("%d", len(summary.Blockers)), Tone: countTone(len(summary.Blockers), shared.HTMLToneBad)},
+	}
+	if resp.AppStore != nil {
+		cards = append(cards, shared.HTMLReportCard{Label: "App Store", Value: shared.OrNA(resp.AppStore.State), Detail: versionDetail(resp.AppStore.Version, resp.AppStore.Platform), Tone: stateTone(resp.AppStore.State)})
+	}
+	if resp.Review != nil {
+		cards = append(cards, shared.HTMLReportCard{Label: "Review", Value: shared.OrNA(resp.Review.State), Detail: formatDateWithRelative(resp.Review.SubmittedDate), Tone: stateTone(resp.Review.State)})
+	}
+	if resp.Builds != nil && resp.Builds.Latest != nil {
+		latest := resp.Builds.Latest
+		cards = append(cards, shared.HTMLReportCard{Label: "Latest Build", Value: versionDetail(latest.Version, latest.BuildNumber), Detail: formatDateWithRelative(latest.UploadedDate), Tone: stateTone(latest.ProcessingState)})
+	}
+	if resp.PhasedRelease != nil && resp.PhasedRelease.Configured {
+		cards = append(cards, shared.HTMLReportCard{Label: "Phased Release", Value: fmt.Sprintf("Day %d", resp.PhasedRelease.CurrentDayNumber), Detail: shared.OrNA(resp.PhasedRelease.State), Tone: stateTone(resp.PhasedRelease.State)})
+	}
+
+	blockers := make([]shared.HTMLReportItem, 0, len(summary.Blockers))
+	for _, blocker := range summary.Blockers {
+		blockers = append(blockers, shared.HTMLReportItem{Text: blocker, Tone: shared.HTMLToneBad})
+	}
+	sections := []shared.HTMLReportSection{
+		{Title: "Blockers", Items: blockers, Empty: "No blockers."},
+		{Title: "Next Step", Steps: []shared.HTMLReportItem{{Text: shared.OrNA(summary.NextAction)}}},
+	}
+
+	if resp.Builds != nil {
+		if resp.Builds.Latest == nil {
+			sections = append(sections, shared.HTMLReportSection{Title: "Builds", Empty: "No builds found."})
+		} else {
+			latest := resp.Builds.Latest
+			sections = append(sections, statusFieldSection("Builds", [][2]string{
+				{"Build ID", latest.ID},
+				{"Version", latest.Version},
+				{"Build number", latest.BuildNumber},
+				{"Processing state", latest.ProcessingState},
+				{"Uploaded", formatDateWithRelative(latest.UploadedDate)},
+				{"Platform", latest.Platform},
+			}))
+		}
+	}
+	if resp.TestFlight != nil {
+		sections = append(sections, statusFieldSection("TestFlight", [][2]string{
+			{"Latest distributed build", resp.TestFlight.LatestDistributedBuildID},
+			{"Beta review state", resp.TestFlight.BetaReviewState},
+			{"External build state", resp.TestFlight.ExternalBuildState},
+			{"Submitted", formatDateWithRelative(resp.TestFlight.SubmittedDate)},
+		}))
+	}
+	if resp.AppStore != nil {
+		sections = append(sections, statusFieldSection("App Store", [][2]string{
+			{"Version", resp.AppStore.Version},
+			{"State", resp.AppStore.State},
+			{"Platform", resp.AppStore.Platform},
+			{"Created", formatDateWithRelative(resp.AppStore.CreatedDate)},
+		}))
+	}
+	if resp.Submission != nil {
+		sections = append(sections, statusFieldSection("Submission", [][2]string{
+			{"In flight", fmt.Sprintf("%t", resp.Submission.InFlight)},
+			{"Blocking issues", fmt.Sprintf("%d", len(resp.Submission.BlockingIssues))},
+		}))
+	}
+	if resp.Review != nil {
+		sections = append(sections, statusFieldSection("Review", [][2]string{
+			{"Latest submission", resp.Review.LatestSubmissionID},
+			{"State", resp.Review.State},
+			{"Submitted", formatDateWithRelative(resp.Review.SubmittedDate)},
+			{"Platform", resp.Review.Platform},
+		}))
+	}
+	if resp.PhasedRelease != nil {
+		sections = append(sections, statusFieldSection("Phased Release", [][2]string{
+			{"Configured", fmt.Sprintf("%t", resp.PhasedRelease.Configured)},
+			{"State", resp.PhasedRelease.State},
+			{"Started", formatDateWithRelative(resp.PhasedRelease.StartDate)},
+			{"Current day", fmt.Sprintf("%d", resp.PhasedRelease.CurrentDayNumber)},
+			{"Progress", phasedReleaseProgressBar(resp.PhasedRelease)},
+		}))
+	}
+	if resp.Links != nil {
+		sections = append(sections, statusFieldSection("Links", [][2]string{
+			{"App Store Connect", resp.Links.AppStoreConnect},
+			{"TestFlight", resp.Links.TestFlight},
+			{"Review", resp.Links.Review},
+		}))
+	}
+
+	return shared.HTMLReport{
+		Title:       title,
+		Subtitle:    subtitle,
+		GeneratedAt: statusNow().UTC().Format(time.RFC3339),
+		Cards:       cards,
+		Sections:    sections,
+	}
+}
+
+func statusFieldSection(title string, fields [][2]string) shared.HTMLReportSection {
+	rows := make([][]shared.HTMLReportCell, 0, len(fields))
+	for _, field := range fields {
+		value := shared.HTMLReportCell{Text: shared.OrNA(field[1])}
+		if strings.Contains(strings.ToLower(field[0]), "state") {
+			value.Tone = stateTone(field[1])
+		}
+		rows = append(rows, []shared.HTMLReportCell{{Text: field[0]}, value})
+	}
+	return shared.HTMLReportSection{
+		Title: title,
+		Table: &shared.HTMLReportTable{Headers: []string{"Field", "Value"}, Rows: rows},
+	}
+}
+
+func versionDetail(parts ...string) string {
+	values := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if strings.TrimSpace(part) != "" {
+			values = append(values, strings.TrimSpace(part))
+		}
+	}
+	if len(values) == 0 {
+		return "n/a"
+	}
+	return strings.Join(values, " · ")
+}
+
+func healthTone(health string) string {
+	switch strings.ToLower(strings.TrimSpace(health)) {
+	case "green":
+		return shared.HTMLToneGood
+	case "yellow":
+		return shared.HTMLToneWarning
+	case "red":
+		return shared.HTMLToneBad
+	default:
+		return shared.HTMLToneNeutral
+	}
+}
+
+// stateTone reuses the table/markdown state symbols so colors match them.
+func stateTone(state string) string {
+	switch stateSymbol(state) {
+	case "[x]":
+		return shared.HTMLToneBad
+	case "[~]":
+		return shared.HTMLToneWarning
+	case "[+]":
+		return shared.HTMLToneGood
+	default:
+		return shared.HTMLToneNeutral
+	}
+}
+
+func countTone(count int, tone string) string {
+	if count == 0 {
+		return shared.HTMLToneGood
+	}
+	return tone
+}
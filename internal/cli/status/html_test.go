@@ -0,0 +1,56 @@
+package status
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+func TestBuildStatusHTMLReport_SummarizesHealthAndBlockers(t *testing.T) {
+	originalNow := statusNow
+	statusNow = func() time.Time {
+		return time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+	}
+	t.Cleanup(func() {
+		statusNow = originalNow
+	})
+
+	resp := &dashboardResponse{
+		App: &statusApp{ID: "app-1", BundleID: "com.example.app", Name: "Example"},
+		Summary: statusSummary{
+			Health:     "red",
+			NextAction: "Fix the invalid build",
+			Blockers:   []string{"Latest build processing state is INVALID"},
+		},
+		Builds:   &buildsSection{Latest: &latestBuild{ID: "build-1", Version: "1.2.0", BuildNumber: "42", ProcessingState: "INVALID"}},
+		AppStore: &appStoreSection{Version: "1.2.0", State: "PREPARE_FOR_SUBMISSION", Platform: "IOS"},
+	}
+
+	report := buildStatusHTMLReport(resp)
+	if report.Title != "Release Status: Example" || report.GeneratedAt != "2026-02-20T12:00:00Z" {
+		t.Fatalf("unexpected report header: %+v", report)
+	}
+	if report.Cards[0].Value != "RED" || report.Cards[0].Tone != shared.HTMLToneBad {
+		t.Fatalf("unexpected health card: %+v", report.Cards[0])
+	}
+	if report.Cards[1].Value != "1" || report.Cards[1].Tone != shared.HTMLToneBad {
+		t.Fatalf("unexpected blockers card: %+v", report.Cards[1])
+	}
+
+	html, err := shared.RenderHTMLReport(report)
+	if err != nil {
+		t.Fatalf("RenderHTMLReport error: %v", err)
+	}
+	for _, want := range []string{
+		`<li class="tone-bad">Latest build processing state is INVALID</li>`,
+		"Fix the invalid build",
+		"<h2>Builds</h2>",
+		"<h2>App Store</h2>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("expected HTML to contain %q:\n%s", want, html)
+		}
+	}
+}
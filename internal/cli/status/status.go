@@ -137,7 +137,7 @@ func StatusCommand() *ffcli.Command {
 	watch := fs.Bool("watch", false, "Poll and emit snapshots when status changes")
 	pollInterval := fs.Duration("poll-interval", 30*time.Second, "Polling interval for --watch")
 	maxPolls := fs.Int("max-polls", 0, "Maximum polls for --watch (0 = unlimited)")
-	output := shared.BindOutputFlags(fs)
+	output := shared.BindOutputFlagsWithFormats(fs, "html")
 
 	return &ffcli.Command{
 		Name:       "status",
@@ -154,7 +154,8 @@ Examples:
   asc status --app "My App"
   asc status --app "123456789" --include builds,testflight,submission
   asc status --app "123456789" --watch --poll-interval 15s
-  asc status --app "123456789" --output table`,
+  asc status --app "123456789" --output table
+  asc status --app "123456789" --output html > status.html`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Exec: func(ctx context.Context, args []string) error {
@@ -182,6 +183,10 @@ Examples:
 			if *maxPolls > 0 && !*watch {
 				return shared.UsageError("--max-polls requires --watch")
 			}
+			htmlOutput := shared.NormalizeOutputFormat(*output.Output) == "html"
+			if htmlOutput && *watch {
+				return shared.UsageError("--output html is not supported with --watch")
+			}
 
 			client, err := shared.GetASCClient()
 			if err != nil {
@@ -207,6 +212,9 @@ Examples:
 				return fmt.Errorf("status: %w", err)
 			}
 
+			if htmlOutput {
+				return shared.PrintHTMLReport(buildStatusHTMLReport(resp))
+			}
 			return shared.PrintOutputWithRenderers(
 				resp,
 				*output.Output,
@@ -139,7 +139,7 @@ Examples:
 
 				if *paginate {
 					paginateOpts := append(opts, asc.WithBetaGroupsLimit(200))
-					groups, err := shared.PaginateWithSpinner(requestCtx,
+					groups, err := shared.PaginateForOutput(requestCtx, *output.Output,
 						func(ctx context.Context) (asc.PaginatedResponse, error) {
 							return client.ListBetaGroups(ctx, paginateOpts...)
 						},
@@ -221,7 +221,7 @@ Examples:
 
 			if *paginate {
 				paginateOpts := append(opts, asc.WithBetaGroupsLimit(200))
-				groups, err := shared.PaginateWithSpinner(requestCtx,
+				groups, err := shared.PaginateForOutput(requestCtx, *output.Output,
 					func(ctx context.Context) (asc.PaginatedResponse, error) {
 						return client.GetBetaGroups(ctx, resolvedAppID, paginateOpts...)
 					},
@@ -98,7 +98,7 @@ Examples:
 				if err != nil {
 					return fmt.Errorf("beta-license-agreements list: failed to fetch: %w", err)
 				}
-				agreements, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				agreements, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetBetaLicenseAgreements(ctx, asc.WithBetaLicenseAgreementsNextURL(nextURL))
 				})
 				if err != nil {
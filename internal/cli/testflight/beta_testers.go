@@ -143,7 +143,7 @@ Examples:
 
 			if *paginate {
 				paginateOpts := append(opts, asc.WithBetaTestersLimit(200))
-				testers, err := shared.PaginateWithSpinner(requestCtx,
+				testers, err := shared.PaginateForOutput(requestCtx, *output.Output,
 					func(ctx context.Context) (asc.PaginatedResponse, error) {
 						return client.GetBetaTesters(ctx, resolvedAppID, paginateOpts...)
 					},
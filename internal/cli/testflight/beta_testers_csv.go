@@ -66,7 +66,7 @@ func BetaTestersExportCommand() *ffcli.Command {
 	buildID, legacyBuildID := bindBuildIDFlag(fs, "Build ID to filter (optional)")
 	email := fs.String("email", "", "Filter by tester email (optional)")
 	includeGroups := fs.Bool("include-groups", false, "Include a groups column (requires additional API calls)")
-	format := shared.BindOutputFlagsWith(fs, "format", "json", "Summary output format: json (default), table, markdown")
+	format := shared.BindOutputFlagsWith(fs, "format", "json", "Summary output format: json (default), table, markdown, csv, tsv, ndjson")
 
 	return &ffcli.Command{
 		Name:       "export",
@@ -259,7 +259,7 @@ func BetaTestersImportCommand() *ffcli.Command {
 	group := fs.String("group", "", "Beta group name or ID to apply to all rows (optional)")
 	skipExisting := fs.Bool("skip-existing", false, "If tester already exists, do not modify group membership")
 	continueOnError := fs.Bool("continue-on-error", true, "Continue processing rows after failures (default true)")
-	format := shared.BindOutputFlagsWith(fs, "format", "json", "Summary output format: json (default), table, markdown")
+	format := shared.BindOutputFlagsWith(fs, "format", "json", "Summary output format: json (default), table, markdown, csv, tsv, ndjson")
 
 	return &ffcli.Command{
 		Name:       "import",
@@ -0,0 +1,259 @@
+package undo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+)
+
+// UndoCommand returns the undo command.
+func UndoCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("undo", flag.ExitOnError)
+
+	logPath := fs.String("log", "", "Audit log file (default: ASC_AUDIT_LOG or audit_log in config.json)")
+	confirm := fs.Bool("confirm", false, "Confirm sending the reversing request")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "undo",
+		ShortUsage: "asc undo <entry-id> --confirm [flags]",
+		ShortHelp:  "Reverse a change recorded in the mutation audit log.",
+		LongHelp: `Reverse a change recorded in the mutation audit log.
+
+When ASC_AUDIT_LOG (or audit_log in config.json) names a file, every
+mutating API request is appended to it as one JSON line with the command
+line, profile, method, path, redacted body, status and resource IDs. Before
+a PATCH the current resource is fetched and stored with the entry, so the
+change can be reversed later:
+  - attribute updates (localizations, beta group settings, ...) restore the
+    previous values of the changed attributes
+  - relationship additions and removals are sent in reverse
+  - created resources are deleted
+
+Deleted resources cannot be recreated; their prior state stays in the log
+for manual recovery. Price schedules and availabilities cannot be deleted
+through the API, so price and availability changes cannot be undone. The undo request is itself audited with undoOf set.
+
+Examples:
+  asc undo list
+  asc undo 3f9a2c71b0de --confirm
+  asc --plan undo 3f9a2c71b0de --confirm`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Subcommands: []*ffcli.Command{
+			undoListCommand(),
+		},
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return shared.UsageError("entry ID is required (see: asc undo list)")
+			}
+			entryID := args[0]
+			// Allow flags after the entry ID.
+			if err := shared.ParseTrailingFlags(fs, args[1:]); err != nil {
+				return err
+			}
+			if !*confirm {
+				return shared.UsageError("--confirm is required")
+			}
+
+			entries, path, err := loadEntries(*logPath)
+			if err != nil {
+				return fmt.Errorf("undo: %w", err)
+			}
+			entry, err := asc.FindAuditEntry(entries, entryID)
+			if err != nil {
+				return fmt.Errorf("undo: %w", err)
+			}
+			if slices.ContainsFunc(entries, func(candidate asc.AuditEntry) bool {
+				return candidate.UndoOf == entry.ID && candidate.Succeeded()
+			}) {
+				return fmt.Errorf("undo: entry %s was already undone", entry.ID)
+			}
+			request, err := entry.Undo()
+			if err != nil {
+				return fmt.Errorf("undo: %w", err)
+			}
+
+			creds, err := shared.ResolveAuthCredentialsMetadata(shared.ResolveProfileName())
+			if err != nil {
+				return fmt.Errorf("undo: %w", err)
+			}
+			if entry.KeyID != "" && creds.KeyID != entry.KeyID {
+				return fmt.Errorf("undo: entry %s was made with key %s (profile %q) but the current key is %s; select that profile with --profile", entry.ID, entry.KeyID, entry.Profile, creds.KeyID)
+			}
+
+			client, err := shared.GetASCClient()
+			if err != nil {
+				return fmt.Errorf("undo: %w", err)
+			}
+
+			requestCtx, cancel := shared.ContextWithTimeout(ctx)
+			defer cancel()
+
+			var body io.Reader
+			if len(request.Body) > 0 {
+				body = bytes.NewReader(request.Body)
+			}
+			if _, err := client.RawRequest(asc.ContextWithAuditUndo(requestCtx, entry.ID), request.Method, request.Path, body); err != nil {
+				return fmt.Errorf("undo: %w", err)
+			}
+
+			result := &undoResult{
+				EntryID: entry.ID,
+				Log:     path,
+				Request: *request,
+			}
+			return shared.PrintOutput(result, *output.Output, *output.Pretty)
+		},
+	}
+}
+
+type undoResult struct {
+	EntryID string          `json:"entryId"`
+	Log     string          `json:"log"`
+	Request asc.UndoRequest `json:"request"`
+}
+
+type undoListEntry struct {
+	ID       string    `json:"id"`
+	Time     time.Time `json:"time"`
+	Profile  string    `json:"profile,omitempty"`
+	Command  string    `json:"command,omitempty"`
+	Method   string    `json:"method"`
+	Path     string    `json:"path"`
+	Status   int       `json:"status,omitempty"`
+	UndoOf   string    `json:"undoOf,omitempty"`
+	Undone   bool      `json:"undone"`
+	Undoable bool      `json:"undoable"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+type undoListResponse struct {
+	Log     string          `json:"log"`
+	Entries []undoListEntry `json:"entries"`
+}
+
+func undoListCommand() *ffcli.Command {
+	fs := flag.NewFlagSet("undo list", flag.ExitOnError)
+
+	logPath := fs.String("log", "", "Audit log file (default: ASC_AUDIT_LOG or audit_log in config.json)")
+	limit := fs.Int("limit", 20, "Maximum number of entries to show, newest first (0 for all)")
+	output := shared.BindOutputFlags(fs)
+
+	return &ffcli.Command{
+		Name:       "list",
+		ShortUsage: "asc undo list [flags]",
+		ShortHelp:  "List recent audit log entries and whether they can be undone.",
+		LongHelp: `List recent audit log entries and whether they can be undone.
+
+Examples:
+  asc undo list
+  asc undo list --limit 50 --output table`,
+		FlagSet:   fs,
+		UsageFunc: shared.DefaultUsageFunc,
+		Exec: func(_ context.Context, args []string) error {
+			if len(args) > 0 {
+				return shared.UsageErrorf("unexpected argument(s): %s", strings.Join(args, " "))
+			}
+			if *limit < 0 {
+				return shared.UsageError("--limit must be 0 or greater")
+			}
+
+			entries, path, err := loadEntries(*logPath)
+			if err != nil {
+				return fmt.Errorf("undo list: %w", err)
+			}
+
+			undone := map[string]bool{}
+			for _, entry := range entries {
+				if entry.UndoOf != "" && entry.Succeeded() {
+					undone[entry.UndoOf] = true
+				}
+			}
+
+			resp := &undoListResponse{Log: path, Entries: []undoListEntry{}}
+			for i := len(entries) - 1; i >= 0; i-- {
+				if *limit > 0 && len(resp.Entries) == *limit {
+					break
+				}
+				entry := entries[i]
+				item := undoListEntry{
+					ID:      entry.ID,
+					Time:    entry.Time,
+					Profile: entry.Profile,
+					Command: entry.Command,
+					Method:  entry.Method,
+					Path:    entry.Path,
+					Status:  entry.Status,
+					UndoOf:  entry.UndoOf,
+					Undone:  undone[entry.ID],
+				}
+				if _, err := entry.Undo(); err != nil {
+					item.Reason = err.Error()
+				} else {
+					item.Undoable = !item.Undone
+				}
+				resp.Entries = append(resp.Entries, item)
+			}
+
+			return shared.PrintOutputWithRenderers(
+				resp,
+				*output.Output,
+				*output.Pretty,
+				func() error { renderUndoList(resp, false); return nil },
+				func() error { renderUndoList(resp, true); return nil },
+			)
+		},
+	}
+}
+
+func renderUndoList(resp *undoListResponse, markdown bool) {
+	rows := make([][]string, 0, len(resp.Entries))
+	for _, entry := range resp.Entries {
+		rows = append(rows, []string{
+			entry.ID,
+			entry.Time.Format(time.RFC3339),
+			entry.Profile,
+			entry.Method,
+			entry.Path,
+			strconv.Itoa(entry.Status),
+			strconv.FormatBool(entry.Undoable),
+		})
+	}
+	render := asc.RenderTable
+	if markdown {
+		render = asc.RenderMarkdown
+	}
+	render([]string{"id", "time", "profile", "method", "path", "status", "undoable"}, rows)
+}
+
+func loadEntries(logPath string) ([]asc.AuditEntry, string, error) {
+	path := strings.TrimSpace(logPath)
+	if path == "" {
+		path = shared.AuditLogPath()
+	}
+	if path == "" {
+		return nil, "", shared.UsageError("no audit log configured (set ASC_AUDIT_LOG, audit_log in config.json, or pass --log)")
+	}
+	entries, err := asc.ReadAuditLog(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, path, fmt.Errorf("audit log %s does not exist yet", path)
+		}
+		return nil, path, err
+	}
+	return entries, path, nil
+}
@@ -107,7 +107,7 @@ Examples:
 					return fmt.Errorf("users list: failed to fetch: %w", err)
 				}
 
-				users, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				users, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetUsers(ctx, asc.WithUsersNextURL(nextURL))
 				})
 				if err != nil {
@@ -470,7 +470,7 @@ Examples:
 					return fmt.Errorf("users invites list: failed to fetch: %w", err)
 				}
 
-				invites, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				invites, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetUserInvitations(ctx, asc.WithUserInvitationsNextURL(nextURL))
 				})
 				if err != nil {
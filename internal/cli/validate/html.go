@@ -0,0 +1,113 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
+)
+
+// buildValidateHTMLReport summarizes readiness for a shareable HTML page:
+// severity counts, blocking checks, the ordered remediation plan and every
+// check that ran.
+func buildValidateHTMLReport(report validation.Report) shared.HTMLReport {
+	summary := report.Summary
+	readiness := "Ready"
+	readinessTone := shared.HTMLToneGood
+	if summary.Blocking > 0 {
+		readiness = "Blocked"
+		readinessTone = shared.HTMLToneBad
+	}
+
+	cards := []shared.HTMLReportCard{
+		{Label: "Readiness", Value: readiness, Tone: readinessTone},
+		{Label: "Blocking", Value: fmt.Sprintf("%d", summary.Blocking), Tone: countTone(summary.Blocking, shared.HTMLToneBad)},
+		{Label: "Errors", Value: fmt.Sprintf("%d", summary.Errors), Tone: countTone(summary.Errors, shared.HTMLToneBad)},
+		{Label: "Warnings", Value: fmt.Sprintf("%d", summary.Warnings), Tone: countTone(summary.Warnings, shared.HTMLToneWarning)},
+		{Label: "Infos", Value: fmt.Sprintf("%d", summary.Infos)},
+	}
+
+	blockers := make([]shared.HTMLReportItem, 0, summary.Blocking)
+	steps := make([]shared.HTMLReportItem, 0, len(report.Remediation.Steps))
+	for _, step := range report.Remediation.Steps {
+		item := shared.HTMLReportItem{
+			Text:   fmt.Sprintf("[%s] %s", step.Severity, step.Message),
+			Detail: remediationDetail(step),
+			Tone:   severityTone(step.Severity),
+		}
+		if step.Blocking {
+			blockers = append(blockers, item)
+		}
+		steps = append(steps, item)
+	}
+
+	rows := make([][]shared.HTMLReportCell, 0, len(report.Checks))
+	for _, check := range report.Checks {
+		rows = append(rows, []shared.HTMLReportCell{
+			{Text: string(check.Severity), Tone: severityTone(check.Severity)},
+			{Text: check.ID},
+			{Text: shared.OrNA(check.Locale)},
+			{Text: shared.OrNA(check.Field)},
+			{Text: check.Message},
+		})
+	}
+	checks := shared.HTMLReportSection{Title: "Checks", Empty: "No checks reported."}
+	if len(rows) > 0 {
+		checks.Table = &shared.HTMLReportTable{Headers: []string{"Severity", "Check", "Locale", "Field", "Message"}, Rows: rows}
+	}
+
+	subtitleParts := []string{"App " + report.AppID}
+	if report.VersionString != "" {
+		subtitleParts = append(subtitleParts, "version "+report.VersionString)
+	}
+	if report.Platform != "" {
+		subtitleParts = append(subtitleParts, report.Platform)
+	}
+	if report.Strict {
+		subtitleParts = append(subtitleParts, "strict")
+	}
+
+	return shared.HTMLReport{
+		Title:       "Submission Readiness",
+		Subtitle:    strings.Join(subtitleParts, " · "),
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Cards:       cards,
+		Sections: []shared.HTMLReportSection{
+			{Title: "Blockers", Items: blockers, Empty: "No blocking issues."},
+			{Title: "Remediation Steps", Steps: steps, Empty: "Nothing to fix."},
+			checks,
+		},
+	}
+}
+
+func remediationDetail(step validation.RemediationStep) string {
+	detail := step.Remediation
+	location := strings.TrimSpace(strings.Join([]string{step.Locale, step.Field}, " "))
+	if location != "" {
+		if detail != "" {
+			detail += " "
+		}
+		detail += "(" + location + ")"
+	}
+	return detail
+}
+
+func severityTone(severity validation.Severity) string {
+	switch severity {
+	case validation.SeverityError:
+		return shared.HTMLToneBad
+	case validation.SeverityWarning:
+		return shared.HTMLToneWarning
+	default:
+		return shared.HTMLToneNeutral
+	}
+}
+
+func countTone(count int, tone string) string {
+	if count == 0 {
+		return shared.HTMLToneGood
+	}
+	return tone
+}
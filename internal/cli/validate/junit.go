@@ -0,0 +1,99 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
+)
+
+// buildJUnitReport groups readiness checks into one test case per check ID.
+// A test case fails when any of its findings is blocking (errors, plus
+// warnings under --strict); non-blocking findings are kept as system-out.
+func buildJUnitReport(report validation.Report) shared.JUnitReport {
+	order := make([]string, 0)
+	grouped := make(map[string][]validation.CheckResult)
+	for _, check := range report.Checks {
+		if _, ok := grouped[check.ID]; !ok {
+			order = append(order, check.ID)
+		}
+		grouped[check.ID] = append(grouped[check.ID], check)
+	}
+
+	tests := make([]shared.JUnitTestCase, 0, len(order))
+	for _, id := range order {
+		checks := grouped[id]
+		testCase := shared.JUnitTestCase{
+			Name:      id,
+			Classname: junitClassname(id),
+		}
+
+		var blocking, details []string
+		for _, check := range checks {
+			line := junitCheckLine(check)
+			details = append(details, line)
+			if isBlockingCheck(check, report.Strict) {
+				blocking = append(blocking, line)
+			}
+		}
+		if len(blocking) > 0 {
+			testCase.Failure = strings.ToUpper(string(highestSeverity(checks)))
+			testCase.Message = strings.Join(blocking, "; ")
+		}
+		testCase.SystemOut = strings.Join(details, "\n")
+		tests = append(tests, testCase)
+	}
+
+	if len(tests) == 0 {
+		tests = append(tests, shared.JUnitTestCase{Name: "readiness", Classname: "validate"})
+	}
+
+	return shared.JUnitReport{
+		Tests:     tests,
+		Timestamp: time.Now(),
+		Name:      "asc validate",
+	}
+}
+
+// junitClassname uses the check ID's first segment (e.g. "metadata" for
+// "metadata.required.name") so CI dashboards group related checks.
+func junitClassname(id string) string {
+	prefix, _, _ := strings.Cut(id, ".")
+	if prefix == "" {
+		return "validate"
+	}
+	return "validate." + prefix
+}
+
+func junitCheckLine(check validation.CheckResult) string {
+	var scope []string
+	for _, part := range []string{check.Locale, check.Field} {
+		if part != "" {
+			scope = append(scope, part)
+		}
+	}
+	line := fmt.Sprintf("[%s] %s", check.Severity, check.Message)
+	if len(scope) > 0 {
+		line += " (" + strings.Join(scope, " ") + ")"
+	}
+	return line
+}
+
+func isBlockingCheck(check validation.CheckResult, strict bool) bool {
+	return check.Severity == validation.SeverityError || (strict && check.Severity == validation.SeverityWarning)
+}
+
+func highestSeverity(checks []validation.CheckResult) validation.Severity {
+	highest := validation.SeverityInfo
+	for _, check := range checks {
+		switch check.Severity {
+		case validation.SeverityError:
+			return validation.SeverityError
+		case validation.SeverityWarning:
+			highest = validation.SeverityWarning
+		}
+	}
+	return highest
+}
@@ -0,0 +1,118 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
+)
+
+func reportFormatsTestReport() validation.Report {
+	return validation.Report{
+		AppID:         "app-1",
+		VersionID:     "ver-1",
+		VersionString: "1.2.0",
+		Checks: []validation.CheckResult{
+			{ID: "metadata.length.description", Severity: validation.SeverityError, Message: "description too long", Locale: "en-US", Field: "description", ResourceType: "appStoreVersionLocalization", ResourceID: "ver-loc-en"},
+			{ID: "metadata.length.description", Severity: validation.SeverityError, Message: "description too long", Locale: "fr-FR", Field: "description", ResourceType: "appStoreVersionLocalization", ResourceID: "ver-loc-fr"},
+			{ID: "metadata.recommended.privacy_policy_url", Severity: validation.SeverityWarning, Message: "privacy policy URL missing", Remediation: "Set privacyPolicyUrl", Locale: "en-US", Field: "privacyPolicyUrl", ResourceType: "appInfoLocalization"},
+			{ID: "privacy.publish_state.unverified", Severity: validation.SeverityInfo, Message: "privacy state not verified"},
+		},
+	}
+}
+
+func writeMetadataFile(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestBuildSARIF_PointsAtLocalMetadataFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeMetadataFile(t, filepath.Join("metadata", "version", "1.2.0", "en-US.json"), "{\n  \"keywords\": \"a,b\",\n  \"description\": \"long\"\n}\n")
+	writeMetadataFile(t, filepath.Join("metadata", "app-info", "en-US.json"), "{\n  \"name\": \"App\"\n}\n")
+
+	log := buildSARIF(reportFormatsTestReport(), "metadata")
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF envelope: %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 3 {
+		t.Fatalf("expected one rule per check ID, got %+v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 4 {
+		t.Fatalf("expected one result per check, got %d", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if first.Level != "error" || first.RuleIndex != 0 {
+		t.Fatalf("unexpected first result: %+v", first)
+	}
+	physical := first.Locations[0].PhysicalLocation
+	if physical == nil || physical.ArtifactLocation.URI != "metadata/version/1.2.0/en-US.json" {
+		t.Fatalf("expected version metadata file location, got %+v", first.Locations)
+	}
+	if physical.Region == nil || physical.Region.StartLine != 3 {
+		t.Fatalf("expected description line 3, got %+v", physical.Region)
+	}
+
+	if run.Results[1].Locations[0].PhysicalLocation != nil {
+		t.Fatalf("expected no physical location for missing fr-FR file, got %+v", run.Results[1].Locations[0])
+	}
+	if got := run.Results[1].Locations[0].LogicalLocations[0].FullyQualifiedName; got != "appStoreVersionLocalization/fr-FR/description" {
+		t.Fatalf("unexpected logical location %q", got)
+	}
+
+	warning := run.Results[2]
+	if warning.Level != "warning" || warning.Locations[0].PhysicalLocation.ArtifactLocation.URI != "metadata/app-info/en-US.json" {
+		t.Fatalf("unexpected app-info result: %+v", warning)
+	}
+	if warning.Locations[0].PhysicalLocation.Region != nil {
+		t.Fatalf("expected no region for absent field, got %+v", warning.Locations[0].PhysicalLocation.Region)
+	}
+	if run.Tool.Driver.Rules[1].Help == nil || run.Tool.Driver.Rules[1].Help.Text != "Set privacyPolicyUrl" {
+		t.Fatalf("expected remediation as rule help, got %+v", run.Tool.Driver.Rules[1])
+	}
+
+	info := run.Results[3]
+	if info.Level != "note" || len(info.Locations) != 0 {
+		t.Fatalf("unexpected info result: %+v", info)
+	}
+}
+
+func TestBuildJUnitReport_OneTestCasePerCheckID(t *testing.T) {
+	report := reportFormatsTestReport()
+
+	junit := buildJUnitReport(report)
+	if len(junit.Tests) != 3 {
+		t.Fatalf("expected 3 test cases, got %+v", junit.Tests)
+	}
+	description := junit.Tests[0]
+	if description.Name != "metadata.length.description" || description.Classname != "validate.metadata" {
+		t.Fatalf("unexpected test case identity: %+v", description)
+	}
+	if description.Failure != "ERROR" || !strings.Contains(description.Message, "(fr-FR description)") {
+		t.Fatalf("expected both locales in failure, got %+v", description)
+	}
+	if junit.Tests[1].Failure != "" || !strings.Contains(junit.Tests[1].SystemOut, "[warning]") {
+		t.Fatalf("expected passing warning test case, got %+v", junit.Tests[1])
+	}
+
+	report.Strict = true
+	if got := buildJUnitReport(report).Tests[1].Failure; got != "WARNING" {
+		t.Fatalf("expected warning to fail under strict, got %q", got)
+	}
+
+	empty := buildJUnitReport(validation.Report{})
+	if len(empty.Tests) != 1 || empty.Tests[0].Failure != "" {
+		t.Fatalf("expected a single passing test case, got %+v", empty.Tests)
+	}
+}
@@ -0,0 +1,285 @@
+package validate
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/metadata"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/validation"
+)
+
+const (
+	sarifSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion   = "2.1.0"
+	sarifToolURI   = "https://github.com/rudrankriyam/App-Store-Connect-CLI"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	Help                 *sarifMessage      `json:"help,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// buildSARIF converts a readiness report into a SARIF 2.1.0 log. When
+// metadataDir is set, locale-scoped metadata findings point at the local
+// files written by `asc metadata pull` so code scanning can annotate them.
+func buildSARIF(report validation.Report, metadataDir string) sarifLog {
+	rules := make([]sarifRule, 0)
+	ruleIndex := make(map[string]int)
+	results := make([]sarifResult, 0, len(report.Checks))
+
+	for _, check := range report.Checks {
+		level := sarifLevel(check.Severity)
+		index, ok := ruleIndex[check.ID]
+		if !ok {
+			index = len(rules)
+			ruleIndex[check.ID] = index
+			rule := sarifRule{
+				ID:                   check.ID,
+				ShortDescription:     sarifMessage{Text: check.Message},
+				DefaultConfiguration: sarifConfiguration{Level: level},
+			}
+			if check.Remediation != "" {
+				rule.Help = &sarifMessage{Text: check.Remediation}
+			}
+			rules = append(rules, rule)
+		}
+
+		result := sarifResult{
+			RuleID:     check.ID,
+			RuleIndex:  index,
+			Level:      level,
+			Message:    sarifMessage{Text: check.Message},
+			Properties: sarifProperties(check),
+		}
+		if location, ok := sarifCheckLocation(check, report.VersionString, metadataDir); ok {
+			result.Locations = []sarifLocation{location}
+		}
+		results = append(results, result)
+	}
+
+	return sarifLog{
+		Schema:  sarifSchemaURI,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "asc",
+				InformationURI: sarifToolURI,
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+func sarifLevel(severity validation.Severity) string {
+	switch severity {
+	case validation.SeverityError:
+		return "error"
+	case validation.SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func sarifProperties(check validation.CheckResult) map[string]string {
+	properties := map[string]string{}
+	for key, value := range map[string]string{
+		"locale":       check.Locale,
+		"field":        check.Field,
+		"resourceType": check.ResourceType,
+		"resourceId":   check.ResourceID,
+		"remediation":  check.Remediation,
+	} {
+		if value != "" {
+			properties[key] = value
+		}
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return properties
+}
+
+func sarifCheckLocation(check validation.CheckResult, versionString, metadataDir string) (sarifLocation, bool) {
+	var location sarifLocation
+	if check.ResourceType != "" || check.Field != "" {
+		name := check.Field
+		if name == "" {
+			name = check.ResourceType
+		}
+		parts := make([]string, 0, 3)
+		for _, part := range []string{check.ResourceType, firstNonEmpty(check.Locale, check.ResourceID), check.Field} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		location.LogicalLocations = []sarifLogicalLocation{{
+			Name:               name,
+			FullyQualifiedName: strings.Join(parts, "/"),
+			Kind:               "member",
+		}}
+	}
+
+	if path := metadataFilePath(check, versionString, metadataDir); path != "" {
+		physical := &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: sarifArtifactURI(path)}}
+		if line := jsonFieldLine(path, check.Field); line > 0 {
+			physical.Region = &sarifRegion{StartLine: line}
+		}
+		location.PhysicalLocation = physical
+	}
+
+	return location, location.PhysicalLocation != nil || len(location.LogicalLocations) > 0
+}
+
+// metadataFilePath returns the local metadata file a localization finding
+// came from, or "" when the check is not tied to one or the file is absent.
+func metadataFilePath(check validation.CheckResult, versionString, metadataDir string) string {
+	if strings.TrimSpace(metadataDir) == "" || check.Locale == "" {
+		return ""
+	}
+	var (
+		path string
+		err  error
+	)
+	switch check.ResourceType {
+	case "appInfoLocalization":
+		path, err = metadata.AppInfoLocalizationFilePath(metadataDir, check.Locale)
+	case "appStoreVersionLocalization":
+		if versionString == "" {
+			return ""
+		}
+		path, err = metadata.VersionLocalizationFilePath(metadataDir, versionString, check.Locale)
+	default:
+		return ""
+	}
+	if err != nil {
+		return ""
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// sarifArtifactURI keeps paths relative to the working directory so code
+// scanning can resolve them against the repository checkout.
+func sarifArtifactURI(path string) string {
+	if filepath.IsAbs(path) {
+		if cwd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(cwd, path); err == nil && !strings.HasPrefix(rel, "..") {
+				return filepath.ToSlash(rel)
+			}
+		}
+		return "file://" + filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// jsonFieldLine returns the 1-based line of the first "field": key in path.
+func jsonFieldLine(path, field string) int {
+	if field == "" {
+		return 0
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	needle := fmt.Sprintf("%q", field)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(text, needle) && strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(text, needle)), ":") {
+			return line
+		}
+	}
+	return 0
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func printSARIF(report validation.Report, metadataDir string) error {
+	data, err := json.MarshalIndent(buildSARIF(report, metadataDir), "", "  ")
+	if err != nil {
+		return fmt.Errorf("validate: marshal SARIF: %w", err)
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+	return err
+}
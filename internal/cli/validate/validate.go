@@ -15,13 +15,15 @@ import (
 )
 
 type validateOptions struct {
-	AppID     string
-	Version   string
-	VersionID string
-	Platform  string
-	Strict    bool
-	Output    string
-	Pretty    bool
+	AppID       string
+	Version     string
+	VersionID   string
+	Platform    string
+	Strict      bool
+	Output      string
+	Pretty      bool
+	MetadataDir string
+	JUnitPath   string
 }
 
 var (
@@ -38,7 +40,9 @@ func ValidateCommand() *ffcli.Command {
 	versionID := fs.String("version-id", "", "App Store version ID")
 	platform := fs.String("platform", "", "Platform: IOS, MAC_OS, TV_OS, VISION_OS")
 	strict := fs.Bool("strict", false, "Treat warnings as errors (exit non-zero)")
-	output := shared.BindOutputFlags(fs)
+	metadataDir := fs.String("metadata-dir", "", "Local metadata directory (from asc metadata pull) used for SARIF file locations")
+	junitPath := fs.String("junit", "", "Write a JUnit XML report with one test case per check ID to this path")
+	output := shared.BindOutputFlagsWithFormats(fs, "sarif", "html")
 
 	testFlight := wrapValidateSubcommand(ValidateTestFlightCommand(), fs)
 	iap := wrapValidateSubcommand(ValidateIAPCommand(), fs)
@@ -73,6 +77,9 @@ Examples:
   asc validate --app "APP_ID" --version "1.0.0" --platform IOS
   asc validate --app "APP_ID" --version-id "VERSION_ID" --platform IOS --output table
   asc validate --app "APP_ID" --version-id "VERSION_ID" --strict
+  asc validate --app "APP_ID" --version "1.0.0" --output sarif --metadata-dir "./metadata" > validate.sarif
+  asc validate --app "APP_ID" --version-id "VERSION_ID" --junit "./validate-junit.xml"
+  asc validate --app "APP_ID" --version-id "VERSION_ID" --output html > readiness.html
 
 TestFlight:
   asc validate testflight --app "APP_ID" --build "BUILD_ID"
@@ -120,13 +127,15 @@ Subscriptions:
 			}
 
 			return runValidate(ctx, validateOptions{
-				AppID:     resolvedAppID,
-				Version:   trimmedVersion,
-				VersionID: trimmedVersionID,
-				Platform:  normalizedPlatform,
-				Strict:    *strict,
-				Output:    *output.Output,
-				Pretty:    *output.Pretty,
+				AppID:       resolvedAppID,
+				Version:     trimmedVersion,
+				VersionID:   trimmedVersionID,
+				Platform:    normalizedPlatform,
+				Strict:      *strict,
+				Output:      *output.Output,
+				Pretty:      *output.Pretty,
+				MetadataDir: strings.TrimSpace(*metadataDir),
+				JUnitPath:   strings.TrimSpace(*junitPath),
 			})
 		},
 	}
@@ -156,9 +165,9 @@ func validateParentFlagUsageMessage(parentFlags *flag.FlagSet) string {
 	topLevelOnly := make([]string, 0, 5)
 	parentFlags.Visit(func(f *flag.Flag) {
 		switch f.Name {
-		case "app", "output", "pretty", "strict":
+		case "app", "output", "pretty", "strict", "template", "template-file":
 			moveAfterSubcommand = append(moveAfterSubcommand, "--"+f.Name)
-		case "version", "version-id", "platform":
+		case "version", "version-id", "platform", "metadata-dir", "junit":
 			topLevelOnly = append(topLevelOnly, "--"+f.Name)
 		}
 	})
@@ -210,8 +219,26 @@ func runValidate(ctx context.Context, opts validateOptions) error {
 		return fmt.Errorf("validate: %w", err)
 	}
 
-	if err := shared.PrintOutput(&report, opts.Output, opts.Pretty); err != nil {
-		return err
+	if opts.JUnitPath != "" {
+		junit := buildJUnitReport(report)
+		if err := junit.Write(opts.JUnitPath); err != nil {
+			return fmt.Errorf("validate: %w", err)
+		}
+	}
+
+	switch shared.NormalizeOutputFormat(opts.Output) {
+	case "sarif":
+		if err := printSARIF(report, opts.MetadataDir); err != nil {
+			return err
+		}
+	case "html":
+		if err := shared.PrintHTMLReport(buildValidateHTMLReport(report)); err != nil {
+			return err
+		}
+	default:
+		if err := shared.PrintOutput(&report, opts.Output, opts.Pretty); err != nil {
+			return err
+		}
 	}
 
 	if report.Summary.Blocking > 0 {
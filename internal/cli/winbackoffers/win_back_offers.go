@@ -209,7 +209,7 @@ Examples:
 					return fmt.Errorf("win-back-offers list: failed to fetch: %w", err)
 				}
 
-				resp, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetSubscriptionWinBackOffers(ctx, id, asc.WithWinBackOffersNextURL(nextURL))
 				})
 				if err != nil {
@@ -763,7 +763,7 @@ Examples:
 					return fmt.Errorf("win-back-offers prices: failed to fetch: %w", err)
 				}
 
-				resp, err := asc.PaginateAll(requestCtx, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
+				resp, err := shared.PaginateAllForOutput(requestCtx, *output.Output, firstPage, func(ctx context.Context, nextURL string) (asc.PaginatedResponse, error) {
 					return client.GetWinBackOfferPrices(ctx, trimmedID, asc.WithWinBackOfferPricesNextURL(nextURL))
 				})
 				if err != nil {
@@ -874,8 +874,8 @@ Examples:
 			}
 
 			if *paginate {
-				resp, err := shared.PaginateWithSpinner(
-					requestCtx,
+				resp, err := shared.PaginateForOutput(
+					requestCtx, *output.Output,
 					func(ctx context.Context) (asc.PaginatedResponse, error) {
 						return fetchPage(ctx, winBackOffersMaxLimit, *next)
 					},
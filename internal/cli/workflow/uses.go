@@ -0,0 +1,178 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/cli/shared"
+	wf "github.com/rudrankriyam/App-Store-Connect-CLI/internal/workflow"
+)
+
+// inProcessRunner runs uses steps through a fresh asc command tree, so flag
+// values never leak from one step to the next. Commands print their results
+// to os.Stdout, which is redirected into the step's writer while a command
+// runs, and output validation keeps process-wide template state; runs are
+// therefore serialized. Shell steps never read os.Stdout, so they can run
+// alongside.
+func inProcessRunner(commands func() []*ffcli.Command) wf.CommandRunner {
+	var mu sync.Mutex
+	return func(ctx context.Context, args []string, stdout io.Writer) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		root := &ffcli.Command{
+			Name:        "asc",
+			FlagSet:     flag.NewFlagSet("asc", flag.ContinueOnError),
+			Subcommands: commands(),
+		}
+		for _, sub := range root.Subcommands {
+			shared.WrapCommandOutputValidation(sub)
+		}
+
+		cmd, path, err := resolveInProcessCommand(root, args)
+		if err != nil {
+			return err
+		}
+		// Subcommand flag sets exit the process on bad flags; check the
+		// flags against a second, throwaway command tree first so a typo
+		// fails the step instead of the whole run.
+		check, _, err := resolveInProcessCommand(&ffcli.Command{Name: "asc", Subcommands: commands()}, path)
+		if err != nil {
+			return err
+		}
+		if err := preflightFlags(check.FlagSet, args[len(path):]); err != nil {
+			return fmt.Errorf("asc %s: %w", strings.Join(path, " "), err)
+		}
+		if cmd.FlagSet.Lookup("output") != nil && !hasFlagArg(args[len(path):], "output") {
+			args = append(append(append([]string{}, path...), "--output=json"), args[len(path):]...)
+		}
+
+		err = withStdout(stdout, func() error {
+			return root.ParseAndRun(ctx, args)
+		})
+		if errors.Is(err, flag.ErrHelp) {
+			return fmt.Errorf("asc %s: invalid usage (see asc %s --help)", strings.Join(path, " "), strings.Join(path, " "))
+		}
+		return err
+	}
+}
+
+// resolveInProcessCommand returns the command named by the words at the
+// start of args, along with those words.
+func resolveInProcessCommand(root *ffcli.Command, args []string) (*ffcli.Command, []string, error) {
+	path := args
+	for i, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			path = args[:i]
+			break
+		}
+	}
+
+	cmd := root
+	for _, word := range path {
+		var next *ffcli.Command
+		for _, sub := range cmd.Subcommands {
+			if strings.EqualFold(sub.Name, word) {
+				next = sub
+				break
+			}
+		}
+		if next == nil {
+			return nil, nil, fmt.Errorf("unknown command: asc %s", strings.Join(path, " "))
+		}
+		cmd = next
+	}
+	if cmd == root {
+		return nil, nil, errors.New("no asc command given")
+	}
+	return cmd, path, nil
+}
+
+func hasFlagArg(args []string, name string) bool {
+	for _, arg := range args {
+		trimmed := strings.TrimLeft(arg, "-")
+		if trimmed == name || strings.HasPrefix(trimmed, name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// preflightFlags parses args into fs with ContinueOnError, so errors are
+// reported instead of exiting. fs must belong to a command tree that is
+// discarded afterwards.
+func preflightFlags(fs *flag.FlagSet, args []string) error {
+	check := flag.NewFlagSet(fs.Name(), flag.ContinueOnError)
+	check.SetOutput(io.Discard)
+	fs.VisitAll(func(f *flag.Flag) {
+		check.Var(&safeFlagValue{Value: f.Value, isBool: isBoolFlag(f.Value)}, f.Name, f.Usage)
+	})
+	if err := check.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return errors.New("help is not available in workflow steps")
+		}
+		return err
+	}
+	return nil
+}
+
+func isBoolFlag(value flag.Value) bool {
+	boolFlag, ok := value.(interface{ IsBoolFlag() bool })
+	return ok && boolFlag.IsBoolFlag()
+}
+
+// safeFlagValue reports a panic in a flag value's Set as an error, so a
+// misbehaving flag type fails the step rather than the whole run.
+type safeFlagValue struct {
+	flag.Value
+	isBool bool
+}
+
+func (v *safeFlagValue) Set(s string) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%v", recovered)
+		}
+	}()
+	return v.Value.Set(s)
+}
+
+func (v *safeFlagValue) String() string {
+	if v == nil || v.Value == nil {
+		return ""
+	}
+	return v.Value.String()
+}
+
+func (v *safeFlagValue) IsBoolFlag() bool { return v.isBool }
+
+// withStdout points os.Stdout at w while fn runs.
+func withStdout(w io.Writer, fn func() error) error {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("capture command output: %w", err)
+	}
+	copied := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(w, reader)
+		_ = reader.Close()
+		close(copied)
+	}()
+
+	original := os.Stdout
+	os.Stdout = writer
+	defer func() {
+		os.Stdout = original
+		_ = writer.Close()
+		<-copied
+	}()
+	return fn()
+}
@@ -17,8 +17,9 @@ import (
 	wf "github.com/rudrankriyam/App-Store-Connect-CLI/internal/workflow"
 )
 
-// WorkflowCommand returns the top-level workflow command group.
-func WorkflowCommand() *ffcli.Command {
+// WorkflowCommand returns the top-level workflow command group. commands
+// builds the asc command tree that "uses" steps run in-process.
+func WorkflowCommand(commands func() []*ffcli.Command) *ffcli.Command {
 	fs := flag.NewFlagSet("workflow", flag.ExitOnError)
 
 	return &ffcli.Command{
@@ -47,6 +48,12 @@ Tips:
   Use asc workflow validate before running a new workflow file.
   Preview the plan with asc workflow run --dry-run <name>.
   Run-step outputs can be referenced later as ${steps.resolve_build.BUILD_ID}.
+  Give steps an "id" and list prerequisites in "needs" to run independent steps concurrently.
+  Group steps under "parallel" to run them at the same time (bounded by max_parallel).
+  Use "uses": "asc builds info" with flags in "with" to run an asc command in-process;
+  its JSON result becomes the step's outputs.
+  Split large files with "imports": ["release-common.json"] (paths relative to the importing file).
+  Declare typed "inputs" on reusable workflows; callers pass them in "with".
   For asc commands that declare outputs, usually pass --output json.
   A proven local Xcode -> TestFlight shape is: asc builds next-build-number --app $APP_ID -> asc xcode archive -> asc xcode export -> asc publish testflight --group ... --wait.
 
@@ -132,7 +139,7 @@ Examples:
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
 		Subcommands: []*ffcli.Command{
-			workflowRunCommand(),
+			workflowRunCommand(commands),
 			workflowValidateCommand(),
 			workflowListCommand(),
 		},
@@ -142,12 +149,13 @@ Examples:
 	}
 }
 
-func workflowRunCommand() *ffcli.Command {
+func workflowRunCommand(commands func() []*ffcli.Command) *ffcli.Command {
 	fs := flag.NewFlagSet("workflow run", flag.ExitOnError)
 	filePath := fs.String("file", wf.DefaultPath, "Path to workflow.json")
 	dryRun := fs.Bool("dry-run", false, "Preview steps without executing")
 	pretty := fs.Bool("pretty", false, "Pretty-print JSON output")
 	resume := fs.String("resume", "", "Resume a prior workflow run by run ID")
+	maxParallel := fs.Int("max-parallel", 0, "Maximum step commands to run at once (overrides max_parallel; default 4)")
 
 	return &ffcli.Command{
 		Name:       "run",
@@ -160,9 +168,16 @@ Use --resume with the emitted run ID to continue a partially completed run witho
 rerunning already-persisted successful steps.
 Resume automatically reuses the original workflow file, saved params, and persisted outputs.
 Do not pass extra KEY:VALUE params with --resume.
+KEY:VALUE params fill the workflow's declared inputs and are checked against their types.
 If a step declares "outputs", the command must emit JSON on stdout; for asc commands,
 usually pass --output json.
 stdout stays machine-parseable JSON even on failure; step and hook output streams to stderr.
+Steps in a "parallel" group, and steps whose "needs" are satisfied, run concurrently;
+--max-parallel caps how many step commands run at once.
+"uses" steps run asc commands in-process with one shared API client, one at a time.
+Their results are captured by redirecting asc's own stdout while each one runs, so
+they never overlap each other; shell steps, including parallel ones, write through
+their own pipes and are unaffected.
 
 Security note:
   Workflows intentionally execute arbitrary shell commands.
@@ -176,6 +191,7 @@ Examples:
   asc workflow run beta
   asc workflow run beta BUILD_ID:123456789 GROUP_ID:abcdef
   asc workflow run --dry-run beta
+  asc workflow run screenshots --max-parallel 8
   asc workflow run release --resume beta-20260312T120000Z-deadbeef`,
 		FlagSet:   fs,
 		UsageFunc: shared.DefaultUsageFunc,
@@ -210,9 +226,13 @@ Examples:
 			if strings.TrimSpace(*resume) != "" && len(paramArgs) > 0 {
 				return shared.UsageError("resume runs do not accept additional KEY:VALUE parameters")
 			}
+			if *maxParallel < 0 {
+				return shared.UsageError("--max-parallel must be greater than or equal to 0")
+			}
 
 			stateDir := filepath.Join(filepath.Dir(absPath), "runs")
 
+			defer shared.ShareASCClient()()
 			result, err := wf.Run(ctx, def, wf.RunOptions{
 				WorkflowName: workflowName,
 				Params:       params,
@@ -220,6 +240,8 @@ Examples:
 				WorkflowFile: absPath,
 				StateDir:     stateDir,
 				ResumeRunID:  strings.TrimSpace(*resume),
+				MaxParallel:  *maxParallel,
+				ASC:          inProcessRunner(commands),
 				// Keep stdout machine-parseable JSON; stream step output to stderr.
 				Stdout: os.Stderr,
 				Stderr: os.Stderr,
@@ -247,6 +269,8 @@ func workflowValidateCommand() *ffcli.Command {
 		ShortUsage: "asc workflow validate [flags]",
 		ShortHelp:  "Validate workflow.json for errors and cycles.",
 		LongHelp: `Validate workflow.json for structure, references, cycles, and output declarations.
+Imported files are loaded and checked too, including import cycles and the inputs
+each workflow step passes to the workflow it calls.
 This checks schema and wiring only; it does not assess shell-command safety.
 
 Examples:
@@ -328,10 +352,11 @@ Examples:
 			}
 
 			type workflowInfo struct {
-				Name        string `json:"name"`
-				Description string `json:"description,omitempty"`
-				Private     bool   `json:"private,omitempty"`
-				StepCount   int    `json:"step_count"`
+				Name        string              `json:"name"`
+				Description string              `json:"description,omitempty"`
+				Private     bool                `json:"private,omitempty"`
+				Inputs      map[string]wf.Input `json:"inputs,omitempty"`
+				StepCount   int                 `json:"step_count"`
 			}
 
 			workflows := make([]workflowInfo, 0, len(def.Workflows))
@@ -343,6 +368,7 @@ Examples:
 					Name:        name,
 					Description: w.Description,
 					Private:     w.Private,
+					Inputs:      w.Inputs,
 					StepCount:   len(w.Steps),
 				})
 			}
@@ -344,7 +344,7 @@ func xcodeCloudProductsList(ctx context.Context, appID string, limit int, next s
 
 func shouldHydrateCiProductBundleIDs(output string) bool {
 	switch shared.NormalizeOutputFormat(output) {
-	case "table", "markdown", "md":
+	case "table", "markdown", "md", "csv", "tsv":
 		return true
 	default:
 		return false
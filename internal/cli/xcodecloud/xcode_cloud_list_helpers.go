@@ -40,7 +40,7 @@ func runXcodeCloudPaginatedList(
 	defer cancel()
 
 	if paginate {
-		resp, err := shared.PaginateWithSpinner(requestCtx,
+		resp, err := shared.PaginateForOutput(requestCtx, output,
 			func(ctx context.Context) (asc.PaginatedResponse, error) {
 				return fetchPage(ctx, client, 200, nextURL)
 			},
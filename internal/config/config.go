@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -109,12 +111,33 @@ func parseDurationValue(raw string) (time.Duration, error) {
 	return time.Duration(seconds) * time.Second, nil
 }
 
+// API key kinds. Team keys authenticate with an issuer ID; Individual keys
+// belong to a single user and have no issuer.
+const (
+	KeyTypeTeam       = "team"
+	KeyTypeIndividual = "individual"
+)
+
+// KeyTypeForIssuer returns the key kind implied by an issuer ID.
+func KeyTypeForIssuer(issuerID string) string {
+	if strings.TrimSpace(issuerID) == "" {
+		return KeyTypeIndividual
+	}
+	return KeyTypeTeam
+}
+
 // Credential stores a named API credential in config.json.
 type Credential struct {
 	Name           string `json:"name"`
 	KeyID          string `json:"key_id"`
 	IssuerID       string `json:"issuer_id"`
 	PrivateKeyPath string `json:"private_key_path"`
+	KeyType        string `json:"key_type,omitempty"`
+}
+
+// IsIndividual reports whether the credential is an Individual API key.
+func (c Credential) IsIndividual() bool {
+	return strings.TrimSpace(c.KeyType) == KeyTypeIndividual
 }
 
 // KeychainMetadata stores non-secret metadata for keychain-backed credentials.
@@ -131,6 +154,7 @@ type Config struct {
 	IssuerID         string             `json:"issuer_id"`
 	PrivateKeyPath   string             `json:"private_key_path"`
 	PrivateKeyPEM    string             `json:"-"`
+	KeyType          string             `json:"key_type,omitempty"`
 	DefaultKeyName   string             `json:"default_key_name"`
 	Keys             []Credential       `json:"keys,omitempty"`
 	KeychainMetadata []KeychainMetadata `json:"keychain_metadata,omitempty"`
@@ -149,6 +173,13 @@ type Config struct {
 	MaxDelay             string        `json:"max_delay"`
 	RetryLog             string        `json:"retry_log"`
 	Debug                string        `json:"debug"`
+	BaseURL              string        `json:"base_url,omitempty"`
+
+	// ReadOnlyProfiles lists profile names whose clients refuse mutating requests.
+	ReadOnlyProfiles []string `json:"read_only_profiles,omitempty"`
+
+	// AuditLog is the JSONL file that records every mutating API request.
+	AuditLog string `json:"audit_log,omitempty"`
 }
 
 // ErrNotFound is returned when the config file doesn't exist
@@ -357,9 +388,54 @@ func (c *Config) Validate() error {
 	if baseSet && maxSet && maxDelay < baseDelay {
 		return wrapInvalidConfig(fmt.Errorf("max_delay must be >= base_delay"))
 	}
+	if err := ValidateBaseURL(c.BaseURL); err != nil {
+		return wrapInvalidConfig(fmt.Errorf("base_url: %w", err))
+	}
 	return nil
 }
 
+// ValidateBaseURL checks an App Store Connect API base URL override.
+// HTTPS is required except for loopback hosts, which lets local emulators
+// run without certificates. An empty value is valid and means "default".
+func ValidateBaseURL(raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", raw, err)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("URL %q must be absolute", raw)
+	}
+	if parsed.User != nil || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return fmt.Errorf("URL %q must not include credentials, query, or fragment", raw)
+	}
+	if strings.Trim(parsed.Path, "/") != "" {
+		return fmt.Errorf("URL %q must not include a path", raw)
+	}
+	switch parsed.Scheme {
+	case "https":
+		return nil
+	case "http":
+		if isLoopbackHost(parsed.Hostname()) {
+			return nil
+		}
+		return fmt.Errorf("URL %q must use https (http is only allowed for loopback hosts)", raw)
+	default:
+		return fmt.Errorf("URL %q must use https", raw)
+	}
+}
+
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 func wrapInvalidConfig(err error) error {
 	return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
 }
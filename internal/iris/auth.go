@@ -24,6 +24,7 @@ import (
 	"golang.org/x/crypto/pbkdf2"
 
 	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/appleauth"
+	"github.com/rudrankriyam/App-Store-Connect-CLI/internal/asc"
 )
 
 var errAppleAccountActionRequired = errors.New("complete the pending Apple Account web prompt in a browser (privacy acknowledgement or 2FA upgrade) and try again")
@@ -1009,6 +1010,13 @@ func finalizeTwoFactor(ctx context.Context, session *AuthSession) error {
 
 // doRequest performs an HTTP request with the IRIS client
 func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error) {
+	if err := asc.CheckReadOnly(method, path); err != nil {
+		return nil, err
+	}
+	if err := asc.CheckPlanSupported(method, path); err != nil {
+		return nil, err
+	}
+
 	var reqBody io.Reader
 	var jsonBody []byte
 	if body != nil {
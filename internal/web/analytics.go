@@ -278,7 +278,8 @@ func (c *Client) analyticsBaseURL() string {
 }
 
 func (c *Client) doAnalyticsRequest(ctx context.Context, path string, body any, referer string) ([]byte, error) {
-	return c.doRequestBase(ctx, c.analyticsBaseURL(), http.MethodPost, path, body, analyticsHeaders(referer))
+	// Analytics queries are POSTs that only read data, so read-only mode allows them.
+	return c.sendRequest(ctx, c.analyticsBaseURL(), http.MethodPost, path, body, analyticsHeaders(referer))
 }
 
 // NormalizeAnalyticsFrequency validates analytics frequency values shared by the
@@ -279,7 +279,7 @@ func (c *Client) analyticsV2BaseURL() string {
 }
 
 func (c *Client) doAnalyticsV2Request(ctx context.Context, path string, body any, referer string) ([]byte, error) {
-	return c.doRequestBase(ctx, c.analyticsV2BaseURL(), http.MethodPost, path, body, analyticsHeaders(referer))
+	return c.sendRequest(ctx, c.analyticsV2BaseURL(), http.MethodPost, path, body, analyticsHeaders(referer))
 }
 
 // GetAnalyticsSettings loads the shared analytics settings payload.
@@ -1323,6 +1323,18 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any) (
 }
 
 func (c *Client) doRequestBase(ctx context.Context, baseURL, method, path string, body any, headers http.Header) ([]byte, error) {
+	if err := asc.CheckReadOnly(method, path); err != nil {
+		return nil, err
+	}
+	if err := asc.CheckPlanSupported(method, path); err != nil {
+		return nil, err
+	}
+	return c.sendRequest(ctx, baseURL, method, path, body, headers)
+}
+
+// sendRequest performs a web request without the read-only and plan guards. Only
+// callers whose POSTs are queries (such as analytics) may use it directly.
+func (c *Client) sendRequest(ctx context.Context, baseURL, method, path string, body any, headers http.Header) ([]byte, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
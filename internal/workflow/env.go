@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
+	"time"
 )
 
 var (
@@ -30,15 +31,14 @@ func mergeEnv(maps ...map[string]string) map[string]string {
 	return result
 }
 
-// isTruthy returns true if a value is explicitly truthy.
-// Truthy: "1", "true", "yes", "y", "on" (case-insensitive).
-// Everything else (empty, "0", "false", "no", "n", "off", unknown) is falsy.
+// isTruthy reports whether a value counts as true. Empty, "0", "false",
+// "no", "n" and "off" (case-insensitive) are false; anything else is true.
 func isTruthy(value string) bool {
 	switch strings.ToLower(strings.TrimSpace(value)) {
-	case "1", "true", "yes", "y", "on":
-		return true
-	default:
+	case "", "0", "false", "no", "n", "off":
 		return false
+	default:
+		return true
 	}
 }
 
@@ -105,6 +105,10 @@ func buildEnvSlice(env map[string]string) []string {
 	return base
 }
 
+// commandWaitDelay bounds how long a cancelled command's output may stay
+// open before Run returns.
+const commandWaitDelay = 2 * time.Second
+
 // runShellCommand executes a command string via bash -o pipefail -c when bash
 // is available. It falls back to sh -c when bash is unavailable.
 // Bash preserves pipeline failures (e.g., "false | cat") for CI correctness.
@@ -119,6 +123,8 @@ func runShellCommand(ctx context.Context, command string, env map[string]string,
 	cmd.Env = buildEnvSlice(env)
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
+	startInProcessGroup(cmd)
+	cmd.WaitDelay = commandWaitDelay
 	return cmd.Run()
 }
 
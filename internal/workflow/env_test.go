@@ -47,9 +47,9 @@ func TestIsTruthy(t *testing.T) {
 		{"n", false},
 		{"off", false},
 		{"OFF", false},
-		{"yep", false},   // unknown = falsy
-		{"nope", false},  // unknown = falsy
-		{"maybe", false}, // unknown = falsy
+		{"yep", true},      // unknown = truthy
+		{"build-42", true}, // unknown = truthy
+		{"  no  ", false},  // trimmed
 		{"1", true},
 		{"true", true},
 		{"True", true},
@@ -3,18 +3,24 @@ package workflow
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"maps"
 	"os"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
 // MaxCallDepth is the maximum nesting depth for sub-workflow calls.
 const MaxCallDepth = 16
 
+// DefaultMaxParallel is the number of step commands that may run at the same
+// time when neither RunOptions nor the workflow sets max_parallel.
+const DefaultMaxParallel = 4
+
 // RunOptions configures a workflow execution.
 type RunOptions struct {
 	WorkflowName string
@@ -25,19 +31,43 @@ type RunOptions struct {
 	WorkflowFile string
 	StateDir     string
 	ResumeRunID  string
+	// MaxParallel overrides the workflow's max_parallel when greater than zero.
+	MaxParallel int
+	// ASC runs steps with uses in-process. Such steps fail when it is nil.
+	ASC CommandRunner
 }
 
+// CommandRunner runs an asc command in-process. args excludes the leading
+// "asc", and the command's result must be written to stdout.
+type CommandRunner func(ctx context.Context, args []string, stdout io.Writer) error
+
 // StepResult records one executed step.
 type StepResult struct {
 	Index          int               `json:"index"`
+	ID             string            `json:"id,omitempty"`
 	Name           string            `json:"name,omitempty"`
 	Command        string            `json:"command,omitempty"`
+	Uses           string            `json:"uses,omitempty"`
 	Workflow       string            `json:"workflow,omitempty"`
 	ParentWorkflow string            `json:"parent_workflow,omitempty"`
 	Status         string            `json:"status"`
 	DurationMS     int64             `json:"duration_ms"`
 	Error          string            `json:"error,omitempty"`
 	Outputs        map[string]string `json:"outputs,omitempty"`
+	// Attempts lists every attempt of a step with a retry policy.
+	Attempts []AttemptResult `json:"attempts,omitempty"`
+	// ContinuedOnError marks a failed step whose failure did not stop the run.
+	ContinuedOnError bool `json:"continued_on_error,omitempty"`
+	// Matrix labels the combination a step ran in, such as "app=A,platform=IOS".
+	Matrix string `json:"matrix,omitempty"`
+}
+
+// AttemptResult records one attempt of a retried step.
+type AttemptResult struct {
+	Attempt    int    `json:"attempt"`
+	Status     string `json:"status"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
 }
 
 // HookResult records execution of a hook command (before_all/after_all/error).
@@ -74,7 +104,9 @@ type RunResult struct {
 	Outputs     map[string]map[string]string `json:"outputs,omitempty"`
 	Hooks       *HooksResult                 `json:"hooks,omitempty"`
 	Steps       []StepResult                 `json:"steps"`
-	DurationMS  int64                        `json:"duration_ms"`
+	// Matrix lists every combination of each matrix the run expanded.
+	Matrix     []MatrixResult `json:"matrix,omitempty"`
+	DurationMS int64          `json:"duration_ms"`
 }
 
 type runner struct {
@@ -85,6 +117,13 @@ type runner struct {
 	statePath      string
 	definitionHash string
 	outputs        map[string]map[string]string
+	// resumable holds the steps an earlier run completed, keyed by step key.
+	resumable map[string]persistedStepState
+
+	// mu guards result, outputs and state while parallel steps run.
+	mu sync.Mutex
+	// slots bounds how many step commands run at the same time.
+	slots chan struct{}
 }
 
 func (r *RunResult) ensureHooks() *HooksResult {
@@ -135,8 +174,15 @@ func Run(ctx context.Context, def *Definition, opts RunOptions) (*RunResult, err
 		result.Error = err.Error()
 		return result, err
 	}
+	r.slots = make(chan struct{}, resolveMaxParallel(opts.MaxParallel, wf.MaxParallel))
 
-	env := mergeEnv(def.Env, wf.Env, r.opts.Params)
+	params, err := resolveInputs(opts.WorkflowName, wf.Inputs, r.opts.Params, true)
+	if err != nil {
+		wrapped := fmt.Errorf("workflow: %w", err)
+		r.markFailure(wrapped, "")
+		return result, wrapped
+	}
+	env := mergeEnv(def.Env, wf.Env, params)
 
 	if resumed := r.resumedHook("before_all", def.BeforeAll); resumed != nil {
 		result.ensureHooks().BeforeAll = resumed
@@ -155,8 +201,8 @@ func Run(ctx context.Context, def *Definition, opts RunOptions) (*RunResult, err
 		}
 	}
 
-	if execErr := r.executeSteps(ctx, opts.WorkflowName, wf.Steps, env, "", 0); execErr != nil {
-		r.markFailure(execErr, result.FailedStep)
+	if execErr := r.executeWorkflow(ctx, opts.WorkflowName, wf, env, "", 0); execErr != nil {
+		r.markFailure(execErr, failedStepFromError(execErr))
 		recordErrorHook(ctx, def.Error, env, opts, result)
 		return result, execErr
 	}
@@ -187,6 +233,11 @@ func Run(ctx context.Context, def *Definition, opts RunOptions) (*RunResult, err
 }
 
 func newRunner(def *Definition, opts RunOptions, result *RunResult) (*runner, error) {
+	// Parallel steps share the output writers; serialize their writes.
+	writeMu := &sync.Mutex{}
+	opts.Stdout = &lockedWriter{mu: writeMu, w: opts.Stdout}
+	opts.Stderr = &lockedWriter{mu: writeMu, w: opts.Stderr}
+
 	r := &runner{
 		def:     def,
 		opts:    opts,
@@ -232,6 +283,12 @@ func newRunner(def *Definition, opts RunOptions, result *RunResult) (*runner, er
 		r.result.RunFile = runFile
 		r.result.Resumed = true
 		r.outputs = r.outputsFromState(state)
+		r.resumable = map[string]persistedStepState{}
+		for key, step := range state.Steps {
+			if step.Status == "ok" {
+				r.resumable[key] = step
+			}
+		}
 		r.result.Outputs = cloneNestedStringMap(r.outputs)
 		return r, nil
 	}
@@ -273,7 +330,7 @@ func (r *runner) outputsFromState(state *persistedRunState) map[string]map[strin
 	}
 	for _, stepKey := range slices.Sorted(maps.Keys(state.Steps)) {
 		step := state.Steps[stepKey]
-		if strings.TrimSpace(step.Name) == "" || len(step.Outputs) == 0 {
+		if step.Status != "ok" || step.Matrix != "" || strings.TrimSpace(step.Name) == "" || len(step.Outputs) == 0 {
 			continue
 		}
 		outputs[step.Name] = cloneStringMap(step.Outputs)
@@ -327,173 +384,573 @@ func (r *runner) persistHook(which string, hook *HookResult) error {
 	return saveRunState(r.statePath, *r.state)
 }
 
+// executeWorkflow runs a workflow's steps, once per combination when the
+// workflow declares a matrix.
+func (r *runner) executeWorkflow(ctx context.Context, workflowName string, wf Workflow, env map[string]string, callPath string, depth int) error {
+	if wf.Matrix == nil {
+		return r.executeSteps(ctx, workflowName, wf.Steps, env, callPath, depth)
+	}
+	matrixKey := callPath
+	if matrixKey == "" {
+		matrixKey = workflowName
+	}
+	return r.executeMatrix(ctx, workflowName, matrixKey, wf.Matrix, env, func(ctx context.Context, key string, env map[string]string) error {
+		return r.executeSteps(ctx, workflowName, wf.Steps, env, key, depth)
+	})
+}
+
 func (r *runner) executeSteps(ctx context.Context, workflowName string, steps []Step, env map[string]string, callPath string, depth int) error {
+	if usesNeeds(steps) {
+		return r.executeGraph(ctx, workflowName, steps, env, callPath, depth)
+	}
 	for i, step := range steps {
 		idx := i + 1
-		stepKey := appendStepKey(callPath, workflowName, idx)
-		stepStart := time.Now()
-
-		sr := StepResult{
-			Index:    idx,
-			Name:     step.Name,
-			Command:  step.Run,
-			Workflow: strings.TrimSpace(step.Workflow),
+		if err := r.executeStep(ctx, workflowName, idx, appendStepKey(callPath, workflowName, idx), step, env, depth); err != nil {
+			return err
 		}
-		if workflowName != r.opts.WorkflowName {
-			sr.ParentWorkflow = workflowName
+	}
+	return nil
+}
+
+// executeGraph runs steps as a dependency graph: a step starts once every
+// step named in its needs has finished. After the first failure no new steps
+// start, but steps already running are allowed to finish so their results
+// are persisted for --resume.
+func (r *runner) executeGraph(ctx context.Context, workflowName string, steps []Step, env map[string]string, callPath string, depth int) error {
+	indexByID := make(map[string]int, len(steps))
+	for i, step := range steps {
+		if id := strings.TrimSpace(step.ID); id != "" {
+			indexByID[id] = i
 		}
+	}
 
-		if ifVar := strings.TrimSpace(step.If); ifVar != "" {
-			val, ok := env[ifVar]
+	pending := make([]int, len(steps))
+	dependents := make([][]int, len(steps))
+	for i, step := range steps {
+		for _, need := range step.Needs {
+			dep, ok := indexByID[strings.TrimSpace(need)]
 			if !ok {
-				val = os.Getenv(ifVar)
-			}
-			if !isTruthy(val) {
-				sr.Status = "skipped"
-				sr.DurationMS = time.Since(stepStart).Milliseconds()
-				r.result.Steps = append(r.result.Steps, sr)
-				continue
+				return fmt.Errorf("workflow: %s step %d: unknown needs %q", workflowName, i+1, need)
 			}
+			pending[i]++
+			dependents[dep] = append(dependents[dep], i)
 		}
+	}
 
-		if ref := sr.Workflow; ref != "" {
-			if depth+1 > MaxCallDepth {
-				err := fmt.Errorf("workflow: %s step %d: max call depth %d exceeded", workflowName, idx, MaxCallDepth)
-				sr.Status = "error"
-				sr.Error = fmt.Sprintf("max call depth %d exceeded", MaxCallDepth)
-				sr.DurationMS = time.Since(stepStart).Milliseconds()
-				r.result.Steps = append(r.result.Steps, sr)
-				r.result.FailedStep = failedStepName(step.Name, stepKey)
-				return err
-			}
+	var ready []int
+	for i := range steps {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
 
-			subWf, ok := r.def.Workflows[ref]
-			if !ok {
-				err := fmt.Errorf("workflow: %s step %d: unknown workflow %q", workflowName, idx, ref)
-				sr.Status = "error"
-				sr.Error = fmt.Sprintf("unknown workflow %q", ref)
-				sr.DurationMS = time.Since(stepStart).Milliseconds()
-				r.result.Steps = append(r.result.Steps, sr)
-				r.result.FailedStep = failedStepName(step.Name, stepKey)
-				return err
-			}
+	type stepDone struct {
+		index int
+		err   error
+	}
+	done := make(chan stepDone)
+	running := 0
+	finished := 0
+	var firstErr error
 
-			resolvedWith := cloneStringMap(step.With)
-			var err error
-			if !r.opts.DryRun {
-				resolvedWith, err = interpolateMapValues(step.With, r.outputs)
-				if err != nil {
-					wrapped := fmt.Errorf("workflow: %s step %d: %w", workflowName, idx, err)
-					sr.Status = "error"
-					sr.Error = err.Error()
-					sr.DurationMS = time.Since(stepStart).Milliseconds()
-					r.result.Steps = append(r.result.Steps, sr)
-					r.result.FailedStep = failedStepName(step.Name, stepKey)
-					return wrapped
-				}
+	complete := func(i int, err error) {
+		finished++
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
 			}
-
-			subEnv := mergeEnv(subWf.Env, env, resolvedWith)
-			if r.opts.DryRun {
-				fmt.Fprintf(r.opts.Stderr, "[dry-run] step %d: workflow %s\n", idx, ref)
+			return
+		}
+		for _, next := range dependents[i] {
+			pending[next]--
+			if pending[next] == 0 {
+				ready = append(ready, next)
 			}
+		}
+	}
 
-			if err := r.executeSteps(ctx, ref, subWf.Steps, subEnv, stepKey, depth+1); err != nil {
-				return err
+	for {
+		if firstErr == nil && len(ready) > 0 {
+			slices.Sort(ready)
+			batch := ready
+			ready = nil
+			for _, i := range batch {
+				idx := i + 1
+				stepKey := appendStepKey(callPath, workflowName, idx)
+				if r.opts.DryRun {
+					// Dry runs stay sequential so the printed plan is deterministic.
+					complete(i, r.executeStep(ctx, workflowName, idx, stepKey, steps[i], env, depth))
+					continue
+				}
+				running++
+				go func(i int, stepKey string) {
+					done <- stepDone{index: i, err: r.executeStep(ctx, workflowName, i+1, stepKey, steps[i], env, depth)}
+				}(i, stepKey)
 			}
 			continue
 		}
+		if running == 0 {
+			break
+		}
+		result := <-done
+		running--
+		complete(result.index, result.err)
+	}
 
-		if r.state != nil {
-			if persisted, ok := r.state.Steps[stepKey]; ok && persisted.Status == "ok" {
-				sr.Status = "resumed"
-				sr.Outputs = cloneStringMap(persisted.Outputs)
-				sr.DurationMS = 0
-				r.result.Steps = append(r.result.Steps, sr)
-				if strings.TrimSpace(persisted.Name) != "" && len(persisted.Outputs) > 0 {
-					r.outputs[persisted.Name] = cloneStringMap(persisted.Outputs)
-					r.result.Outputs = cloneNestedStringMap(r.outputs)
-				}
-				continue
+	if firstErr != nil {
+		return firstErr
+	}
+	if finished < len(steps) {
+		return fmt.Errorf("workflow: %s: steps blocked by unsatisfiable needs", workflowName)
+	}
+	return nil
+}
+
+// executeParallel runs a parallel group's steps concurrently and returns the
+// first error in declaration order once every branch has finished.
+func (r *runner) executeParallel(ctx context.Context, workflowName, groupKey string, steps []Step, env map[string]string, depth int) error {
+	errs := make([]error, len(steps))
+	if r.opts.DryRun {
+		for i, step := range steps {
+			if errs[i] = r.executeStep(ctx, workflowName, i+1, parallelStepKey(groupKey, i+1), step, env, depth); errs[i] != nil {
+				break
 			}
 		}
+	} else {
+		var wg sync.WaitGroup
+		for i, step := range steps {
+			wg.Add(1)
+			go func(i int, step Step) {
+				defer wg.Done()
+				errs[i] = r.executeStep(ctx, workflowName, i+1, parallelStepKey(groupKey, i+1), step, env, depth)
+			}(i, step)
+		}
+		wg.Wait()
+	}
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+func (r *runner) executeStep(ctx context.Context, workflowName string, idx int, stepKey string, step Step, env map[string]string, depth int) error {
+	if step.Matrix != nil {
+		return r.executeMatrix(ctx, workflowName, stepKey, step.Matrix, env, func(ctx context.Context, key string, env map[string]string) error {
+			combined := step
+			combined.Matrix = nil
+			return r.executeStep(ctx, workflowName, idx, key, combined, env, depth)
+		})
+	}
+
+	stepStart := time.Now()
+	combo := comboFromContext(ctx)
+
+	sr := StepResult{
+		Index:    idx,
+		ID:       strings.TrimSpace(step.ID),
+		Name:     step.Name,
+		Command:  step.Run,
+		Uses:     strings.TrimSpace(step.Uses),
+		Workflow: strings.TrimSpace(step.Workflow),
+	}
+	if workflowName != r.opts.WorkflowName {
+		sr.ParentWorkflow = workflowName
+	}
+	if combo != nil {
+		sr.Matrix = combo.label
+	}
+
+	cond, err := parseCondition(step.If)
+	if err != nil {
+		sr.DurationMS = time.Since(stepStart).Milliseconds()
+		return r.failStep(sr, stepKey, fmt.Sprintf("invalid if: %v", err),
+			fmt.Errorf("workflow: %s step %d: invalid if: %w", workflowName, idx, err))
+	}
+	if cond != nil && !cond.evaluate(runnerScope{r: r, env: env, combo: combo}) {
+		sr.Status = "skipped"
+		sr.DurationMS = time.Since(stepStart).Milliseconds()
+		r.recordStep(sr)
+		return nil
+	}
+
+	if len(step.Parallel) > 0 {
 		if r.opts.DryRun {
-			fmt.Fprintf(r.opts.Stderr, "[dry-run] step %d: %s\n", idx, step.Run)
-			sr.Status = "dry-run"
+			fmt.Fprintf(r.opts.Stderr, "[dry-run] step %d: parallel (%d steps)\n", idx, len(step.Parallel))
+		}
+		return r.executeParallel(ctx, workflowName, stepKey, step.Parallel, env, depth)
+	}
+
+	if ref := sr.Workflow; ref != "" {
+		if depth+1 > MaxCallDepth {
 			sr.DurationMS = time.Since(stepStart).Milliseconds()
-			r.result.Steps = append(r.result.Steps, sr)
-			continue
+			return r.failStep(sr, stepKey, fmt.Sprintf("max call depth %d exceeded", MaxCallDepth),
+				fmt.Errorf("workflow: %s step %d: max call depth %d exceeded", workflowName, idx, MaxCallDepth))
+		}
+
+		subWf, ok := r.def.Workflows[ref]
+		if !ok {
+			sr.DurationMS = time.Since(stepStart).Milliseconds()
+			return r.failStep(sr, stepKey, fmt.Sprintf("unknown workflow %q", ref),
+				fmt.Errorf("workflow: %s step %d: unknown workflow %q", workflowName, idx, ref))
+		}
+
+		resolvedWith := cloneStringMap(step.With)
+		if !r.opts.DryRun {
+			r.mu.Lock()
+			resolvedWith, err = interpolateMapValues(step.With, r.stepOutputs(combo))
+			r.mu.Unlock()
+			if err != nil {
+				sr.DurationMS = time.Since(stepStart).Milliseconds()
+				return r.failStep(sr, stepKey, err.Error(), fmt.Errorf("workflow: %s step %d: %w", workflowName, idx, err))
+			}
 		}
 
-		command, err := interpolateCommand(step.Run, r.outputs)
+		resolvedWith, err = resolveInputs(ref, subWf.Inputs, resolvedWith, !r.opts.DryRun)
 		if err != nil {
-			wrapped := fmt.Errorf("workflow: %s step %d: %w", workflowName, idx, err)
-			sr.Status = "error"
-			sr.Error = err.Error()
 			sr.DurationMS = time.Since(stepStart).Milliseconds()
-			r.result.Steps = append(r.result.Steps, sr)
-			r.result.FailedStep = failedStepName(step.Name, stepKey)
-			return wrapped
+			return r.failStep(sr, stepKey, err.Error(), fmt.Errorf("workflow: %s step %d: %w", workflowName, idx, err))
+		}
+
+		subEnv := mergeEnv(subWf.Env, env, resolvedWith)
+		if r.opts.DryRun {
+			fmt.Fprintf(r.opts.Stderr, "[dry-run] step %d: workflow %s\n", idx, ref)
+			return r.executeWorkflow(ctx, ref, subWf, subEnv, stepKey, depth+1)
+		}
+		if !hasStepPolicy(step) {
+			return r.executeWorkflow(ctx, ref, subWf, subEnv, stepKey, depth+1)
+		}
+
+		// Workflow calls with a retry, timeout or continue_on_error policy
+		// get their own result so attempts are visible.
+		err = r.runAttempts(ctx, step, &sr, func(attemptCtx context.Context) error {
+			return r.executeWorkflow(attemptCtx, ref, subWf, subEnv, stepKey, depth+1)
+		})
+		sr.DurationMS = time.Since(stepStart).Milliseconds()
+		return r.finishPolicyStep(combo, sr, stepKey, step.ContinueOnError, err, err)
+	}
+
+	if r.resumeStep(combo, stepKey, sr) {
+		return nil
+	}
+
+	if r.opts.DryRun {
+		if sr.Uses != "" {
+			fmt.Fprintf(r.opts.Stderr, "[dry-run] step %d: uses %s\n", idx, describeUses(step.Uses, step.With))
+		} else {
+			fmt.Fprintf(r.opts.Stderr, "[dry-run] step %d: %s\n", idx, step.Run)
 		}
+		sr.Status = "dry-run"
+		sr.DurationMS = time.Since(stepStart).Milliseconds()
+		r.recordStep(sr)
+		return nil
+	}
+
+	if sr.Uses != "" {
+		return r.executeUses(ctx, workflowName, idx, stepKey, step, sr, env, stepStart)
+	}
+
+	r.mu.Lock()
+	command, err := interpolateCommand(step.Run, r.stepOutputs(combo))
+	r.mu.Unlock()
+	if err != nil {
+		sr.DurationMS = time.Since(stepStart).Milliseconds()
+		return r.failStep(sr, stepKey, err.Error(), fmt.Errorf("workflow: %s step %d: %w", workflowName, idx, err))
+	}
 
+	err = r.runAttempts(ctx, step, &sr, func(attemptCtx context.Context) error {
 		stdout := r.opts.Stdout
 		var captured bytes.Buffer
 		if len(step.Outputs) > 0 {
 			stdout = io.MultiWriter(r.opts.Stdout, &captured)
 		}
-
-		if err := runShellCommand(ctx, command, env, stdout, r.opts.Stderr); err != nil {
-			wrapped := fmt.Errorf("workflow: %s step %d: %w", workflowName, idx, err)
-			sr.Status = "error"
-			sr.Error = err.Error()
-			sr.DurationMS = time.Since(stepStart).Milliseconds()
-			r.result.Steps = append(r.result.Steps, sr)
-			r.result.FailedStep = failedStepName(step.Name, stepKey)
-			return wrapped
+		if err := r.runCommand(attemptCtx, command, env, stdout); err != nil {
+			return err
 		}
-
 		if len(step.Outputs) > 0 {
 			extracted, err := extractDeclaredOutputs(step.Outputs, captured.Bytes())
 			if err != nil {
-				wrapped := fmt.Errorf("workflow: %s step %d: %w", workflowName, idx, err)
-				sr.Status = "error"
-				sr.Error = err.Error()
-				sr.DurationMS = time.Since(stepStart).Milliseconds()
-				r.result.Steps = append(r.result.Steps, sr)
-				r.result.FailedStep = failedStepName(step.Name, stepKey)
-				return wrapped
+				return err
 			}
 			sr.Outputs = extracted
-			if strings.TrimSpace(step.Name) != "" {
-				r.outputs[step.Name] = cloneStringMap(extracted)
-				r.result.Outputs = cloneNestedStringMap(r.outputs)
-			}
 		}
+		return nil
+	})
+	sr.DurationMS = time.Since(stepStart).Milliseconds()
+	var wrapped error
+	if err != nil {
+		wrapped = fmt.Errorf("workflow: %s step %d: %w", workflowName, idx, err)
+	}
+	return r.finishPolicyStep(combo, sr, stepKey, step.ContinueOnError, err, wrapped)
+}
 
-		sr.Status = "ok"
+// executeUses runs a uses step through the in-process command runner. A
+// JSON result becomes the step's outputs; declared outputs are extracted on
+// top and take precedence.
+func (r *runner) executeUses(ctx context.Context, workflowName string, idx int, stepKey string, step Step, sr StepResult, env map[string]string, stepStart time.Time) error {
+	combo := comboFromContext(ctx)
+	if r.opts.ASC == nil {
 		sr.DurationMS = time.Since(stepStart).Milliseconds()
-		r.result.Steps = append(r.result.Steps, sr)
+		return r.failStep(sr, stepKey, "uses steps are not supported by this runner",
+			fmt.Errorf("workflow: %s step %d: uses steps are not supported by this runner", workflowName, idx))
+	}
 
-		if err := r.persistStep(stepKey, sr); err != nil {
-			r.result.FailedStep = failedStepName(step.Name, stepKey)
+	r.mu.Lock()
+	args, err := usesArgs(step.Uses, step.With, env, r.stepOutputs(combo))
+	r.mu.Unlock()
+	if err != nil {
+		sr.DurationMS = time.Since(stepStart).Milliseconds()
+		return r.failStep(sr, stepKey, err.Error(), fmt.Errorf("workflow: %s step %d: %w", workflowName, idx, err))
+	}
+
+	err = r.runAttempts(ctx, step, &sr, func(attemptCtx context.Context) error {
+		release, err := r.acquireSlot(attemptCtx)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		var captured bytes.Buffer
+		if err := r.opts.ASC(attemptCtx, args, io.MultiWriter(r.opts.Stdout, &captured)); err != nil {
+			return err
+		}
+		outputs := resultOutputs(captured.Bytes())
+		if len(step.Outputs) > 0 {
+			declared, err := extractDeclaredOutputs(step.Outputs, captured.Bytes())
+			if err != nil {
+				return err
+			}
+			if outputs == nil {
+				outputs = map[string]string{}
+			}
+			maps.Copy(outputs, declared)
+		}
+		sr.Outputs = outputs
+		return nil
+	})
+	sr.DurationMS = time.Since(stepStart).Milliseconds()
+	var wrapped error
+	if err != nil {
+		wrapped = fmt.Errorf("workflow: %s step %d: %w", workflowName, idx, err)
+	}
+	return r.finishPolicyStep(combo, sr, stepKey, step.ContinueOnError, err, wrapped)
+}
+
+// runnerScope resolves step conditions against the step's environment and
+// the steps the run has finished so far.
+type runnerScope struct {
+	r     *runner
+	env   map[string]string
+	combo *matrixCombo
+}
+
+func (s runnerScope) envValue(name string) string {
+	if val, ok := s.env[name]; ok {
+		return val
+	}
+	return os.Getenv(name)
+}
+
+func (s runnerScope) stepOutput(step, output string) string {
+	s.r.mu.Lock()
+	defer s.r.mu.Unlock()
+	if outputs, ok := s.r.stepOutputs(s.combo)[step]; ok {
+		return outputs[output]
+	}
+	for i := len(s.r.result.Steps) - 1; i >= 0; i-- {
+		if sr := s.r.result.Steps[i]; sr.ID == step && s.combo.sees(sr.Matrix) {
+			return sr.Outputs[output]
+		}
+	}
+	return ""
+}
+
+func (s runnerScope) stepSucceeded(step string) bool {
+	status := s.stepStatus(step)
+	return status == "ok" || status == "resumed" || status == "dry-run"
+}
+
+func (s runnerScope) stepFailed(step string) bool {
+	return s.stepStatus(step) == "error"
+}
+
+// stepStatus returns the latest status of the step named or identified by
+// step. With no step it returns "error" if any finished step failed and "ok"
+// otherwise. Inside a matrix, steps of other combinations are ignored.
+func (s runnerScope) stepStatus(step string) string {
+	s.r.mu.Lock()
+	defer s.r.mu.Unlock()
+	if step == "" {
+		for _, sr := range s.r.result.Steps {
+			if sr.Status == "error" && s.combo.sees(sr.Matrix) {
+				return "error"
+			}
+		}
+		return "ok"
+	}
+	for i := len(s.r.result.Steps) - 1; i >= 0; i-- {
+		if sr := s.r.result.Steps[i]; (sr.ID == step || sr.Name == step) && s.combo.sees(sr.Matrix) {
+			return sr.Status
+		}
+	}
+	return ""
+}
+
+// runAttempts runs attempt until it succeeds or the step's retry policy is
+// exhausted, applying the per-attempt timeout and recording every attempt
+// on sr when the step retries.
+func (r *runner) runAttempts(ctx context.Context, step Step, sr *StepResult, attempt func(context.Context) error) error {
+	attempts, backoff := retrySettings(step.Retry)
+	timeout := parseStepDuration(step.Timeout)
+
+	var err error
+	for n := 1; n <= attempts; n++ {
+		attemptStart := time.Now()
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if timeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+		}
+		err = attempt(attemptCtx)
+		if err != nil && timeout > 0 && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+		cancel()
+
+		if step.Retry != nil {
+			ar := AttemptResult{Attempt: n, Status: "ok", DurationMS: time.Since(attemptStart).Milliseconds()}
+			if err != nil {
+				ar.Status = "error"
+				ar.Error = err.Error()
+			}
+			sr.Attempts = append(sr.Attempts, ar)
+		}
+		if err == nil || n == attempts || ctx.Err() != nil {
+			return err
+		}
+
+		fmt.Fprintf(r.opts.Stderr, "workflow: %s attempt %d/%d failed: %v; retrying in %s\n", stepLabel(*sr), n, attempts, err, backoff)
+		if waitErr := sleepContext(ctx, backoff); waitErr != nil {
 			return err
 		}
+		backoff *= 2
+	}
+	return err
+}
+
+// finishPolicyStep records the final outcome of a step that ran through
+// runAttempts. A failure with continue_on_error is recorded and persisted
+// but does not stop the run.
+func (r *runner) finishPolicyStep(combo *matrixCombo, sr StepResult, stepKey string, continueOnError bool, cause, err error) error {
+	if cause == nil {
+		sr.Status = "ok"
+		return r.completeStep(combo, stepKey, sr)
+	}
+	if !continueOnError {
+		return r.failStep(sr, stepKey, cause.Error(), err)
+	}
+
+	sr.Status = "error"
+	sr.Error = cause.Error()
+	sr.ContinuedOnError = true
+	fmt.Fprintf(r.opts.Stderr, "workflow: %s failed, continuing: %v\n", stepLabel(sr), cause)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result.Steps = append(r.result.Steps, sr)
+	if r.state == nil {
+		return nil
+	}
+	r.state.Steps[stepKey] = persistedStepStateFromResult(sr)
+	return saveRunState(r.statePath, *r.state)
+}
+
+// runCommand runs a step command once a worker slot is free, bounding how
+// many commands run at the same time across the whole run.
+func (r *runner) runCommand(ctx context.Context, command string, env map[string]string, stdout io.Writer) error {
+	release, err := r.acquireSlot(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return runShellCommand(ctx, command, env, stdout, r.opts.Stderr)
+}
+
+// acquireSlot waits for a worker slot and returns the function that frees it.
+func (r *runner) acquireSlot(ctx context.Context) (func(), error) {
+	if r.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case r.slots <- struct{}{}:
+		return func() { <-r.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// resumeStep records a step that an earlier run persisted as successful and
+// restores its outputs. It reports whether the step was resumed.
+func (r *runner) resumeStep(combo *matrixCombo, stepKey string, sr StepResult) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	persisted, ok := r.resumable[stepKey]
+	if !ok {
+		return false
+	}
+	sr.Status = "resumed"
+	sr.Outputs = cloneStringMap(persisted.Outputs)
+	sr.DurationMS = 0
+	r.result.Steps = append(r.result.Steps, sr)
+	if strings.TrimSpace(persisted.Name) != "" && len(persisted.Outputs) > 0 {
+		r.publishOutputs(combo, persisted.Name, persisted.Outputs)
+	}
+	return true
+}
+
+func (r *runner) recordStep(sr StepResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result.Steps = append(r.result.Steps, sr)
+}
+
+// completeStep records a successful step, publishes its outputs and persists it.
+func (r *runner) completeStep(combo *matrixCombo, stepKey string, sr StepResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(sr.Outputs) > 0 && strings.TrimSpace(sr.Name) != "" {
+		r.publishOutputs(combo, sr.Name, sr.Outputs)
+	}
+	r.result.Steps = append(r.result.Steps, sr)
+
+	if err := r.persistStep(stepKey, sr); err != nil {
+		return &stepError{step: failedStepName(sr.Name, stepKey), err: err}
 	}
 	return nil
 }
 
+// failStep records a failed step and returns err tagged with the step name
+// reported as the run's failed step. The failure is persisted with the run
+// state by markFailure.
+func (r *runner) failStep(sr StepResult, stepKey, message string, err error) error {
+	sr.Status = "error"
+	sr.Error = message
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result.Steps = append(r.result.Steps, sr)
+	if r.state != nil {
+		r.state.Steps[stepKey] = persistedStepStateFromResult(sr)
+	}
+	return &stepError{step: failedStepName(sr.Name, stepKey), err: err}
+}
+
+// persistStep saves a successful step. Callers must hold r.mu.
 func (r *runner) persistStep(stepKey string, sr StepResult) error {
 	if r.state == nil || sr.Status != "ok" {
 		return nil
 	}
-	r.state.Steps[stepKey] = persistedStepState{
-		Name:           sr.Name,
-		Workflow:       sr.Workflow,
-		ParentWorkflow: sr.ParentWorkflow,
-		Status:         "ok",
-		Outputs:        cloneStringMap(sr.Outputs),
-	}
+	r.state.Steps[stepKey] = persistedStepStateFromResult(sr)
 	return saveRunState(r.statePath, *r.state)
 }
 
@@ -532,8 +989,10 @@ func (r *runner) hasRecoverableState() bool {
 	if r.state == nil {
 		return false
 	}
-	if len(r.state.Steps) > 0 {
-		return true
+	for _, step := range r.state.Steps {
+		if step.Status == "ok" {
+			return true
+		}
 	}
 	return r.state.Hooks != nil && r.state.Hooks.BeforeAll != nil && r.state.Hooks.BeforeAll.Status == "ok"
 }
@@ -583,6 +1042,31 @@ func runHookAndRecord(ctx context.Context, command string, env map[string]string
 	return hr, nil
 }
 
+func resolveMaxParallel(override, configured int) int {
+	switch {
+	case override > 0:
+		return override
+	case configured > 0:
+		return configured
+	default:
+		return DefaultMaxParallel
+	}
+}
+
+// usesNeeds reports whether steps form a dependency graph rather than a list.
+func usesNeeds(steps []Step) bool {
+	for _, step := range steps {
+		if len(step.Needs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func parallelStepKey(groupKey string, idx int) string {
+	return fmt.Sprintf("%s/parallel[%d]", groupKey, idx)
+}
+
 func appendStepKey(callPath, workflowName string, idx int) string {
 	segment := fmt.Sprintf("%s[%d]", workflowName, idx)
 	if strings.TrimSpace(callPath) == "" {
@@ -591,9 +1075,87 @@ func appendStepKey(callPath, workflowName string, idx int) string {
 	return callPath + "/" + segment
 }
 
+// stepError tags a step failure with the name reported as the run's
+// failed_step. Retried and continue_on_error steps drop the error, so only
+// a failure that ends the run sets failed_step.
+type stepError struct {
+	step string
+	err  error
+}
+
+func (e *stepError) Error() string { return e.err.Error() }
+
+func (e *stepError) Unwrap() error { return e.err }
+
+func failedStepFromError(err error) string {
+	var se *stepError
+	if errors.As(err, &se) {
+		return se.step
+	}
+	return ""
+}
+
+func hasStepPolicy(step Step) bool {
+	return step.Retry != nil || strings.TrimSpace(step.Timeout) != "" || step.ContinueOnError
+}
+
+// retrySettings returns the number of attempts and the initial backoff.
+func retrySettings(policy *RetryPolicy) (int, time.Duration) {
+	if policy == nil || policy.Attempts < 1 {
+		return 1, 0
+	}
+	return policy.Attempts, parseStepDuration(policy.Backoff)
+}
+
+// parseStepDuration parses a validated duration; invalid values mean zero.
+func parseStepDuration(value string) time.Duration {
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func stepLabel(sr StepResult) string {
+	switch {
+	case strings.TrimSpace(sr.Name) != "":
+		return "step " + strings.TrimSpace(sr.Name)
+	case sr.Workflow != "":
+		return fmt.Sprintf("step %d (workflow %s)", sr.Index, sr.Workflow)
+	default:
+		return fmt.Sprintf("step %d", sr.Index)
+	}
+}
+
 func failedStepName(name, stepKey string) string {
 	if strings.TrimSpace(name) != "" {
 		return strings.TrimSpace(name)
 	}
 	return stepKey
 }
+
+// lockedWriter serializes writes from concurrently running steps.
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (lw *lockedWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	return lw.w.Write(p)
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 func newTestDefinition() *Definition {
@@ -1567,3 +1569,582 @@ func TestRun_DurationMS_IncludesAfterAll(t *testing.T) {
 		t.Fatalf("expected DurationMS >= 100 (must include after_all time), got %d", result.DurationMS)
 	}
 }
+
+func TestRun_NeedsRunsDependenciesBeforeDependents(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "order.log")
+
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {
+				Steps: []Step{
+					{ID: "submit", Needs: []string{"metadata", "build"}, Run: fmt.Sprintf("echo submit >> %q", logPath)},
+					{ID: "metadata", Run: fmt.Sprintf("echo metadata >> %q", logPath)},
+					{ID: "build", Needs: []string{"metadata"}, Run: fmt.Sprintf("echo build >> %q", logPath)},
+				},
+			},
+		},
+	}
+
+	result, err := Run(context.Background(), def, runOpts("release"))
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Steps) != 3 || result.Steps[2].ID != "submit" {
+		t.Fatalf("expected submit to finish last, got %+v", result.Steps)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	if got := string(data); got != "metadata\nbuild\nsubmit\n" {
+		t.Fatalf("unexpected execution order %q", got)
+	}
+}
+
+func TestRun_NeedsFailureSkipsDependents(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {
+				Steps: []Step{
+					{ID: "build", Name: "build", Run: "exit 3"},
+					{ID: "submit", Needs: []string{"build"}, Run: "echo should-not-run"},
+				},
+			},
+		},
+	}
+	opts := runOpts("release")
+
+	result, err := Run(context.Background(), def, opts)
+	if err == nil {
+		t.Fatal("expected failure")
+	}
+	if result.FailedStep != "build" || len(result.Steps) != 1 {
+		t.Fatalf("expected only the failed build step, got failed_step=%q steps=%+v", result.FailedStep, result.Steps)
+	}
+	if strings.Contains(opts.Stdout.(*bytes.Buffer).String(), "should-not-run") {
+		t.Fatal("expected dependent step not to run")
+	}
+}
+
+func TestRun_ParallelStepsOverlap(t *testing.T) {
+	dir := t.TempDir()
+	// Each branch waits for the other's marker, so the group only succeeds
+	// when both branches run at the same time.
+	branch := func(mine, other string) Step {
+		return Step{Run: fmt.Sprintf(
+			`touch %q; i=0; while [ ! -f %q ]; do i=$((i+1)); [ $i -gt 100 ] && exit 1; sleep 0.05; done`,
+			filepath.Join(dir, mine), filepath.Join(dir, other),
+		)}
+	}
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"screens": {
+				MaxParallel: 2,
+				Steps: []Step{
+					{Name: "upload", Parallel: []Step{branch("en", "fr"), branch("fr", "en")}},
+					{Run: "echo done"},
+				},
+			},
+		},
+	}
+
+	result, err := Run(context.Background(), def, runOpts("screens"))
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Steps) != 3 {
+		t.Fatalf("expected two parallel steps and one sequential step, got %+v", result.Steps)
+	}
+	if result.Steps[2].Command != "echo done" {
+		t.Fatalf("expected sequential step after the group, got %+v", result.Steps[2])
+	}
+}
+
+func TestRun_ParallelBranchFailureResumesOnlyFailedBranch(t *testing.T) {
+	dir := t.TempDir()
+	counterPath := filepath.Join(dir, "en-count.txt")
+	allowPath := filepath.Join(dir, "allow-fr")
+
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"screens": {
+				Steps: []Step{
+					{Parallel: []Step{
+						{Name: "upload_en", Run: fmt.Sprintf(`printf 'hit\n' >> %q`, counterPath)},
+						{Name: "upload_fr", Run: fmt.Sprintf(`test -f %q`, allowPath)},
+					}},
+				},
+			},
+		},
+	}
+
+	runFile := filepath.Join(dir, "workflow.json")
+	stateDir := filepath.Join(dir, "runs")
+	firstOpts := runOpts("screens")
+	firstOpts.WorkflowFile = runFile
+	firstOpts.StateDir = stateDir
+
+	first, err := Run(context.Background(), def, firstOpts)
+	if err == nil {
+		t.Fatal("expected first run to fail")
+	}
+	if first.FailedStep != "upload_fr" || !first.Recoverable {
+		t.Fatalf("expected recoverable upload_fr failure, got %+v", first)
+	}
+
+	state, err := loadRunState(first.RunFile)
+	if err != nil || state == nil {
+		t.Fatalf("load run state: %v", err)
+	}
+	if got := state.Steps["screens[1]/parallel[1]"].Status; got != "ok" {
+		t.Fatalf("expected persisted ok for upload_en, got %q", got)
+	}
+	if got := state.Steps["screens[1]/parallel[2]"]; got.Status != "error" || got.Error == "" {
+		t.Fatalf("expected persisted error for upload_fr, got %+v", got)
+	}
+
+	if err := os.WriteFile(allowPath, []byte("ok"), 0o600); err != nil {
+		t.Fatalf("write allow file: %v", err)
+	}
+	resumeOpts := runOpts("screens")
+	resumeOpts.WorkflowFile = runFile
+	resumeOpts.StateDir = stateDir
+	resumeOpts.ResumeRunID = first.RunID
+
+	resumed, err := Run(context.Background(), def, resumeOpts)
+	if err != nil {
+		t.Fatalf("resume Run: %v", err)
+	}
+	statuses := map[string]string{}
+	for _, step := range resumed.Steps {
+		statuses[step.Name] = step.Status
+	}
+	if statuses["upload_en"] != "resumed" || statuses["upload_fr"] != "ok" {
+		t.Fatalf("unexpected resumed statuses %v", statuses)
+	}
+	data, err := os.ReadFile(counterPath)
+	if err != nil {
+		t.Fatalf("read counter: %v", err)
+	}
+	if got := strings.Count(string(data), "hit\n"); got != 1 {
+		t.Fatalf("expected upload_en to run once, got %d", got)
+	}
+}
+
+func TestRun_DryRunGraphIsDeterministic(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {
+				Steps: []Step{
+					{ID: "submit", Needs: []string{"upload"}, Run: "echo submit"},
+					{ID: "upload", Parallel: []Step{{Run: "echo en"}, {Run: "echo fr"}}},
+				},
+			},
+		},
+	}
+	opts := runOpts("release")
+	opts.DryRun = true
+
+	if _, err := Run(context.Background(), def, opts); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	want := "[dry-run] step 2: parallel (2 steps)\n[dry-run] step 1: echo en\n[dry-run] step 2: echo fr\n[dry-run] step 1: echo submit\n"
+	if got := opts.Stderr.(*bytes.Buffer).String(); got != want {
+		t.Fatalf("unexpected dry-run plan:\n%s", got)
+	}
+}
+
+func TestResolveMaxParallel(t *testing.T) {
+	tests := []struct {
+		override, configured, want int
+	}{
+		{0, 0, DefaultMaxParallel},
+		{0, 8, 8},
+		{2, 8, 2},
+	}
+	for _, test := range tests {
+		if got := resolveMaxParallel(test.override, test.configured); got != test.want {
+			t.Fatalf("resolveMaxParallel(%d, %d) = %d, want %d", test.override, test.configured, got, test.want)
+		}
+	}
+}
+
+func flakyCommand(counterPath string, succeedOn int) string {
+	return fmt.Sprintf(`printf 'hit\n' >> %q; [ "$(wc -l < %q)" -ge %d ]`, counterPath, counterPath, succeedOn)
+}
+
+func TestRun_RetryRecordsAttemptsAndSkipsErrorHookOnRecovery(t *testing.T) {
+	dir := t.TempDir()
+	def := &Definition{
+		Error: "echo error_hook_ran",
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{{
+				Name:  "wait_build",
+				Run:   flakyCommand(filepath.Join(dir, "count"), 3),
+				Retry: &RetryPolicy{Attempts: 3, Backoff: "1ms"},
+			}}},
+		},
+	}
+	opts := runOpts("release")
+	opts.StateDir = filepath.Join(dir, "runs")
+
+	result, err := Run(context.Background(), def, opts)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	step := result.Steps[0]
+	if step.Status != "ok" || len(step.Attempts) != 3 {
+		t.Fatalf("expected ok after 3 attempts, got %+v", step)
+	}
+	if step.Attempts[0].Status != "error" || step.Attempts[2].Status != "ok" {
+		t.Fatalf("unexpected attempt outcomes %+v", step.Attempts)
+	}
+	if result.Hooks != nil && result.Hooks.Error != nil {
+		t.Fatalf("expected error hook not to run, got %+v", result.Hooks.Error)
+	}
+	if !strings.Contains(opts.Stderr.(*bytes.Buffer).String(), "attempt 1/3 failed") {
+		t.Fatalf("expected retry notice on stderr, got %q", opts.Stderr.(*bytes.Buffer).String())
+	}
+
+	state, err := loadRunState(result.RunFile)
+	if err != nil || state == nil {
+		t.Fatalf("load run state: %v", err)
+	}
+	if got := state.Steps["release[1]"].Attempts; len(got) != 3 {
+		t.Fatalf("expected persisted attempts, got %+v", got)
+	}
+}
+
+func TestRun_RetryExhaustedFailsOnce(t *testing.T) {
+	dir := t.TempDir()
+	def := &Definition{
+		Error: "echo error_hook_ran",
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{{
+				Name:  "wait_build",
+				Run:   flakyCommand(filepath.Join(dir, "count"), 5),
+				Retry: &RetryPolicy{Attempts: 2},
+			}}},
+		},
+	}
+	opts := runOpts("release")
+
+	result, err := Run(context.Background(), def, opts)
+	if err == nil {
+		t.Fatal("expected failure after retries")
+	}
+	if result.FailedStep != "wait_build" || len(result.Steps) != 1 || len(result.Steps[0].Attempts) != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if got := strings.Count(opts.Stdout.(*bytes.Buffer).String(), "error_hook_ran"); got != 1 {
+		t.Fatalf("expected error hook to run once, ran %d times", got)
+	}
+}
+
+func TestRun_StepTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+	}{
+		{name: "single command", command: "sleep 5"},
+		// The shell forks sleep here, so the timeout must stop the whole
+		// process group rather than only the shell.
+		{name: "compound command", command: "sleep 5; echo done"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			def := &Definition{
+				Workflows: map[string]Workflow{
+					"release": {Steps: []Step{{Name: "slow", Run: test.command, Timeout: "100ms"}}},
+				},
+			}
+			opts := runOpts("release")
+
+			start := time.Now()
+			result, err := Run(context.Background(), def, opts)
+			if err == nil {
+				t.Fatal("expected timeout failure")
+			}
+			if elapsed := time.Since(start); elapsed >= commandWaitDelay {
+				t.Fatalf("expected the step to stop promptly, took %s", elapsed)
+			}
+			if result.Steps[0].Error != "timed out after 100ms" {
+				t.Fatalf("unexpected step error %q", result.Steps[0].Error)
+			}
+			if stdout := opts.Stdout.(*bytes.Buffer).String(); strings.Contains(stdout, "done") {
+				t.Fatalf("expected the command to be killed, got stdout %q", stdout)
+			}
+		})
+	}
+}
+
+func TestRun_ContinueOnError(t *testing.T) {
+	def := &Definition{
+		Error: "echo error_hook_ran",
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{
+				{Name: "notify", Run: "exit 2", ContinueOnError: true},
+				{Name: "publish", Run: "echo published"},
+			}},
+		},
+	}
+	opts := runOpts("release")
+
+	result, err := Run(context.Background(), def, opts)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Status != "ok" || result.FailedStep != "" {
+		t.Fatalf("expected ok run, got %+v", result)
+	}
+	if step := result.Steps[0]; step.Status != "error" || !step.ContinuedOnError {
+		t.Fatalf("expected continued error step, got %+v", step)
+	}
+	if stdout := opts.Stdout.(*bytes.Buffer).String(); !strings.Contains(stdout, "published") || strings.Contains(stdout, "error_hook_ran") {
+		t.Fatalf("unexpected stdout %q", stdout)
+	}
+}
+
+func TestRun_RetryWorkflowCall(t *testing.T) {
+	dir := t.TempDir()
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{{
+				Name:     "upload",
+				Workflow: "upload",
+				Retry:    &RetryPolicy{Attempts: 2},
+			}}},
+			"upload": {Private: true, Steps: []Step{{Run: flakyCommand(filepath.Join(dir, "count"), 2)}}},
+		},
+	}
+
+	result, err := Run(context.Background(), def, runOpts("release"))
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	call := result.Steps[len(result.Steps)-1]
+	if call.Workflow != "upload" || call.Status != "ok" || len(call.Attempts) != 2 {
+		t.Fatalf("expected call step with two attempts, got %+v", call)
+	}
+}
+
+func TestRun_IfExpressionUsesOutputsAndOutcomes(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {
+				Env: map[string]string{"PLATFORM": "IOS"},
+				Steps: []Step{
+					{
+						Name:    "upload",
+						Run:     `printf '{"buildId":"build-42"}'`,
+						Outputs: map[string]string{"BUILD_ID": "$.buildId"},
+					},
+					{Name: "notify", Run: "exit 3", ContinueOnError: true},
+					{Name: "distribute", Run: "echo distributed", If: "steps.upload.BUILD_ID != '' && env.PLATFORM == 'IOS'"},
+					{Name: "mac", Run: "echo mac", If: "env.PLATFORM == 'MAC_OS'"},
+					{Name: "cleanup", Run: "echo cleanup", If: "failure('notify') && success('upload')"},
+					{Name: "celebrate", Run: "echo celebrate", If: "success()"},
+				},
+			},
+		},
+	}
+	opts := runOpts("release")
+
+	result, err := Run(context.Background(), def, opts)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	want := map[string]string{"distribute": "ok", "mac": "skipped", "cleanup": "ok", "celebrate": "skipped"}
+	for _, step := range result.Steps {
+		if status, ok := want[step.Name]; ok && step.Status != status {
+			t.Fatalf("expected %s to be %s, got %+v", step.Name, status, step)
+		}
+	}
+}
+
+func TestRun_InvalidIfExpressionFailsStep(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"test": {Steps: []Step{{Name: "check", Run: "echo no", If: "env.A = 'x'"}}},
+		},
+	}
+
+	result, err := Run(context.Background(), def, runOpts("test"))
+	if err == nil || !strings.Contains(err.Error(), "invalid if") {
+		t.Fatalf("expected invalid if error, got %v", err)
+	}
+	if result.FailedStep != "check" || result.Steps[0].Status != "error" {
+		t.Fatalf("expected failed check step, got %+v", result)
+	}
+}
+
+func TestRun_WorkflowMatrixIsolatesCombinationOutputs(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {
+				Matrix: &Matrix{Axes: map[string][]string{"platform": {"IOS", "MAC_OS"}, "app": {"A", "B"}}},
+				Steps: []Step{
+					{
+						Name:    "upload",
+						Run:     `printf '{"buildId":"%s-%s"}' "$MATRIX_APP" "$MATRIX_PLATFORM"`,
+						Outputs: map[string]string{"BUILD_ID": "$.buildId"},
+					},
+					{
+						Name: "check",
+						Run:  `test "${steps.upload.BUILD_ID}" = "$MATRIX_APP-$MATRIX_PLATFORM"`,
+					},
+					{Name: "mac", Run: "echo mac-only", If: "env.MATRIX_PLATFORM == 'MAC_OS'"},
+				},
+			},
+		},
+	}
+	opts := runOpts("release")
+	opts.StateDir = filepath.Join(t.TempDir(), "runs")
+
+	result, err := Run(context.Background(), def, opts)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Matrix) != 4 || len(result.Steps) != 12 {
+		t.Fatalf("expected 4 combinations and 12 steps, got %+v", result)
+	}
+	wantKeys := []string{
+		"release/matrix[app=A,platform=IOS]",
+		"release/matrix[app=A,platform=MAC_OS]",
+		"release/matrix[app=B,platform=IOS]",
+		"release/matrix[app=B,platform=MAC_OS]",
+	}
+	for i, combo := range result.Matrix {
+		if combo.Key != wantKeys[i] || combo.Status != "ok" {
+			t.Fatalf("unexpected combination %d: %+v", i, combo)
+		}
+		want := combo.Values["app"] + "-" + combo.Values["platform"]
+		if got := combo.Outputs["upload"]["BUILD_ID"]; got != want {
+			t.Fatalf("expected %s outputs %q, got %q", combo.Key, want, got)
+		}
+	}
+	if len(result.Outputs) != 0 {
+		t.Fatalf("expected matrix outputs to stay out of run outputs, got %v", result.Outputs)
+	}
+	if stdout := opts.Stdout.(*bytes.Buffer).String(); strings.Count(stdout, "mac-only") != 2 {
+		t.Fatalf("expected mac step in two combinations, got %q", stdout)
+	}
+}
+
+func TestRun_StepMatrixFailFastCancelsRemainingCombinations(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{
+				{
+					Name:   "build",
+					Run:    `if [ "$MATRIX_APP" = "A" ]; then exit 4; fi; echo "built $MATRIX_APP"`,
+					Matrix: &Matrix{Axes: map[string][]string{"app": {"A", "B", "C"}}, MaxParallel: 1},
+				},
+				{Name: "after", Run: "echo after"},
+			}},
+		},
+	}
+	opts := runOpts("release")
+
+	result, err := Run(context.Background(), def, opts)
+	if err == nil {
+		t.Fatal("expected matrix failure")
+	}
+	if result.FailedStep != "build" {
+		t.Fatalf("expected failed_step build, got %q", result.FailedStep)
+	}
+	statuses := []string{result.Matrix[0].Status, result.Matrix[1].Status, result.Matrix[2].Status}
+	if !slices.Equal(statuses, []string{"error", "cancelled", "cancelled"}) {
+		t.Fatalf("unexpected combination statuses %v", statuses)
+	}
+	if stdout := opts.Stdout.(*bytes.Buffer).String(); strings.Contains(stdout, "built") || strings.Contains(stdout, "after") {
+		t.Fatalf("expected no further steps, got %q", stdout)
+	}
+}
+
+func TestRun_StepMatrixWithoutFailFastRunsEveryCombination(t *testing.T) {
+	failFast := false
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{{
+				Name:   "build",
+				Run:    `if [ "$MATRIX_APP" = "A" ]; then exit 4; fi; echo "built $MATRIX_APP"`,
+				Matrix: &Matrix{Axes: map[string][]string{"app": {"A", "B", "C"}}, FailFast: &failFast},
+			}}},
+		},
+	}
+	opts := runOpts("release")
+
+	result, err := Run(context.Background(), def, opts)
+	if err == nil {
+		t.Fatal("expected matrix failure")
+	}
+	statuses := []string{result.Matrix[0].Status, result.Matrix[1].Status, result.Matrix[2].Status}
+	if !slices.Equal(statuses, []string{"error", "ok", "ok"}) {
+		t.Fatalf("unexpected combination statuses %v", statuses)
+	}
+	for _, step := range result.Steps {
+		if step.Matrix == "" {
+			t.Fatalf("expected matrix label on %+v", step)
+		}
+	}
+}
+
+func TestRun_MatrixResumeSkipsCompletedCombinations(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "fixed")
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{{
+				Name:   "build",
+				Run:    fmt.Sprintf(`if [ "$MATRIX_APP" = "B" ] && [ ! -f %q ]; then exit 4; fi; echo "built $MATRIX_APP"`, marker),
+				Matrix: &Matrix{Axes: map[string][]string{"app": {"A", "B"}}, MaxParallel: 1},
+			}}},
+		},
+	}
+	opts := runOpts("release")
+	opts.WorkflowFile = filepath.Join(dir, "workflow.json")
+	opts.StateDir = filepath.Join(dir, "runs")
+
+	first, err := Run(context.Background(), def, opts)
+	if err == nil || !first.Recoverable {
+		t.Fatalf("expected recoverable failure, got %+v (%v)", first, err)
+	}
+	if err := os.WriteFile(marker, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resumeOpts := runOpts("release")
+	resumeOpts.WorkflowFile = opts.WorkflowFile
+	resumeOpts.StateDir = opts.StateDir
+	resumeOpts.ResumeRunID = first.RunID
+	second, err := Run(context.Background(), def, resumeOpts)
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if second.Steps[0].Status != "resumed" || second.Steps[1].Status != "ok" {
+		t.Fatalf("expected A resumed and B rerun, got %+v", second.Steps)
+	}
+}
+
+func TestRun_DryRunMatrixListsCombinations(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{{
+				Run:    "asc builds latest --platform $MATRIX_PLATFORM",
+				Matrix: &Matrix{Axes: map[string][]string{"platform": {"IOS", "TV_OS"}}},
+			}}},
+		},
+	}
+	opts := runOpts("release")
+	opts.DryRun = true
+
+	if _, err := Run(context.Background(), def, opts); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	stderr := opts.Stderr.(*bytes.Buffer).String()
+	if !strings.Contains(stderr, "[dry-run] matrix platform=IOS\n[dry-run] step 1: asc builds latest --platform $MATRIX_PLATFORM\n[dry-run] matrix platform=TV_OS\n") {
+		t.Fatalf("unexpected dry-run output %q", stderr)
+	}
+}
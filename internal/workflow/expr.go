@@ -0,0 +1,555 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Step conditions ("if") are either a bare environment variable name or an
+// expression such as
+//
+//	steps.upload.build_id != '' && env.PLATFORM == 'IOS'
+//
+// Expressions support string, number and boolean literals, env.NAME and
+// steps.STEP.OUTPUT references, comparisons (== != < <= > >=), boolean logic
+// (&& || !), parentheses, and the functions contains, startsWith, endsWith,
+// success, failure and always. A comparison is numeric only when one side is
+// a number literal; otherwise values compare as strings. They are evaluated
+// in-process; nothing is passed to a shell.
+
+var (
+	legacyConditionPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	decimalPattern         = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+)
+
+// conditionScope resolves the references a condition can read.
+type conditionScope interface {
+	envValue(name string) string
+	stepOutput(step, output string) string
+	// stepSucceeded and stepFailed report the outcome of the named step, or
+	// of all steps finished so far when step is empty.
+	stepSucceeded(step string) bool
+	stepFailed(step string) bool
+}
+
+type condition struct {
+	legacyEnv string
+	expr      exprNode
+}
+
+// parseCondition parses a step "if" value.
+func parseCondition(input string) (*condition, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return nil, nil
+	}
+	if legacyConditionPattern.MatchString(trimmed) && !isExprKeyword(trimmed) {
+		return &condition{legacyEnv: trimmed}, nil
+	}
+	p := &exprParser{input: trimmed}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", tok.describe(), tok.pos+1)
+	}
+	return &condition{expr: node}, nil
+}
+
+// isExprKeyword reports whether word is a literal keyword rather than a
+// variable name.
+func isExprKeyword(word string) bool {
+	switch word {
+	case "true", "false", "null":
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *condition) evaluate(scope conditionScope) bool {
+	if c.legacyEnv != "" {
+		return isTruthy(scope.envValue(c.legacyEnv))
+	}
+	return truthy(c.expr.eval(scope))
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenDot
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return fmt.Sprintf("string %q", t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+type exprParser struct {
+	input  string
+	tokens []token
+	next   int
+}
+
+func (p *exprParser) tokenize() error {
+	s := p.input
+	for i := 0; i < len(s); {
+		ch := s[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case ch == '(':
+			p.tokens = append(p.tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case ch == ')':
+			p.tokens = append(p.tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case ch == ',':
+			p.tokens = append(p.tokens, token{kind: tokenComma, text: ",", pos: i})
+			i++
+		case ch == '.':
+			p.tokens = append(p.tokens, token{kind: tokenDot, text: ".", pos: i})
+			i++
+		case ch == '\'' || ch == '"':
+			value, end, err := scanQuoted(s, i)
+			if err != nil {
+				return err
+			}
+			p.tokens = append(p.tokens, token{kind: tokenString, text: value, value: value, pos: i})
+			i = end
+		case strings.HasPrefix(s[i:], "&&"), strings.HasPrefix(s[i:], "||"),
+			strings.HasPrefix(s[i:], "=="), strings.HasPrefix(s[i:], "!="),
+			strings.HasPrefix(s[i:], "<="), strings.HasPrefix(s[i:], ">="):
+			p.tokens = append(p.tokens, token{kind: tokenOp, text: s[i : i+2], pos: i})
+			i += 2
+		case ch == '!' || ch == '<' || ch == '>':
+			p.tokens = append(p.tokens, token{kind: tokenOp, text: string(ch), pos: i})
+			i++
+		case ch == '-' || (ch >= '0' && ch <= '9'):
+			start := i
+			i++
+			for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+				i++
+			}
+			number, err := strconv.ParseFloat(s[start:i], 64)
+			if err != nil {
+				return fmt.Errorf("invalid number %q at position %d", s[start:i], start+1)
+			}
+			p.tokens = append(p.tokens, token{kind: tokenNumber, text: s[start:i], value: number, pos: start})
+		case isIdentStart(ch):
+			start := i
+			for i < len(s) && isIdentPart(s[i]) {
+				i++
+			}
+			p.tokens = append(p.tokens, token{kind: tokenIdent, text: s[start:i], pos: start})
+		default:
+			return fmt.Errorf("unexpected character %q at position %d", ch, i+1)
+		}
+	}
+	p.tokens = append(p.tokens, token{kind: tokenEOF, pos: len(s)})
+	return nil
+}
+
+// scanQuoted reads a quoted string starting at s[start]. A doubled quote
+// character inside the string stands for one literal quote.
+func scanQuoted(s string, start int) (string, int, error) {
+	quote := s[start]
+	var b strings.Builder
+	for i := start + 1; i < len(s); i++ {
+		if s[i] != quote {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == quote {
+			b.WriteByte(quote)
+			i++
+			continue
+		}
+		return b.String(), i + 1, nil
+	}
+	return "", 0, fmt.Errorf("unterminated string starting at position %d", start+1)
+}
+
+func isIdentStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isIdentPart(ch byte) bool {
+	return isIdentStart(ch) || ch == '-' || (ch >= '0' && ch <= '9')
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *exprParser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *exprParser) expect(kind tokenKind, text string) error {
+	tok := p.advance()
+	if tok.kind != kind {
+		return fmt.Errorf("expected %q at position %d, found %s", text, tok.pos+1, tok.describe())
+	}
+	return nil
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOp && p.peek().text == "||" {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOp && p.peek().text == "&&" {
+		p.advance()
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind == tokenOp {
+		switch tok.text {
+		case "==", "!=", "<", "<=", ">", ">=":
+			p.advance()
+			right, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			return compareNode{op: tok.text, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if tok := p.peek(); tok.kind == tokenOp && tok.text == "!" {
+		p.advance()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokenString, tokenNumber:
+		return literalNode{value: tok.value}, nil
+	case tokenLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "null":
+			return literalNode{value: nil}, nil
+		}
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(tok)
+		}
+		return p.parseReference(tok)
+	default:
+		return nil, fmt.Errorf("unexpected %s at position %d", tok.describe(), tok.pos+1)
+	}
+}
+
+var exprFunctionArity = map[string][2]int{
+	"contains":   {2, 2},
+	"startsWith": {2, 2},
+	"endsWith":   {2, 2},
+	"success":    {0, 1},
+	"failure":    {0, 1},
+	"always":     {0, 0},
+}
+
+func (p *exprParser) parseCall(name token) (exprNode, error) {
+	arity, ok := exprFunctionArity[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos+1)
+	}
+	p.advance() // (
+	var args []exprNode
+	if p.peek().kind != tokenRParen {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.advance()
+		}
+	}
+	if err := p.expect(tokenRParen, ")"); err != nil {
+		return nil, err
+	}
+	if len(args) < arity[0] || len(args) > arity[1] {
+		return nil, fmt.Errorf("%s() takes %s, got %d", name.text, describeArity(arity), len(args))
+	}
+	return callNode{name: name.text, args: args}, nil
+}
+
+func describeArity(arity [2]int) string {
+	switch {
+	case arity[0] == arity[1] && arity[0] == 1:
+		return "1 argument"
+	case arity[0] == arity[1]:
+		return fmt.Sprintf("%d arguments", arity[0])
+	default:
+		return fmt.Sprintf("%d to %d arguments", arity[0], arity[1])
+	}
+}
+
+func (p *exprParser) parseReference(root token) (exprNode, error) {
+	parts := []string{root.text}
+	for p.peek().kind == tokenDot {
+		p.advance()
+		part := p.advance()
+		if part.kind != tokenIdent {
+			return nil, fmt.Errorf("expected a name after '.' at position %d, found %s", part.pos+1, part.describe())
+		}
+		parts = append(parts, part.text)
+	}
+
+	ref := strings.Join(parts, ".")
+	switch parts[0] {
+	case "env":
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid reference %q (expected env.NAME)", ref)
+		}
+	case "steps":
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid reference %q (expected steps.STEP.OUTPUT)", ref)
+		}
+	default:
+		return nil, fmt.Errorf("unknown name %q at position %d (use env.NAME, steps.STEP.OUTPUT or a quoted string)", ref, root.pos+1)
+	}
+	return referenceNode{parts: parts}, nil
+}
+
+type exprNode interface {
+	eval(scope conditionScope) any
+}
+
+type literalNode struct{ value any }
+
+func (n literalNode) eval(conditionScope) any { return n.value }
+
+type referenceNode struct{ parts []string }
+
+func (n referenceNode) eval(scope conditionScope) any {
+	if n.parts[0] == "env" {
+		return scope.envValue(n.parts[1])
+	}
+	return scope.stepOutput(n.parts[1], n.parts[2])
+}
+
+type notNode struct{ operand exprNode }
+
+func (n notNode) eval(scope conditionScope) any { return !truthy(n.operand.eval(scope)) }
+
+type logicalNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n logicalNode) eval(scope conditionScope) any {
+	left := truthy(n.left.eval(scope))
+	if n.op == "&&" {
+		return left && truthy(n.right.eval(scope))
+	}
+	return left || truthy(n.right.eval(scope))
+}
+
+type compareNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n compareNode) eval(scope conditionScope) any {
+	left, right := n.left.eval(scope), n.right.eval(scope)
+
+	// Only a number literal makes a comparison numeric, so two references
+	// such as versions "1.10" and "1.1" still compare as strings.
+	_, lnum := left.(float64)
+	_, rnum := right.(float64)
+	if lnum || rnum {
+		ln, lok := exprNumber(left)
+		rn, rok := exprNumber(right)
+		if lok && rok {
+			return compareOrdered(n.op, ln, rn)
+		}
+	}
+	return compareOrdered(n.op, exprString(left), exprString(right))
+}
+
+func compareOrdered[T float64 | string](op string, left, right T) bool {
+	switch op {
+	case "==":
+		return left == right
+	case "!=":
+		return left != right
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	default:
+		return left >= right
+	}
+}
+
+type callNode struct {
+	name string
+	args []exprNode
+}
+
+func (n callNode) eval(scope conditionScope) any {
+	args := make([]any, len(n.args))
+	for i, arg := range n.args {
+		args[i] = arg.eval(scope)
+	}
+	switch n.name {
+	case "contains":
+		return strings.Contains(exprString(args[0]), exprString(args[1]))
+	case "startsWith":
+		return strings.HasPrefix(exprString(args[0]), exprString(args[1]))
+	case "endsWith":
+		return strings.HasSuffix(exprString(args[0]), exprString(args[1]))
+	case "success":
+		return scope.stepSucceeded(optionalStepArg(args))
+	case "failure":
+		return scope.stepFailed(optionalStepArg(args))
+	default: // always
+		return true
+	}
+}
+
+func optionalStepArg(args []any) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return exprString(args[0])
+}
+
+// exprNumber reports a value as a number when it is one or when it is a
+// plain decimal string such as "42" or "-1.5". Forms like "1e3", "0x10" and
+// "inf" are left as strings.
+func exprNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if !decimalPattern.MatchString(trimmed) {
+			return 0, false
+		}
+		n, err := strconv.ParseFloat(trimmed, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func exprString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// truthy reports whether a value counts as true in an expression. Strings
+// follow isTruthy, the same rule as a bare variable name, so a reference
+// such as steps.upload.build_id is true whenever the step produced an ID.
+func truthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return isTruthy(v)
+	default:
+		return false
+	}
+}
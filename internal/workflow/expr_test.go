@@ -0,0 +1,133 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+)
+
+type fakeScope struct {
+	env      map[string]string
+	outputs  map[string]map[string]string
+	statuses map[string]string
+}
+
+func (s fakeScope) envValue(name string) string { return s.env[name] }
+
+func (s fakeScope) stepOutput(step, output string) string { return s.outputs[step][output] }
+
+func (s fakeScope) stepSucceeded(step string) bool {
+	if step == "" {
+		return !s.stepFailed("")
+	}
+	return s.statuses[step] == "ok"
+}
+
+func (s fakeScope) stepFailed(step string) bool {
+	if step == "" {
+		for _, status := range s.statuses {
+			if status == "error" {
+				return true
+			}
+		}
+		return false
+	}
+	return s.statuses[step] == "error"
+}
+
+func TestCondition_Evaluate(t *testing.T) {
+	scope := fakeScope{
+		env:      map[string]string{"PLATFORM": "IOS", "SUBMIT": "yes", "BUILDS": "3", "DRY_RUN": "false", "VERSION": "1.10", "AUTO_SUBMIT": "no"},
+		outputs:  map[string]map[string]string{"upload": {"build_id": "build-42", "state": "VALID", "count": "1e3", "raw_id": "12345abc"}},
+		statuses: map[string]string{"upload": "ok", "notify": "error"},
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"SUBMIT", true},
+		{"MISSING", false},
+		{"steps.upload.build_id != '' && env.PLATFORM == 'IOS'", true},
+		{"steps.upload.missing != ''", false},
+		{"env.PLATFORM == 'MAC_OS' || env.SUBMIT", true},
+		{"!(env.PLATFORM == 'IOS')", false},
+		{"env.BUILDS >= 2 && env.BUILDS < 10", true},
+		{"env.BUILDS == 3.0", true},
+		{`contains(steps.upload.build_id, "42")`, true},
+		{"startsWith(steps.upload.build_id, 'build-') && endsWith(steps.upload.state, 'ID')", true},
+		{"'it''s' == \"it's\"", true},
+		{"success()", false},
+		{"failure()", true},
+		{"success('upload') && failure('notify')", true},
+		{"always()", true},
+		{"true && !false", true},
+		{"null", false},
+		{"env.UNSET == ''", true},
+		{"env.VERSION == '1.1'", false},
+		{"env.VERSION == '1.10'", true},
+		{"'01' == '1'", false},
+		{"env.VERSION > 1.2", false},
+		{"steps.upload.count == 1000", false},
+		{"steps.upload.count == '1e3'", true},
+		{"steps.upload.raw_id && env.PLATFORM == 'IOS'", true},
+		{"steps.upload.missing || env.UNSET", false},
+		{"env.DRY_RUN", false},
+		{"!env.DRY_RUN && env.SUBMIT", true},
+		{"AUTO_SUBMIT", false},
+		{"env.AUTO_SUBMIT", false},
+		{"DRY_RUN", false},
+		{"true", true},
+		{"false", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			cond, err := parseCondition(test.expr)
+			if err != nil {
+				t.Fatalf("parseCondition(%q): %v", test.expr, err)
+			}
+			if got := cond.evaluate(scope); got != test.want {
+				t.Fatalf("evaluate(%q) = %v, want %v", test.expr, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseCondition_Empty(t *testing.T) {
+	cond, err := parseCondition("  ")
+	if err != nil || cond != nil {
+		t.Fatalf("expected no condition, got %+v, %v", cond, err)
+	}
+}
+
+func TestParseCondition_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"env.PLATFORM ==", "unexpected end of expression"},
+		{"env.PLATFORM = 'IOS'", "unexpected character '='"},
+		{"'unterminated", "unterminated string"},
+		{"platform == 'IOS'", `unknown name "platform"`},
+		{"steps.upload == ''", "expected steps.STEP.OUTPUT"},
+		{"env.A.B", "expected env.NAME"},
+		{"exec('rm -rf /')", `unknown function "exec"`},
+		{"contains('a')", "contains() takes 2 arguments, got 1"},
+		{"always(1)", "always() takes 0 arguments, got 1"},
+		{"(env.A == 'x'", `expected ")"`},
+		{"env.A == 'x' env.B", `unexpected "env"`},
+		{"env.A == 'x' == 'y'", `unexpected "=="`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			_, err := parseCondition(test.expr)
+			if err == nil {
+				t.Fatalf("expected error for %q", test.expr)
+			}
+			if !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("expected error containing %q, got %v", test.want, err)
+			}
+		})
+	}
+}
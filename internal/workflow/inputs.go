@@ -0,0 +1,92 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	inputTypeString  = "string"
+	inputTypeNumber  = "number"
+	inputTypeBoolean = "boolean"
+)
+
+func (in Input) inputType() string {
+	if t := strings.TrimSpace(in.Type); t != "" {
+		return t
+	}
+	return inputTypeString
+}
+
+// defaultValue renders the declared default as an environment value.
+func (in Input) defaultValue() (string, bool) {
+	switch v := in.Default.(type) {
+	case nil:
+		return "", false
+	case string, bool, float64:
+		return exprString(v), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// normalizeInputValue checks value against the input type and returns it in
+// canonical form: booleans become "true" or "false".
+func normalizeInputValue(inputType, value string) (string, error) {
+	switch inputType {
+	case inputTypeNumber:
+		if _, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil {
+			return "", fmt.Errorf("%q is not a number", value)
+		}
+		return strings.TrimSpace(value), nil
+	case inputTypeBoolean:
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "true", "1", "yes", "y", "on":
+			return "true", nil
+		case "false", "0", "no", "n", "off":
+			return "false", nil
+		default:
+			return "", fmt.Errorf("%q is not a boolean", value)
+		}
+	default:
+		return value, nil
+	}
+}
+
+// resolveInputs applies a workflow's declared inputs to the values a caller
+// passed: defaults fill in missing values, required inputs must be present,
+// and every declared input is type-checked unless checkTypes is false (dry
+// runs, where step outputs are not interpolated). Undeclared values pass
+// through unchanged.
+func resolveInputs(workflowName string, inputs map[string]Input, provided map[string]string, checkTypes bool) (map[string]string, error) {
+	if len(inputs) == 0 {
+		return provided, nil
+	}
+	resolved := make(map[string]string, len(provided)+len(inputs))
+	maps.Copy(resolved, provided)
+
+	for _, name := range slices.Sorted(maps.Keys(inputs)) {
+		input := inputs[name]
+		value, ok := provided[name]
+		if !ok {
+			if value, ok = input.defaultValue(); !ok {
+				if input.Required {
+					return nil, fmt.Errorf("workflow %s: missing required input %q", workflowName, name)
+				}
+				continue
+			}
+		}
+		if checkTypes {
+			normalized, err := normalizeInputValue(input.inputType(), value)
+			if err != nil {
+				return nil, fmt.Errorf("workflow %s: input %q: %w", workflowName, name, err)
+			}
+			value = normalized
+		}
+		resolved[name] = value
+	}
+	return resolved, nil
+}
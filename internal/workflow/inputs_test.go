@@ -0,0 +1,125 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRun_WorkflowInputsApplyDefaultsAndTypes(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {
+				Steps: []Step{{Workflow: "release-common", With: map[string]string{"APP_ID": "123", "NOTIFY": "yes"}}},
+			},
+			"release-common": {
+				Private: true,
+				Inputs: map[string]Input{
+					"APP_ID":   {Type: "number", Required: true},
+					"NOTIFY":   {Type: "boolean", Default: false},
+					"PLATFORM": {Default: "IOS"},
+				},
+				Steps: []Step{{Run: `echo "$APP_ID $NOTIFY $PLATFORM"`}},
+			},
+		},
+	}
+	opts := runOpts("release")
+
+	if _, err := Run(context.Background(), def, opts); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := opts.Stdout.(*bytes.Buffer).String(); !strings.Contains(got, "123 true IOS") {
+		t.Fatalf("expected resolved inputs in output, got %q", got)
+	}
+}
+
+func TestRun_WorkflowInputErrors(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"caller": {
+				Steps: []Step{
+					{
+						Name:    "pick",
+						Run:     `printf '{"build":"latest"}'`,
+						Outputs: map[string]string{"BUILD": "$.build"},
+					},
+					{Workflow: "deploy", With: map[string]string{"BUILD_NUMBER": "${steps.pick.BUILD}"}},
+				},
+			},
+			"deploy": {
+				Inputs: map[string]Input{"BUILD_NUMBER": {Type: "number", Required: true}},
+				Steps:  []Step{{Run: "echo deploy"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		workflow string
+		params   map[string]string
+		wantErr  string
+	}{
+		{name: "missing required param", workflow: "deploy", wantErr: `workflow deploy: missing required input "BUILD_NUMBER"`},
+		{name: "mistyped param", workflow: "deploy", params: map[string]string{"BUILD_NUMBER": "abc"}, wantErr: `input "BUILD_NUMBER": "abc" is not a number`},
+		{name: "mistyped step output", workflow: "caller", wantErr: `input "BUILD_NUMBER": "latest" is not a number`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := runOpts(test.workflow)
+			opts.Params = test.params
+			result, err := Run(context.Background(), def, opts)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+			}
+			if result.Status != "error" {
+				t.Fatalf("expected status error, got %q", result.Status)
+			}
+		})
+	}
+}
+
+func TestValidate_WorkflowInputs(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"caller": {
+				Steps: []Step{
+					{Workflow: "deploy", With: map[string]string{"COUNT": "many", "EXTRA": "x"}},
+					{Workflow: "deploy", With: map[string]string{"BUILD": "$BUILD_ID", "COUNT": "${steps.size.COUNT}"}},
+				},
+			},
+			"deploy": {
+				Inputs: map[string]Input{
+					"BUILD":    {Required: true},
+					"COUNT":    {Type: "number", Default: 1.0},
+					"DRY":      {Type: "boolean", Default: "maybe"},
+					"LEVEL":    {Type: "enum"},
+					"TARGET":   {Required: true, Default: "prod"},
+					"bad-name": {},
+				},
+				Steps: []Step{{Run: "echo deploy"}},
+			},
+		},
+	}
+
+	errs := Validate(def)
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Message)
+	}
+	want := []string{
+		`workflow "caller" step 1 does not pass required input "BUILD" to workflow "deploy"`,
+		`workflow "caller" step 1 passes input "COUNT" to workflow "deploy": "many" is not a number`,
+		`workflow "caller" step 1 does not pass required input "TARGET" to workflow "deploy"`,
+		`workflow "caller" step 1 passes "EXTRA", which workflow "deploy" does not declare as an input`,
+		`workflow "caller" step 2 does not pass required input "TARGET" to workflow "deploy"`,
+		`workflow "deploy" input "DRY" default "maybe" is not a boolean`,
+		`workflow "deploy" input "LEVEL" has unknown type "enum" (use string, number or boolean)`,
+		`workflow "deploy" input "TARGET" cannot be required and have a default`,
+		`workflow "deploy" input "bad-name" must start with a letter or underscore and contain only letters, digits, underscores`,
+	}
+	if !slices.Equal(messages, want) {
+		t.Fatalf("unexpected errors:\n%s", strings.Join(messages, "\n"))
+	}
+}
@@ -6,7 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/tidwall/jsonc"
 )
@@ -37,8 +41,101 @@ func Load(path string) (*Definition, error) {
 	return def, nil
 }
 
-// LoadUnvalidated reads and parses a workflow definition file without validation.
+// LoadUnvalidated reads and parses a workflow definition file and the files it
+// imports without validation. Import cycles are recorded rather than
+// followed, and reported by Validate.
 func LoadUnvalidated(path string) (*Definition, error) {
+	def, err := parseDefinitionFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(def.Imports) == 0 {
+		return def, nil
+	}
+	if err := resolveImports(def, path); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// resolveImports merges the workflows of every file def imports, directly
+// or transitively, into def. An imported file's env is folded into the env of
+// its own workflows (workflow keys win) rather than into def.Env. Imported
+// files may not declare hooks, and a workflow name may be defined only once
+// across all files.
+func resolveImports(def *Definition, path string) error {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrWorkflowRead, err)
+	}
+	def.file = root
+	def.importGraph = map[string][]string{}
+
+	origins := make(map[string]string, len(def.Workflows))
+	for name := range def.Workflows {
+		origins[name] = root
+	}
+	loaded := map[string]bool{root: true}
+	var visit func(file string, imports []string) error
+	visit = func(file string, imports []string) error {
+		for _, imp := range imports {
+			target, err := importPath(file, imp)
+			if err != nil {
+				return err
+			}
+			def.importGraph[file] = append(def.importGraph[file], target)
+			if loaded[target] {
+				continue
+			}
+			loaded[target] = true
+
+			imported, err := parseDefinitionFile(target)
+			if err != nil {
+				return fmt.Errorf("import %q: %w", imp, err)
+			}
+			if imported.BeforeAll != "" || imported.AfterAll != "" || imported.Error != "" {
+				return fmt.Errorf("import %q: hooks (before_all, after_all, error) are only allowed in the root workflow file", imp)
+			}
+			for _, name := range slices.Sorted(maps.Keys(imported.Workflows)) {
+				if origin, exists := origins[name]; exists {
+					return fmt.Errorf("import %q: workflow %q is already defined in %s", imp, name, origin)
+				}
+				origins[name] = target
+				if def.Workflows == nil {
+					def.Workflows = map[string]Workflow{}
+				}
+				workflow := imported.Workflows[name]
+				if len(imported.Env) > 0 {
+					workflow.Env = mergeEnv(imported.Env, workflow.Env)
+				}
+				def.Workflows[name] = workflow
+			}
+			if err := visit(target, imported.Imports); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return visit(root, def.Imports)
+}
+
+// importPath resolves an import relative to the importing file. Only local
+// paths are supported.
+func importPath(from, imp string) (string, error) {
+	trimmed := strings.TrimSpace(imp)
+	if trimmed == "" {
+		return "", fmt.Errorf("import path must not be empty")
+	}
+	if strings.Contains(trimmed, "://") {
+		return "", fmt.Errorf("import %q: only local file paths can be imported", imp)
+	}
+	if !filepath.IsAbs(trimmed) {
+		trimmed = filepath.Join(filepath.Dir(from), trimmed)
+	}
+	return filepath.Clean(trimmed), nil
+}
+
+func parseDefinitionFile(path string) (*Definition, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrWorkflowRead, err)
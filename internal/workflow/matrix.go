@@ -0,0 +1,303 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxMatrixCombinations caps how many combinations one matrix may expand to.
+const MaxMatrixCombinations = 256
+
+// Matrix expands a workflow or step into one run per combination of its axis
+// values. In JSON the axes sit beside the reserved max_parallel and
+// fail_fast keys:
+//
+//	"matrix": {"platform": ["IOS", "MAC_OS"], "app": ["A", "B"], "max_parallel": 2}
+//
+// Each combination sees its values as MATRIX_<AXIS> environment variables and
+// publishes step outputs into its own namespace.
+type Matrix struct {
+	Axes map[string][]string
+	// MaxParallel limits how many combinations run at once; zero means all.
+	MaxParallel int
+	// FailFast cancels the remaining combinations after the first failure.
+	// It defaults to true.
+	FailFast *bool
+}
+
+// MatrixResult records one combination of a matrix run.
+type MatrixResult struct {
+	Key        string                       `json:"key"`
+	Workflow   string                       `json:"workflow"`
+	Values     map[string]string            `json:"values"`
+	Status     string                       `json:"status"`
+	Error      string                       `json:"error,omitempty"`
+	Outputs    map[string]map[string]string `json:"outputs,omitempty"`
+	DurationMS int64                        `json:"duration_ms"`
+}
+
+// UnmarshalJSON reads axes and the reserved max_parallel and fail_fast keys.
+// Axis values may be strings, numbers or booleans.
+func (m *Matrix) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("matrix must be an object: %w", err)
+	}
+
+	*m = Matrix{Axes: map[string][]string{}}
+	for key, value := range raw {
+		switch key {
+		case "max_parallel":
+			if err := json.Unmarshal(value, &m.MaxParallel); err != nil {
+				return fmt.Errorf("matrix max_parallel must be an integer: %w", err)
+			}
+		case "fail_fast":
+			var failFast bool
+			if err := json.Unmarshal(value, &failFast); err != nil {
+				return fmt.Errorf("matrix fail_fast must be a boolean: %w", err)
+			}
+			m.FailFast = &failFast
+		default:
+			var items []any
+			if err := json.Unmarshal(value, &items); err != nil {
+				return fmt.Errorf("matrix axis %q must be an array: %w", key, err)
+			}
+			values := make([]string, 0, len(items))
+			for _, item := range items {
+				switch item.(type) {
+				case string, float64, bool:
+					values = append(values, exprString(item))
+				default:
+					return fmt.Errorf("matrix axis %q values must be strings, numbers or booleans", key)
+				}
+			}
+			m.Axes[key] = values
+		}
+	}
+	return nil
+}
+
+// MarshalJSON writes the matrix in the same flat shape UnmarshalJSON reads.
+func (m Matrix) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any, len(m.Axes)+2)
+	for axis, values := range m.Axes {
+		out[axis] = values
+	}
+	if m.MaxParallel != 0 {
+		out["max_parallel"] = m.MaxParallel
+	}
+	if m.FailFast != nil {
+		out["fail_fast"] = *m.FailFast
+	}
+	return json.Marshal(out)
+}
+
+func (m *Matrix) failFast() bool {
+	return m.FailFast == nil || *m.FailFast
+}
+
+// combinations expands the axes in sorted axis order, varying the last axis
+// fastest.
+func (m *Matrix) combinations() []map[string]string {
+	axes := slices.Sorted(maps.Keys(m.Axes))
+	combos := []map[string]string{{}}
+	for _, axis := range axes {
+		next := make([]map[string]string, 0, len(combos)*len(m.Axes[axis]))
+		for _, combo := range combos {
+			for _, value := range m.Axes[axis] {
+				expanded := make(map[string]string, len(combo)+1)
+				maps.Copy(expanded, combo)
+				expanded[axis] = value
+				next = append(next, expanded)
+			}
+		}
+		combos = next
+	}
+	return combos
+}
+
+// combinationCount returns the number of combinations without expanding them,
+// or -1 once it passes MaxMatrixCombinations.
+func (m *Matrix) combinationCount() int {
+	count := 1
+	for _, values := range m.Axes {
+		count *= len(values)
+		if count > MaxMatrixCombinations {
+			return -1
+		}
+	}
+	return count
+}
+
+func matrixEnvName(axis string) string {
+	return "MATRIX_" + strings.ToUpper(axis)
+}
+
+func matrixLabel(values map[string]string) string {
+	parts := make([]string, 0, len(values))
+	for _, axis := range slices.Sorted(maps.Keys(values)) {
+		parts = append(parts, axis+"="+values[axis])
+	}
+	return strings.Join(parts, ",")
+}
+
+// matrixCombo is the combination a step runs in. It travels in the context
+// so nested steps publish and read outputs in the combination's namespace.
+type matrixCombo struct {
+	parent *matrixCombo
+	// values holds this and every enclosing combination's axis values.
+	values map[string]string
+	label  string
+	// outputs is guarded by runner.mu.
+	outputs map[string]map[string]string
+}
+
+// sees reports whether a step that ran in the combination labelled label is
+// visible from combo: steps outside any matrix, and steps of combo or one of
+// its enclosing combinations.
+func (combo *matrixCombo) sees(label string) bool {
+	if label == "" {
+		return true
+	}
+	for c := combo; c != nil; c = c.parent {
+		if c.label == label {
+			return true
+		}
+	}
+	return false
+}
+
+type matrixComboKey struct{}
+
+func comboFromContext(ctx context.Context) *matrixCombo {
+	combo, _ := ctx.Value(matrixComboKey{}).(*matrixCombo)
+	return combo
+}
+
+// stepOutputs returns the outputs a step in combo can see: the run's outputs
+// overlaid with each enclosing combination's. Callers must hold r.mu.
+func (r *runner) stepOutputs(combo *matrixCombo) map[string]map[string]string {
+	if combo == nil {
+		return r.outputs
+	}
+	view := r.stepOutputs(combo.parent)
+	if len(combo.outputs) == 0 {
+		return view
+	}
+	view = maps.Clone(view)
+	maps.Copy(view, combo.outputs)
+	return view
+}
+
+// publishOutputs stores a step's outputs in the namespace of the combination
+// it ran in, or in the run's outputs outside a matrix. Callers must hold r.mu.
+func (r *runner) publishOutputs(combo *matrixCombo, name string, outputs map[string]string) {
+	if combo != nil {
+		combo.outputs[name] = cloneStringMap(outputs)
+		return
+	}
+	r.outputs[name] = cloneStringMap(outputs)
+	r.result.Outputs = cloneNestedStringMap(r.outputs)
+}
+
+// executeMatrix runs fn once per combination of matrix. Combinations run
+// concurrently up to the matrix's max_parallel; with fail_fast the first
+// failure cancels the rest. Every combination is recorded in
+// RunResult.Matrix, and the first failure in combination order is returned.
+func (r *runner) executeMatrix(ctx context.Context, workflowName, matrixKey string, matrix *Matrix, env map[string]string,
+	fn func(ctx context.Context, key string, env map[string]string) error,
+) error {
+	combos := matrix.combinations()
+	parent := comboFromContext(ctx)
+
+	matrixCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]MatrixResult, len(combos))
+	errs := make([]error, len(combos))
+
+	run := func(i int) {
+		values := combos[i]
+		if parent != nil {
+			values = cloneStringMap(parent.values)
+			maps.Copy(values, combos[i])
+		}
+		key := fmt.Sprintf("%s/matrix[%s]", matrixKey, matrixLabel(combos[i]))
+		results[i] = MatrixResult{Key: key, Workflow: workflowName, Values: values, Status: "cancelled"}
+		if matrixCtx.Err() != nil {
+			return
+		}
+
+		comboEnv := make(map[string]string, len(env)+len(combos[i]))
+		maps.Copy(comboEnv, env)
+		for axis, value := range combos[i] {
+			comboEnv[matrixEnvName(axis)] = value
+		}
+		combo := &matrixCombo{parent: parent, values: values, label: matrixLabel(values), outputs: map[string]map[string]string{}}
+		comboCtx := context.WithValue(matrixCtx, matrixComboKey{}, combo)
+
+		start := time.Now()
+		err := fn(comboCtx, key, comboEnv)
+		results[i].DurationMS = time.Since(start).Milliseconds()
+
+		r.mu.Lock()
+		results[i].Outputs = cloneNestedStringMap(combo.outputs)
+		r.mu.Unlock()
+
+		switch {
+		case err == nil:
+			results[i].Status = "ok"
+		case matrixCtx.Err() != nil && ctx.Err() == nil:
+			// Interrupted by fail_fast after another combination failed.
+			results[i].Error = err.Error()
+		default:
+			results[i].Status = "error"
+			results[i].Error = err.Error()
+			errs[i] = err
+			if matrix.failFast() {
+				cancel()
+			}
+		}
+	}
+
+	if r.opts.DryRun {
+		for i := range combos {
+			fmt.Fprintf(r.opts.Stderr, "[dry-run] matrix %s\n", matrixLabel(combos[i]))
+			run(i)
+		}
+	} else {
+		limit := matrix.MaxParallel
+		if limit <= 0 || limit > len(combos) {
+			limit = len(combos)
+		}
+		sem := make(chan struct{}, limit)
+		var wg sync.WaitGroup
+		for i := range combos {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				run(i)
+			}(i)
+		}
+		wg.Wait()
+	}
+
+	r.mu.Lock()
+	r.result.Matrix = append(r.result.Matrix, results...)
+	r.mu.Unlock()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !darwin && !linux && !freebsd && !netbsd && !openbsd && !dragonfly
+
+package workflow
+
+import "os/exec"
+
+// startInProcessGroup keeps the default cancellation, which kills only the
+// shell; commandWaitDelay bounds how long its children can hold the output
+// open afterwards.
+func startInProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,24 @@
+//go:build darwin || linux || freebsd || netbsd || openbsd || dragonfly
+
+package workflow
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// startInProcessGroup runs cmd in its own process group and makes context
+// cancellation kill the whole group, so commands started by the shell (for
+// example "asc builds wait ...; echo ok") stop along with it.
+func startInProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		if errors.Is(err, syscall.ESRCH) {
+			return os.ErrProcessDone
+		}
+		return err
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 )
@@ -24,11 +25,29 @@ type persistedRunHooks struct {
 }
 
 type persistedStepState struct {
+	ID             string            `json:"id,omitempty"`
 	Name           string            `json:"name,omitempty"`
 	Workflow       string            `json:"workflow,omitempty"`
 	ParentWorkflow string            `json:"parent_workflow,omitempty"`
 	Status         string            `json:"status,omitempty"`
+	Error          string            `json:"error,omitempty"`
 	Outputs        map[string]string `json:"outputs,omitempty"`
+	Attempts       []AttemptResult   `json:"attempts,omitempty"`
+	Matrix         string            `json:"matrix,omitempty"`
+}
+
+func persistedStepStateFromResult(sr StepResult) persistedStepState {
+	return persistedStepState{
+		ID:             sr.ID,
+		Name:           sr.Name,
+		Workflow:       sr.Workflow,
+		ParentWorkflow: sr.ParentWorkflow,
+		Status:         sr.Status,
+		Error:          sr.Error,
+		Outputs:        cloneStringMap(sr.Outputs),
+		Attempts:       slices.Clone(sr.Attempts),
+		Matrix:         sr.Matrix,
+	}
 }
 
 type persistedRunState struct {
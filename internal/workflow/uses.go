@@ -0,0 +1,141 @@
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var validFlagName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]*$`)
+
+// usesCommand splits a uses value such as "asc builds latest" into the
+// command path after "asc".
+func usesCommand(uses string) ([]string, error) {
+	fields := strings.Fields(uses)
+	if len(fields) < 2 || fields[0] != "asc" {
+		return nil, fmt.Errorf(`uses must name an asc command, such as "asc builds latest"`)
+	}
+	for _, field := range fields[1:] {
+		if strings.HasPrefix(field, "-") {
+			return nil, fmt.Errorf("uses %q must not contain flags; pass them in with", uses)
+		}
+	}
+	// Command names match case-insensitively when run, so the guard must too.
+	if strings.EqualFold(fields[1], "workflow") {
+		return nil, fmt.Errorf("uses cannot run asc workflow; use a workflow step instead")
+	}
+	return fields[1:], nil
+}
+
+// usesArgs builds the in-process argument list for a uses step: the command
+// path followed by one --flag=value per with entry in sorted order. $VAR and
+// ${VAR} in with values expand against the step environment, since no shell
+// is involved, and ${steps.NAME.OUTPUT} resolves from outputs.
+func usesArgs(uses string, with, env map[string]string, outputs map[string]map[string]string) ([]string, error) {
+	args, err := usesCommand(uses)
+	if err != nil {
+		return nil, err
+	}
+	lookup := func(name string) string {
+		if strings.HasPrefix(name, "steps.") {
+			// Left for step output interpolation.
+			return "${" + name + "}"
+		}
+		if value, ok := env[name]; ok {
+			return value
+		}
+		return os.Getenv(name)
+	}
+	for _, flagName := range slices.Sorted(maps.Keys(with)) {
+		value, err := interpolateStepOutputs(os.Expand(with[flagName], lookup), outputs, false)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", flagName, value))
+	}
+	return args, nil
+}
+
+// describeUses renders a uses step for dry runs without expanding variables.
+func describeUses(uses string, with map[string]string) string {
+	parts := []string{strings.Join(strings.Fields(uses), " ")}
+	for _, flagName := range slices.Sorted(maps.Keys(with)) {
+		parts = append(parts, fmt.Sprintf("--%s=%s", flagName, with[flagName]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// resultOutputs turns a command's JSON result into step outputs. Every
+// scalar reachable through objects becomes an output named by its path
+// joined with underscores, such as data_attributes_version. For a JSON:API
+// document whose data is a single resource, id, type and each scalar
+// attribute are also exposed directly. Output that is not a JSON object
+// yields no outputs.
+func resultOutputs(stdout []byte) map[string]string {
+	var payload map[string]any
+	if err := json.Unmarshal(bytes.TrimSpace(stdout), &payload); err != nil {
+		return nil
+	}
+
+	outputs := map[string]string{}
+	flattenResult(outputs, "", payload)
+
+	if resource, ok := payload["data"].(map[string]any); ok {
+		shortcuts := map[string]string{}
+		for _, key := range []string{"id", "type"} {
+			if value, ok := resultScalar(resource[key]); ok {
+				shortcuts[key] = value
+			}
+		}
+		if attributes, ok := resource["attributes"].(map[string]any); ok {
+			for key, raw := range attributes {
+				if value, ok := resultScalar(raw); ok && validOutputName.MatchString(key) {
+					shortcuts[key] = value
+				}
+			}
+		}
+		for key, value := range shortcuts {
+			if _, exists := outputs[key]; !exists {
+				outputs[key] = value
+			}
+		}
+	}
+
+	if len(outputs) == 0 {
+		return nil
+	}
+	return outputs
+}
+
+func flattenResult(outputs map[string]string, prefix string, object map[string]any) {
+	for key, raw := range object {
+		if !validOutputName.MatchString(key) {
+			continue
+		}
+		name := key
+		if prefix != "" {
+			name = prefix + "_" + key
+		}
+		if nested, ok := raw.(map[string]any); ok {
+			flattenResult(outputs, name, nested)
+			continue
+		}
+		if value, ok := resultScalar(raw); ok {
+			outputs[name] = value
+		}
+	}
+}
+
+func resultScalar(raw any) (string, bool) {
+	switch raw.(type) {
+	case string, bool, float64:
+		return exprString(raw), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,158 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRun_UsesStepRunsInProcessWithFlagsAndOutputs(t *testing.T) {
+	def := &Definition{
+		Env: map[string]string{"APP_ID": "123456789"},
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{
+				{
+					Name: "latest",
+					Uses: "asc builds latest",
+					With: map[string]string{"app": "$APP_ID", "platform": "IOS"},
+				},
+				{
+					Name:    "info",
+					Uses:    "asc builds info",
+					With:    map[string]string{"build-id": "${steps.latest.id}"},
+					Outputs: map[string]string{"STATE": "$.data.attributes.processingState"},
+				},
+				{Run: `test "${steps.info.STATE}" = VALID && test "${steps.latest.data_attributes_version}" = 42`},
+			}},
+		},
+	}
+
+	var mu sync.Mutex
+	var calls [][]string
+	opts := runOpts("release")
+	opts.ASC = func(_ context.Context, args []string, stdout io.Writer) error {
+		mu.Lock()
+		calls = append(calls, args)
+		mu.Unlock()
+		_, err := fmt.Fprint(stdout, `{"data":{"type":"builds","id":"build-1","attributes":{"version":"42","processingState":"VALID","expired":false}},"links":{"self":"https://example.com"}}`)
+		return err
+	}
+
+	result, err := Run(context.Background(), def, opts)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	want := [][]string{
+		{"builds", "latest", "--app=123456789", "--platform=IOS"},
+		{"builds", "info", "--build-id=build-1"},
+	}
+	if !slices.EqualFunc(calls, want, slices.Equal[[]string]) {
+		t.Fatalf("unexpected in-process calls %v", calls)
+	}
+	latest := result.Outputs["latest"]
+	for key, value := range map[string]string{
+		"id":                      "build-1",
+		"type":                    "builds",
+		"version":                 "42",
+		"expired":                 "false",
+		"data_attributes_version": "42",
+		"links_self":              "https://example.com",
+	} {
+		if latest[key] != value {
+			t.Fatalf("expected output %s=%q, got %v", key, value, latest)
+		}
+	}
+	if result.Outputs["info"]["STATE"] != "VALID" || result.Steps[0].Uses != "asc builds latest" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+}
+
+func TestRun_UsesStepFailureAndMissingRunner(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{{Name: "latest", Uses: "asc builds latest"}}},
+		},
+	}
+
+	result, err := Run(context.Background(), def, runOpts("release"))
+	if err == nil || !strings.Contains(err.Error(), "uses steps are not supported") || result.FailedStep != "latest" {
+		t.Fatalf("expected unsupported runner error, got %v (%+v)", err, result)
+	}
+
+	opts := runOpts("release")
+	opts.ASC = func(context.Context, []string, io.Writer) error { return errors.New("app not found") }
+	result, err = Run(context.Background(), def, opts)
+	if err == nil || !strings.Contains(err.Error(), "app not found") || result.Steps[0].Status != "error" {
+		t.Fatalf("expected command error, got %v (%+v)", err, result)
+	}
+}
+
+func TestRun_DryRunUsesDoesNotCallRunner(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{{Uses: "asc builds latest", With: map[string]string{"app": "$APP_ID"}}}},
+		},
+	}
+	opts := runOpts("release")
+	opts.DryRun = true
+	opts.ASC = func(context.Context, []string, io.Writer) error {
+		t.Fatal("dry run must not call the runner")
+		return nil
+	}
+
+	if _, err := Run(context.Background(), def, opts); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if stderr := opts.Stderr.(*bytes.Buffer).String(); !strings.Contains(stderr, "[dry-run] step 1: uses asc builds latest --app=$APP_ID") {
+		t.Fatalf("unexpected dry-run output %q", stderr)
+	}
+}
+
+func TestResultOutputs_IgnoresNonObjectResults(t *testing.T) {
+	for _, stdout := range []string{"", "not json", `[{"id":"1"}]`, `{"data":[{"id":"1"}]}`} {
+		if outputs := resultOutputs([]byte(stdout)); outputs != nil {
+			t.Fatalf("expected no outputs for %q, got %v", stdout, outputs)
+		}
+	}
+}
+
+func TestValidate_UsesSteps(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"main": {Steps: []Step{
+				{Name: "latest", Uses: "asc builds latest", With: map[string]string{"app": "$APP_ID"}},
+				{Uses: "builds latest"},
+				{Uses: "asc builds latest --app 1"},
+				{Uses: "asc workflow run other"},
+				{Uses: "asc Workflow run other"},
+				{Uses: "asc apps list", Run: "echo"},
+				{Uses: "asc apps list", With: map[string]string{"--app": "1"}},
+				{Name: "latest", Uses: "asc builds latest"},
+			}},
+		},
+	}
+
+	errs := Validate(def)
+	var got []string
+	for _, err := range errs {
+		got = append(got, fmt.Sprintf("%d %s", err.Step, err.Code))
+	}
+	want := []string{
+		"2 invalid_uses",
+		"3 invalid_uses",
+		"4 invalid_uses",
+		"5 invalid_uses",
+		"6 step_run_and_workflow",
+		"7 invalid_uses",
+		"8 duplicate_output_producer_name",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+}
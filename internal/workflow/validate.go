@@ -3,9 +3,11 @@ package workflow
 import (
 	"fmt"
 	"maps"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 )
 
 // ValidationCode classifies validation failures.
@@ -26,6 +28,22 @@ const (
 	ErrDuplicateOutputProducerName ValidationCode = "duplicate_output_producer_name"
 	ErrInvalidOutputName           ValidationCode = "invalid_output_name"
 	ErrInvalidOutputExpr           ValidationCode = "invalid_output_expr"
+	ErrInvalidMaxParallel          ValidationCode = "invalid_max_parallel"
+	ErrStepParallelConflict        ValidationCode = "step_parallel_conflict"
+	ErrParallelStepInvalid         ValidationCode = "parallel_step_invalid"
+	ErrInvalidStepID               ValidationCode = "invalid_step_id"
+	ErrDuplicateStepID             ValidationCode = "duplicate_step_id"
+	ErrUnknownStepNeed             ValidationCode = "unknown_step_need"
+	ErrStepNeedsCycle              ValidationCode = "step_needs_cycle"
+	ErrInvalidRetry                ValidationCode = "invalid_retry"
+	ErrInvalidTimeout              ValidationCode = "invalid_timeout"
+	ErrInvalidCondition            ValidationCode = "invalid_condition"
+	ErrInvalidMatrix               ValidationCode = "invalid_matrix"
+	ErrInvalidUses                 ValidationCode = "invalid_uses"
+	ErrImportCycle                 ValidationCode = "import_cycle"
+	ErrInvalidInput                ValidationCode = "invalid_input"
+	ErrMissingInput                ValidationCode = "missing_input"
+	ErrUnknownInput                ValidationCode = "unknown_input"
 )
 
 // ValidationError describes a structured workflow validation failure.
@@ -45,6 +63,7 @@ var (
 	validWorkflowName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
 	validOutputName   = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
 	validOutputExpr   = regexp.MustCompile(`^\$\.[a-zA-Z0-9_]+(?:\.[a-zA-Z0-9_]+)*$`)
+	validInputName    = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
 )
 
 // Validate checks a Definition for structural errors.
@@ -77,6 +96,15 @@ func Validate(def *Definition) []*ValidationError {
 
 	for _, name := range names {
 		wf := def.Workflows[name]
+		if wf.MaxParallel < 0 {
+			errs = append(errs, &ValidationError{
+				Code:     ErrInvalidMaxParallel,
+				Workflow: name,
+				Message:  fmt.Sprintf("workflow %q max_parallel must be greater than or equal to 0", name),
+			})
+		}
+		errs = append(errs, validateMatrix(name, 0, fmt.Sprintf("workflow %q", name), wf.Matrix)...)
+		errs = append(errs, validateInputs(name, wf.Inputs)...)
 		if len(wf.Steps) == 0 {
 			errs = append(errs, &ValidationError{
 				Code:     ErrEmptySteps,
@@ -88,118 +116,510 @@ func Validate(def *Definition) []*ValidationError {
 
 		for i, step := range wf.Steps {
 			idx := i + 1
-			hasRun := strings.TrimSpace(step.Run) != ""
-			hasWorkflow := strings.TrimSpace(step.Workflow) != ""
-			hasRawRun := step.Run != ""
-
-			if !hasRun && !hasWorkflow {
-				if hasRawRun {
-					errs = append(errs, &ValidationError{
-						Code:     ErrStepEmptyRun,
-						Workflow: name,
-						Step:     idx,
-						Message:  fmt.Sprintf("workflow %q step %d has empty run command", name, idx),
-					})
-				} else {
-					errs = append(errs, &ValidationError{
-						Code:     ErrStepNoAction,
-						Workflow: name,
-						Step:     idx,
-						Message:  fmt.Sprintf("workflow %q step %d must have run or workflow", name, idx),
-					})
+			label := fmt.Sprintf("step %d", idx)
+			errs = append(errs, validateStep(def, name, idx, label, step, outputProducerWorkflows)...)
+
+			if len(step.Parallel) > 0 {
+				for j, child := range step.Parallel {
+					childLabel := fmt.Sprintf("step %d parallel step %d", idx, j+1)
+					if len(child.Parallel) > 0 || len(child.Needs) > 0 || strings.TrimSpace(child.ID) != "" {
+						errs = append(errs, &ValidationError{
+							Code:     ErrParallelStepInvalid,
+							Workflow: name,
+							Step:     idx,
+							Message:  fmt.Sprintf("workflow %q %s cannot declare id, needs or parallel", name, childLabel),
+						})
+					}
+					errs = append(errs, validateStep(def, name, idx, childLabel, child, outputProducerWorkflows)...)
 				}
 			}
+		}
+
+		errs = append(errs, validateStepGraph(name, wf.Steps)...)
+	}
+
+	if cycleErr := detectCycles(def); cycleErr != nil {
+		errs = append(errs, cycleErr)
+	}
+	if cycleErr := detectImportCycle(def); cycleErr != nil {
+		errs = append(errs, cycleErr)
+	}
+
+	return errs
+}
+
+// validateStep checks one step. label names the step in messages, e.g.
+// "step 2" or "step 2 parallel step 1".
+func validateStep(def *Definition, name string, idx int, label string, step Step, outputProducerWorkflows map[string]string) []*ValidationError {
+	var errs []*ValidationError
+
+	hasRun := strings.TrimSpace(step.Run) != ""
+	hasWorkflow := strings.TrimSpace(step.Workflow) != ""
+	hasUses := strings.TrimSpace(step.Uses) != ""
+	hasParallel := len(step.Parallel) > 0
+	hasRawRun := step.Run != ""
+
+	if _, err := parseCondition(step.If); err != nil {
+		errs = append(errs, &ValidationError{
+			Code:     ErrInvalidCondition,
+			Workflow: name,
+			Step:     idx,
+			Message:  fmt.Sprintf("workflow %q %s has invalid if %q: %v", name, label, step.If, err),
+		})
+	}
+
+	if hasParallel {
+		if hasRawRun || hasWorkflow || hasUses || len(step.With) > 0 || len(step.Outputs) > 0 || hasStepPolicy(step) || step.Matrix != nil {
+			errs = append(errs, &ValidationError{
+				Code:     ErrStepParallelConflict,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q %s has parallel with run, workflow, uses, with, outputs, retry, timeout, continue_on_error or matrix (a parallel group only runs its steps)", name, label),
+			})
+		}
+		return errs
+	}
+
+	errs = append(errs, validateMatrix(name, idx, fmt.Sprintf("workflow %q %s", name, label), step.Matrix)...)
 
-			if hasRun && hasWorkflow {
+	if step.Retry != nil {
+		if step.Retry.Attempts < 1 {
+			errs = append(errs, &ValidationError{
+				Code:     ErrInvalidRetry,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q %s retry.attempts must be at least 1", name, label),
+			})
+		}
+		if backoff := strings.TrimSpace(step.Retry.Backoff); backoff != "" {
+			if d, err := time.ParseDuration(backoff); err != nil || d < 0 {
 				errs = append(errs, &ValidationError{
-					Code:     ErrStepConflict,
+					Code:     ErrInvalidRetry,
 					Workflow: name,
 					Step:     idx,
-					Message:  fmt.Sprintf("workflow %q step %d has both run and workflow (only one allowed)", name, idx),
+					Message:  fmt.Sprintf("workflow %q %s retry.backoff %q must be a non-negative duration like 30s", name, label, step.Retry.Backoff),
 				})
 			}
+		}
+	}
+
+	if timeout := strings.TrimSpace(step.Timeout); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err != nil || d <= 0 {
+			errs = append(errs, &ValidationError{
+				Code:     ErrInvalidTimeout,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q %s timeout %q must be a positive duration like 10m", name, label, step.Timeout),
+			})
+		}
+	}
+
+	if !hasRun && !hasWorkflow && !hasUses {
+		if hasRawRun {
+			errs = append(errs, &ValidationError{
+				Code:     ErrStepEmptyRun,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q %s has empty run command", name, label),
+			})
+		} else {
+			errs = append(errs, &ValidationError{
+				Code:     ErrStepNoAction,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q %s must have run, workflow, uses or parallel", name, label),
+			})
+		}
+	}
 
-			if hasRun && len(step.With) > 0 {
+	if hasRun && hasWorkflow {
+		errs = append(errs, &ValidationError{
+			Code:     ErrStepConflict,
+			Workflow: name,
+			Step:     idx,
+			Message:  fmt.Sprintf("workflow %q %s has both run and workflow (only one allowed)", name, label),
+		})
+	}
+
+	if hasUses {
+		if hasRun || hasWorkflow {
+			errs = append(errs, &ValidationError{
+				Code:     ErrStepConflict,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q %s has uses with run or workflow (only one allowed)", name, label),
+			})
+		}
+		if _, err := usesCommand(step.Uses); err != nil {
+			errs = append(errs, &ValidationError{
+				Code:     ErrInvalidUses,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q %s: %v", name, label, err),
+			})
+		}
+		for _, flagName := range slices.Sorted(maps.Keys(step.With)) {
+			if !validFlagName.MatchString(flagName) {
 				errs = append(errs, &ValidationError{
-					Code:     ErrStepWithOnRun,
+					Code:     ErrInvalidUses,
 					Workflow: name,
 					Step:     idx,
-					Message:  fmt.Sprintf("workflow %q step %d has 'with' on a run step (only allowed on workflow steps)", name, idx),
+					Message:  fmt.Sprintf("workflow %q %s has invalid with flag %q (use the flag name without dashes, such as \"app\")", name, label, flagName),
 				})
 			}
+		}
+		// Uses steps publish their JSON result as outputs under their name.
+		trimmedName := strings.TrimSpace(step.Name)
+		if len(step.Outputs) == 0 && validWorkflowName.MatchString(trimmedName) {
+			if prevWorkflow, exists := outputProducerWorkflows[trimmedName]; exists {
+				errs = append(errs, &ValidationError{
+					Code:     ErrDuplicateOutputProducerName,
+					Workflow: name,
+					Step:     idx,
+					Message:  fmt.Sprintf("workflow %q %s reuses output-producing step name %q already declared in workflow %q", name, label, trimmedName, prevWorkflow),
+				})
+			} else {
+				outputProducerWorkflows[trimmedName] = name
+			}
+		}
+	}
 
-			if len(step.Outputs) > 0 {
-				if hasWorkflow {
-					errs = append(errs, &ValidationError{
-						Code:     ErrStepOutputsOnWorkflow,
-						Workflow: name,
-						Step:     idx,
-						Message:  fmt.Sprintf("workflow %q step %d has 'outputs' on a workflow step (only allowed on run steps)", name, idx),
-					})
-				}
+	if hasRun && len(step.With) > 0 {
+		errs = append(errs, &ValidationError{
+			Code:     ErrStepWithOnRun,
+			Workflow: name,
+			Step:     idx,
+			Message:  fmt.Sprintf("workflow %q %s has 'with' on a run step (only allowed on workflow and uses steps)", name, label),
+		})
+	}
 
-				trimmedName := strings.TrimSpace(step.Name)
-				if trimmedName == "" || !validWorkflowName.MatchString(trimmedName) {
-					errs = append(errs, &ValidationError{
-						Code:     ErrStepOutputsRequireName,
-						Workflow: name,
-						Step:     idx,
-						Message:  fmt.Sprintf("workflow %q step %d must use a reference-safe 'name' when declaring outputs", name, idx),
-					})
-				} else {
-					if prevWorkflow, exists := outputProducerWorkflows[trimmedName]; exists {
-						errs = append(errs, &ValidationError{
-							Code:     ErrDuplicateOutputProducerName,
-							Workflow: name,
-							Step:     idx,
-							Message:  fmt.Sprintf("workflow %q step %d reuses output-producing step name %q already declared in workflow %q", name, idx, trimmedName, prevWorkflow),
-						})
-					} else {
-						outputProducerWorkflows[trimmedName] = name
-					}
-				}
+	if len(step.Outputs) > 0 {
+		if hasWorkflow {
+			errs = append(errs, &ValidationError{
+				Code:     ErrStepOutputsOnWorkflow,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q %s has 'outputs' on a workflow step (only allowed on run steps)", name, label),
+			})
+		}
 
-				for _, outputName := range slices.Sorted(maps.Keys(step.Outputs)) {
-					if !validOutputName.MatchString(outputName) {
-						errs = append(errs, &ValidationError{
-							Code:     ErrInvalidOutputName,
-							Workflow: name,
-							Step:     idx,
-							Message:  fmt.Sprintf("workflow %q step %d has invalid output name %q", name, idx, outputName),
-						})
-					}
-					if !validOutputExpr.MatchString(strings.TrimSpace(step.Outputs[outputName])) {
-						errs = append(errs, &ValidationError{
-							Code:     ErrInvalidOutputExpr,
-							Workflow: name,
-							Step:     idx,
-							Message:  fmt.Sprintf("workflow %q step %d output %q must use a JSON path like $.field", name, idx, outputName),
-						})
-					}
-				}
+		trimmedName := strings.TrimSpace(step.Name)
+		if trimmedName == "" || !validWorkflowName.MatchString(trimmedName) {
+			errs = append(errs, &ValidationError{
+				Code:     ErrStepOutputsRequireName,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q %s must use a reference-safe 'name' when declaring outputs", name, label),
+			})
+		} else {
+			if prevWorkflow, exists := outputProducerWorkflows[trimmedName]; exists {
+				errs = append(errs, &ValidationError{
+					Code:     ErrDuplicateOutputProducerName,
+					Workflow: name,
+					Step:     idx,
+					Message:  fmt.Sprintf("workflow %q %s reuses output-producing step name %q already declared in workflow %q", name, label, trimmedName, prevWorkflow),
+				})
+			} else {
+				outputProducerWorkflows[trimmedName] = name
 			}
+		}
 
-			if hasWorkflow {
-				ref := strings.TrimSpace(step.Workflow)
-				if _, ok := def.Workflows[ref]; !ok {
-					errs = append(errs, &ValidationError{
-						Code:     ErrWorkflowNotFound,
-						Workflow: name,
-						Step:     idx,
-						Message:  fmt.Sprintf("workflow %q step %d references unknown workflow %q", name, idx, ref),
-					})
-				}
+		for _, outputName := range slices.Sorted(maps.Keys(step.Outputs)) {
+			if !validOutputName.MatchString(outputName) {
+				errs = append(errs, &ValidationError{
+					Code:     ErrInvalidOutputName,
+					Workflow: name,
+					Step:     idx,
+					Message:  fmt.Sprintf("workflow %q %s has invalid output name %q", name, label, outputName),
+				})
+			}
+			if !validOutputExpr.MatchString(strings.TrimSpace(step.Outputs[outputName])) {
+				errs = append(errs, &ValidationError{
+					Code:     ErrInvalidOutputExpr,
+					Workflow: name,
+					Step:     idx,
+					Message:  fmt.Sprintf("workflow %q %s output %q must use a JSON path like $.field", name, label, outputName),
+				})
 			}
 		}
 	}
 
-	if cycleErr := detectCycles(def); cycleErr != nil {
-		errs = append(errs, cycleErr)
+	if hasWorkflow {
+		ref := strings.TrimSpace(step.Workflow)
+		if target, ok := def.Workflows[ref]; !ok {
+			errs = append(errs, &ValidationError{
+				Code:     ErrWorkflowNotFound,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q %s references unknown workflow %q", name, label, ref),
+			})
+		} else {
+			errs = append(errs, validateCallInputs(name, idx, label, ref, target.Inputs, step.With)...)
+		}
+	}
+
+	return errs
+}
+
+// validateInputs checks a workflow's input declarations.
+func validateInputs(name string, inputs map[string]Input) []*ValidationError {
+	var errs []*ValidationError
+	for _, inputName := range slices.Sorted(maps.Keys(inputs)) {
+		input := inputs[inputName]
+		invalid := func(format string, args ...any) {
+			errs = append(errs, &ValidationError{
+				Code:     ErrInvalidInput,
+				Workflow: name,
+				Message:  fmt.Sprintf("workflow %q input %q ", name, inputName) + fmt.Sprintf(format, args...),
+			})
+		}
+
+		if !validInputName.MatchString(inputName) {
+			invalid("must start with a letter or underscore and contain only letters, digits, underscores")
+		}
+		inputType := input.inputType()
+		switch inputType {
+		case inputTypeString, inputTypeNumber, inputTypeBoolean:
+		default:
+			invalid("has unknown type %q (use string, number or boolean)", input.Type)
+			continue
+		}
+		if input.Default == nil {
+			continue
+		}
+		if input.Required {
+			invalid("cannot be required and have a default")
+		}
+		switch input.Default.(type) {
+		case string, bool, float64:
+			value, _ := input.defaultValue()
+			if _, err := normalizeInputValue(inputType, value); err != nil {
+				invalid("default %v", err)
+			}
+		default:
+			invalid("default must be a string, number or boolean")
+		}
+	}
+	return errs
+}
+
+// validateCallInputs checks the with values a workflow step passes against
+// the inputs its target declares. Targets without inputs accept any with
+// keys. Values that reference step outputs are only known at run time and
+// are type-checked then.
+func validateCallInputs(name string, idx int, label, ref string, inputs map[string]Input, with map[string]string) []*ValidationError {
+	if len(inputs) == 0 {
+		return nil
 	}
 
+	var errs []*ValidationError
+	for _, inputName := range slices.Sorted(maps.Keys(inputs)) {
+		input := inputs[inputName]
+		value, ok := with[inputName]
+		if !ok {
+			if input.Required {
+				errs = append(errs, &ValidationError{
+					Code:     ErrMissingInput,
+					Workflow: name,
+					Step:     idx,
+					Message:  fmt.Sprintf("workflow %q %s does not pass required input %q to workflow %q", name, label, inputName, ref),
+				})
+			}
+			continue
+		}
+		if stepOutputPattern.MatchString(value) {
+			continue
+		}
+		if _, err := normalizeInputValue(input.inputType(), value); err != nil {
+			errs = append(errs, &ValidationError{
+				Code:     ErrInvalidInput,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q %s passes input %q to workflow %q: %v", name, label, inputName, ref, err),
+			})
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(with)) {
+		if _, declared := inputs[key]; !declared {
+			errs = append(errs, &ValidationError{
+				Code:     ErrUnknownInput,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q %s passes %q, which workflow %q does not declare as an input", name, label, key, ref),
+			})
+		}
+	}
 	return errs
 }
 
+// validateMatrix checks a workflow or step matrix. subject names its owner in
+// messages, e.g. `workflow "release" step 2`.
+func validateMatrix(name string, idx int, subject string, matrix *Matrix) []*ValidationError {
+	if matrix == nil {
+		return nil
+	}
+
+	var errs []*ValidationError
+	invalid := func(format string, args ...any) {
+		errs = append(errs, &ValidationError{
+			Code:     ErrInvalidMatrix,
+			Workflow: name,
+			Step:     idx,
+			Message:  subject + " matrix " + fmt.Sprintf(format, args...),
+		})
+	}
+
+	if len(matrix.Axes) == 0 {
+		invalid("must declare at least one axis")
+	}
+	for _, axis := range slices.Sorted(maps.Keys(matrix.Axes)) {
+		if !validOutputName.MatchString(axis) {
+			invalid("axis %q must start with a letter and contain only letters, digits, underscores", axis)
+		}
+		if len(matrix.Axes[axis]) == 0 {
+			invalid("axis %q must have at least one value", axis)
+		}
+		if dup := firstDuplicate(matrix.Axes[axis]); dup != "" {
+			invalid("axis %q repeats value %q", axis, dup)
+		}
+	}
+	if matrix.combinationCount() < 0 {
+		invalid("expands to more than %d combinations", MaxMatrixCombinations)
+	}
+	if matrix.MaxParallel < 0 {
+		invalid("max_parallel must be greater than or equal to 0")
+	}
+	return errs
+}
+
+func firstDuplicate(values []string) string {
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		if seen[value] {
+			return value
+		}
+		seen[value] = true
+	}
+	return ""
+}
+
+// validateStepGraph checks step ids and needs within one workflow and
+// rejects dependency cycles.
+func validateStepGraph(name string, steps []Step) []*ValidationError {
+	var errs []*ValidationError
+
+	indexByID := map[string]int{}
+	for i, step := range steps {
+		id := strings.TrimSpace(step.ID)
+		if id == "" {
+			continue
+		}
+		idx := i + 1
+		if !validWorkflowName.MatchString(id) {
+			errs = append(errs, &ValidationError{
+				Code:     ErrInvalidStepID,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q step %d id %q must start with a letter and contain only letters, digits, hyphens, underscores", name, idx, id),
+			})
+			continue
+		}
+		if prev, exists := indexByID[id]; exists {
+			errs = append(errs, &ValidationError{
+				Code:     ErrDuplicateStepID,
+				Workflow: name,
+				Step:     idx,
+				Message:  fmt.Sprintf("workflow %q step %d reuses id %q already declared by step %d", name, idx, id, prev+1),
+			})
+			continue
+		}
+		indexByID[id] = i
+	}
+
+	needs := make([][]int, len(steps))
+	for i, step := range steps {
+		idx := i + 1
+		for _, need := range step.Needs {
+			dep, ok := indexByID[strings.TrimSpace(need)]
+			if !ok {
+				errs = append(errs, &ValidationError{
+					Code:     ErrUnknownStepNeed,
+					Workflow: name,
+					Step:     idx,
+					Message:  fmt.Sprintf("workflow %q step %d needs unknown step id %q", name, idx, need),
+				})
+				continue
+			}
+			if dep == i {
+				errs = append(errs, &ValidationError{
+					Code:     ErrStepNeedsCycle,
+					Workflow: name,
+					Step:     idx,
+					Message:  fmt.Sprintf("workflow %q step %d needs itself", name, idx),
+				})
+				continue
+			}
+			needs[i] = append(needs[i], dep)
+		}
+	}
+
+	if cycle := findNeedsCycle(steps, needs); len(cycle) > 0 {
+		errs = append(errs, &ValidationError{
+			Code:     ErrStepNeedsCycle,
+			Workflow: name,
+			Message:  fmt.Sprintf("workflow %q has cyclic step needs: %s", name, strings.Join(cycle, " -> ")),
+		})
+	}
+
+	return errs
+}
+
+// findNeedsCycle returns the step ids along the first needs cycle found,
+// using the same white/gray/black DFS as detectCycles.
+func findNeedsCycle(steps []Step, needs [][]int) []string {
+	const (
+		white = 0
+		gray  = 1
+		black = 2
+	)
+
+	colors := make([]int, len(steps))
+	var path []int
+
+	var dfs func(i int) []string
+	dfs = func(i int) []string {
+		colors[i] = gray
+		path = append(path, i)
+		for _, dep := range needs[i] {
+			switch colors[dep] {
+			case gray:
+				start := slices.Index(path, dep)
+				cycle := make([]string, 0, len(path)-start+1)
+				for _, p := range path[start:] {
+					cycle = append(cycle, strings.TrimSpace(steps[p].ID))
+				}
+				return append(cycle, strings.TrimSpace(steps[dep].ID))
+			case white:
+				if cycle := dfs(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		colors[i] = black
+		return nil
+	}
+
+	for i := range steps {
+		if colors[i] == white {
+			if cycle := dfs(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
 // detectCycles performs DFS across all workflows to find circular references.
 // Uses white(0)/gray(1)/black(2) coloring.
 func detectCycles(def *Definition) *ValidationError {
@@ -224,7 +644,7 @@ func detectCycles(def *Definition) *ValidationError {
 			return nil
 		}
 
-		for _, step := range wf.Steps {
+		for _, step := range flattenSteps(wf.Steps) {
 			ref := strings.TrimSpace(step.Workflow)
 			if ref == "" {
 				continue
@@ -266,3 +686,72 @@ func detectCycles(def *Definition) *ValidationError {
 	}
 	return nil
 }
+
+// detectImportCycle walks the import graph recorded by LoadUnvalidated from
+// the root file and reports the first cycle, naming files relative to the
+// root file's directory.
+func detectImportCycle(def *Definition) *ValidationError {
+	if def.file == "" || len(def.importGraph) == 0 {
+		return nil
+	}
+
+	const (
+		white = 0
+		gray  = 1
+		black = 2
+	)
+
+	display := func(file string) string {
+		if rel, err := filepath.Rel(filepath.Dir(def.file), file); err == nil {
+			return rel
+		}
+		return file
+	}
+
+	colors := map[string]int{}
+	var path []string
+
+	var dfs func(file string) *ValidationError
+	dfs = func(file string) *ValidationError {
+		colors[file] = gray
+		path = append(path, file)
+
+		for _, target := range def.importGraph[file] {
+			switch colors[target] {
+			case gray:
+				cycleStart := slices.Index(path, target)
+				cycle := make([]string, 0, len(path)-cycleStart+1)
+				for _, p := range path[cycleStart:] {
+					cycle = append(cycle, display(p))
+				}
+				cycle = append(cycle, display(target))
+				return &ValidationError{
+					Code:    ErrImportCycle,
+					Message: fmt.Sprintf("cyclic workflow import: %s", strings.Join(cycle, " -> ")),
+				}
+			case white:
+				if err := dfs(target); err != nil {
+					return err
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		colors[file] = black
+		return nil
+	}
+	return dfs(def.file)
+}
+
+// flattenSteps returns steps with each parallel group replaced by its steps.
+func flattenSteps(steps []Step) []Step {
+	flat := make([]Step, 0, len(steps))
+	for _, step := range steps {
+		if len(step.Parallel) > 0 {
+			flat = append(flat, step.Parallel...)
+			continue
+		}
+		flat = append(flat, step)
+	}
+	return flat
+}
@@ -3,8 +3,11 @@ package workflow
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -635,3 +638,330 @@ func TestLoad_ReturnsAllValidationErrors(t *testing.T) {
 		t.Fatalf("expected errors.As to find ValidationError, got %T: %v", err, err)
 	}
 }
+
+func TestValidate_StepNeeds(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{
+				{ID: "build", Run: "echo build"},
+				{ID: "build", Run: "echo again"},
+				{ID: "1bad", Run: "echo bad"},
+				{ID: "submit", Needs: []string{"missing"}, Run: "echo submit"},
+			}},
+		},
+	}
+	errs := Validate(def)
+	assertValidationCode(t, errs, ErrDuplicateStepID)
+	assertValidationCode(t, errs, ErrInvalidStepID)
+	assertValidationCode(t, errs, ErrUnknownStepNeed)
+}
+
+func TestValidate_StepNeedsCycle(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{
+				{ID: "a", Needs: []string{"c"}, Run: "echo a"},
+				{ID: "b", Needs: []string{"a"}, Run: "echo b"},
+				{ID: "c", Needs: []string{"b"}, Run: "echo c"},
+			}},
+		},
+	}
+	errs := Validate(def)
+	assertValidationCode(t, errs, ErrStepNeedsCycle)
+	for _, e := range errs {
+		if e.Code == ErrStepNeedsCycle && !strings.Contains(e.Message, "a -> c -> b -> a") {
+			t.Fatalf("unexpected cycle message %q", e.Message)
+		}
+	}
+}
+
+func TestValidate_ParallelGroups(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {MaxParallel: -1, Steps: []Step{
+				{Run: "echo conflict", Parallel: []Step{{Run: "echo a"}}},
+				{Parallel: []Step{
+					{ID: "nested", Run: "echo b"},
+					{Workflow: "missing"},
+				}},
+			}},
+		},
+	}
+	errs := Validate(def)
+	assertValidationCode(t, errs, ErrInvalidMaxParallel)
+	assertValidationCode(t, errs, ErrStepParallelConflict)
+	assertValidationCode(t, errs, ErrParallelStepInvalid)
+	assertValidationCode(t, errs, ErrWorkflowNotFound)
+}
+
+func TestValidate_ParallelSubWorkflowCycle(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"a": {Steps: []Step{{Parallel: []Step{{Workflow: "b"}, {Run: "echo a"}}}}},
+			"b": {Steps: []Step{{Workflow: "a"}}},
+		},
+	}
+	errs := Validate(def)
+	assertValidationCode(t, errs, ErrCyclicReference)
+}
+
+func TestValidate_RetryAndTimeout(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{
+				{Run: "echo a", Retry: &RetryPolicy{Attempts: 0}},
+				{Run: "echo b", Retry: &RetryPolicy{Attempts: 2, Backoff: "soon"}},
+				{Run: "echo c", Timeout: "0s"},
+				{Parallel: []Step{{Run: "echo d"}}, ContinueOnError: true},
+			}},
+		},
+	}
+	errs := Validate(def)
+	assertValidationCode(t, errs, ErrInvalidRetry)
+	assertValidationCode(t, errs, ErrInvalidTimeout)
+	assertValidationCode(t, errs, ErrStepParallelConflict)
+
+	valid := &Definition{
+		Workflows: map[string]Workflow{
+			"release": {Steps: []Step{{Run: "asc builds wait", Retry: &RetryPolicy{Attempts: 3, Backoff: "30s"}, Timeout: "2h", ContinueOnError: true}}},
+		},
+	}
+	if errs := Validate(valid); len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}
+
+func TestValidate_InvalidIfExpression(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"main": {Steps: []Step{
+				{Run: "echo ok", If: "steps.upload.BUILD_ID != '' && env.PLATFORM == 'IOS'"},
+				{Run: "echo bad", If: "startsWith(env.PLATFORM)"},
+				{Parallel: []Step{{Run: "echo nested", If: "env.PLATFORM ==="}}},
+			}},
+		},
+	}
+
+	errs := Validate(def)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	for _, err := range errs {
+		if err.Code != ErrInvalidCondition {
+			t.Fatalf("expected invalid_condition, got %+v", err)
+		}
+	}
+	if !strings.Contains(errs[0].Message, "startsWith() takes 2 arguments") {
+		t.Fatalf("unexpected message %q", errs[0].Message)
+	}
+}
+
+func TestLoad_MatrixAcceptsScalarValuesAndOptions(t *testing.T) {
+	dir := t.TempDir()
+	path := writeWorkflowFile(t, dir, `{
+		"workflows": {
+			"release": {
+				"matrix": {"platform": ["IOS", "MAC_OS"], "shard": [1, 2], "max_parallel": 2, "fail_fast": false},
+				"steps": ["echo $MATRIX_PLATFORM $MATRIX_SHARD"]
+			}
+		}
+	}`)
+
+	def, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	matrix := def.Workflows["release"].Matrix
+	if matrix == nil || matrix.MaxParallel != 2 || matrix.failFast() {
+		t.Fatalf("unexpected matrix options %+v", matrix)
+	}
+	if got := matrix.Axes["shard"]; len(got) != 2 || got[0] != "1" || got[1] != "2" {
+		t.Fatalf("expected numeric values as strings, got %v", got)
+	}
+	if len(matrix.combinations()) != 4 {
+		t.Fatalf("expected 4 combinations, got %v", matrix.combinations())
+	}
+}
+
+func TestValidate_InvalidMatrix(t *testing.T) {
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"main": {
+				Matrix: &Matrix{},
+				Steps: []Step{
+					{Run: "echo a", Matrix: &Matrix{Axes: map[string][]string{"app": {}, "bad-axis": {"x"}}}},
+					{Run: "echo b", Matrix: &Matrix{Axes: map[string][]string{"app": {"A", "A"}}, MaxParallel: -1}},
+					{Parallel: []Step{{Run: "echo c"}}, Matrix: &Matrix{Axes: map[string][]string{"app": {"A"}}}},
+				},
+			},
+		},
+	}
+
+	errs := Validate(def)
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Message)
+	}
+	want := []string{
+		`workflow "main" matrix must declare at least one axis`,
+		`workflow "main" step 1 matrix axis "app" must have at least one value`,
+		`workflow "main" step 1 matrix axis "bad-axis" must start with a letter and contain only letters, digits, underscores`,
+		`workflow "main" step 2 matrix axis "app" repeats value "A"`,
+		`workflow "main" step 2 matrix max_parallel must be greater than or equal to 0`,
+		`workflow "main" step 3 has parallel with run, workflow, uses, with, outputs, retry, timeout, continue_on_error or matrix (a parallel group only runs its steps)`,
+	}
+	if !slices.Equal(messages, want) {
+		t.Fatalf("unexpected errors:\n%s", strings.Join(messages, "\n"))
+	}
+}
+
+func TestValidate_MatrixCombinationLimit(t *testing.T) {
+	values := make([]string, 20)
+	for i := range values {
+		values[i] = fmt.Sprint(i)
+	}
+	def := &Definition{
+		Workflows: map[string]Workflow{
+			"main": {
+				Matrix: &Matrix{Axes: map[string][]string{"a": values, "b": values}},
+				Steps:  []Step{{Run: "echo $MATRIX_A"}},
+			},
+		},
+	}
+
+	errs := Validate(def)
+	if len(errs) != 1 || errs[0].Code != ErrInvalidMatrix || !strings.Contains(errs[0].Message, "more than 256 combinations") {
+		t.Fatalf("expected combination limit error, got %v", errs)
+	}
+}
+
+func TestLoad_ImportsScopeEnvToImportedWorkflows(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "shared"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "shared", "release-common.json"), []byte(`{
+		"imports": ["notify.json"],
+		"env": {"APP_ID": "imported", "TEAM": "ios"},
+		"workflows": {
+			"release-common": {"env": {"TEAM": "watch"}, "steps": [{"workflow": "notify"}]},
+			"release-ios": {"steps": ["echo ios"]}
+		}
+	}`), 0o600); err != nil {
+		t.Fatalf("write import: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "shared", "notify.json"), []byte(`{
+		"workflows": {"notify": {"private": true, "steps": ["echo done"]}}
+	}`), 0o600); err != nil {
+		t.Fatalf("write nested import: %v", err)
+	}
+	path := writeWorkflowFile(t, dir, `{
+		"imports": ["shared/release-common.json"],
+		"env": {"APP_ID": "root"},
+		"workflows": {
+			"release": {"steps": [{"workflow": "release-common"}]}
+		}
+	}`)
+
+	def, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	names := slices.Sorted(maps.Keys(def.Workflows))
+	if !slices.Equal(names, []string{"notify", "release", "release-common", "release-ios"}) {
+		t.Fatalf("unexpected workflows: %v", names)
+	}
+	if !maps.Equal(def.Env, map[string]string{"APP_ID": "root"}) {
+		t.Fatalf("expected imported env to stay out of the root env, got %v", def.Env)
+	}
+	if got := def.Workflows["release-common"].Env; !maps.Equal(got, map[string]string{"APP_ID": "imported", "TEAM": "watch"}) {
+		t.Fatalf("unexpected release-common env: %v", got)
+	}
+	if got := def.Workflows["release-ios"].Env; !maps.Equal(got, map[string]string{"APP_ID": "imported", "TEAM": "ios"}) {
+		t.Fatalf("unexpected release-ios env: %v", got)
+	}
+	if got := def.Workflows["release"].Env; len(got) != 0 {
+		t.Fatalf("expected root workflow env to stay empty, got %v", got)
+	}
+	if got := def.Workflows["notify"].Env; len(got) != 0 {
+		t.Fatalf("expected nested import env to be scoped to its own file, got %v", got)
+	}
+}
+
+func TestLoad_ImportErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		root    string
+		other   string
+		wantErr string
+	}{
+		{
+			name:    "duplicate workflow",
+			root:    `{"imports": ["other.json"], "workflows": {"beta": {"steps": ["echo root"]}}}`,
+			other:   `{"workflows": {"beta": {"steps": ["echo other"]}}}`,
+			wantErr: `import "other.json": workflow "beta" is already defined in`,
+		},
+		{
+			name:    "hooks in import",
+			root:    `{"imports": ["other.json"], "workflows": {"beta": {"steps": ["echo root"]}}}`,
+			other:   `{"before_all": "echo hi", "workflows": {"gamma": {"steps": ["echo other"]}}}`,
+			wantErr: "hooks (before_all, after_all, error) are only allowed in the root workflow file",
+		},
+		{
+			name:    "remote import",
+			root:    `{"imports": ["https://example.com/workflow.json"], "workflows": {"beta": {"steps": ["echo root"]}}}`,
+			wantErr: "only local file paths can be imported",
+		},
+		{
+			name:    "missing import",
+			root:    `{"imports": ["missing.json"], "workflows": {"beta": {"steps": ["echo root"]}}}`,
+			wantErr: `import "missing.json": read workflow`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if test.other != "" {
+				if err := os.WriteFile(filepath.Join(dir, "other.json"), []byte(test.other), 0o600); err != nil {
+					t.Fatalf("write import: %v", err)
+				}
+			}
+			_, err := Load(writeWorkflowFile(t, dir, test.root))
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoad_ImportCycle(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{
+		"imports": ["b.json"],
+		"workflows": {"a": {"steps": ["echo a"]}}
+	}`), 0o600); err != nil {
+		t.Fatalf("write a: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.json"), []byte(`{
+		"imports": ["a.json"],
+		"workflows": {"b": {"steps": ["echo b"]}}
+	}`), 0o600); err != nil {
+		t.Fatalf("write b: %v", err)
+	}
+	path := writeWorkflowFile(t, dir, `{
+		"imports": ["a.json"],
+		"workflows": {"main": {"steps": ["echo main"]}}
+	}`)
+
+	def, err := LoadUnvalidated(path)
+	if err != nil {
+		t.Fatalf("LoadUnvalidated: %v", err)
+	}
+	errs := Validate(def)
+	assertValidationCode(t, errs, ErrImportCycle)
+	want := "cyclic workflow import: a.json -> b.json -> a.json"
+	if errs[len(errs)-1].Message != want {
+		t.Fatalf("expected %q, got %q", want, errs[len(errs)-1].Message)
+	}
+}
@@ -11,31 +11,80 @@ import (
 )
 
 // Definition is the top-level .asc/workflow.json schema.
+// Imports lists other workflow files, relative to this one, whose workflows
+// and env defaults are merged in at load time.
 type Definition struct {
+	Imports   []string            `json:"imports,omitempty"`
 	Env       map[string]string   `json:"env,omitempty"`
 	BeforeAll string              `json:"before_all,omitempty"`
 	AfterAll  string              `json:"after_all,omitempty"`
 	Error     string              `json:"error,omitempty"`
 	Workflows map[string]Workflow `json:"workflows"`
+
+	// file and importGraph are set by LoadUnvalidated: the loaded file and,
+	// for each file, the files it imports, all as absolute paths.
+	file        string
+	importGraph map[string][]string
 }
 
-// Workflow is a named automation sequence.
+// Workflow is a named automation sequence. A workflow with a Matrix runs its
+// steps once per combination. Inputs declares the typed parameters callers
+// pass in with (or as KEY:VALUE params when the workflow is run directly).
 type Workflow struct {
 	Description string            `json:"description,omitempty"`
 	Private     bool              `json:"private,omitempty"`
+	Inputs      map[string]Input  `json:"inputs,omitempty"`
 	Env         map[string]string `json:"env,omitempty"`
+	MaxParallel int               `json:"max_parallel,omitempty"`
+	Matrix      *Matrix           `json:"matrix,omitempty"`
 	Steps       []Step            `json:"steps"`
 }
 
 // Step is one executable action in a workflow.
 // Bare JSON strings unmarshal to Step{Run: "..."} as shorthand.
+//
+// A step with Parallel is a group whose child steps run concurrently.
+// When any step in a workflow declares Needs, the workflow runs as a
+// dependency graph keyed by step ID instead of in list order.
+// Retry, Timeout and ContinueOnError apply to run, uses and workflow steps.
+// If is either an environment variable name or a condition expression over
+// env, step outputs and step outcomes.
+// A step with Matrix runs once per combination of the matrix values.
+// Uses runs an asc command such as "asc builds latest" in-process, with With
+// supplying its flags; on workflow steps With supplies environment variables.
 type Step struct {
 	Run      string            `json:"run,omitempty"`
 	Workflow string            `json:"workflow,omitempty"`
+	Uses     string            `json:"uses,omitempty"`
+	Parallel []Step            `json:"parallel,omitempty"`
 	Name     string            `json:"name,omitempty"`
+	ID       string            `json:"id,omitempty"`
+	Needs    []string          `json:"needs,omitempty"`
 	If       string            `json:"if,omitempty"`
 	With     map[string]string `json:"with,omitempty"`
 	Outputs  map[string]string `json:"outputs,omitempty"`
+
+	Retry           *RetryPolicy `json:"retry,omitempty"`
+	Timeout         string       `json:"timeout,omitempty"`
+	ContinueOnError bool         `json:"continue_on_error,omitempty"`
+	Matrix          *Matrix      `json:"matrix,omitempty"`
+}
+
+// Input declares one workflow parameter. Type is "string" (the default),
+// "number" or "boolean". A required input has no default.
+type Input struct {
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Default     any    `json:"default,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// RetryPolicy re-runs a failed step. Attempts counts the first run; Backoff
+// is a Go duration waited before the second attempt and doubled after each
+// further failure.
+type RetryPolicy struct {
+	Attempts int    `json:"attempts"`
+	Backoff  string `json:"backoff,omitempty"`
 }
 
 // UnmarshalJSON handles the flexible step format: